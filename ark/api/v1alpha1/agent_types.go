@@ -29,7 +29,7 @@ type ToolPartial struct {
 
 type AgentTool struct {
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=built-in;custom
+	// +kubebuilder:validation:Enum=built-in;custom;selector
 	Type string `json:"type"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:validation:MinLength=1
@@ -44,6 +44,23 @@ type AgentTool struct {
 	// from the agent. Parameters defined here are injected at runtime and are not visible or
 	// editable by the agent itself.
 	Partial *ToolPartial `json:"partial,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Selector pulls in all Tools of the namespace matching a label selector, for the selector type
+	Selector *AgentToolSelector `json:"selector,omitempty"`
+}
+
+// AgentToolSelector selects Tools by label. Include and exclude are name patterns such as "create_*".
+type AgentToolSelector struct {
+	metav1.LabelSelector `json:",inline"`
+	// Tools to include by name. All matching Tools are included when empty
+	// +kubebuilder:validation:Optional
+	Include []string `json:"include,omitempty"`
+	// Tools to exclude by name, applied after include
+	// +kubebuilder:validation:Optional
+	Exclude []string `json:"exclude,omitempty"`
+	// NamePrefix is prepended to the names of the selected Tools as exposed to the agent
+	// +kubebuilder:validation:Optional
+	NamePrefix string `json:"namePrefix,omitempty"`
 }
 
 // GetToolCRDName returns the actual Tool CRD name to lookup in Kubernetes.
@@ -56,6 +73,32 @@ func (a *AgentTool) GetToolCRDName() string {
 	return a.Name
 }
 
+// AgentResource attaches a resource of an MCP server to the prompt of the agent. It is read each time the agent runs.
+type AgentResource struct {
+	// +kubebuilder:validation:Required
+	MCPServerRef MCPServerRef `json:"mcpServerRef"`
+	// URI of the resource on the MCP server
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	URI string `json:"uri"`
+}
+
+// AgentPromptSource reads the prompt of the agent from another source.
+type AgentPromptSource struct {
+	// MCPPrompt renders a prompt of an MCP server, with the agent parameters as its arguments
+	// +kubebuilder:validation:Optional
+	MCPPrompt *MCPPromptRef `json:"mcpPrompt,omitempty"`
+}
+
+// MCPPromptRef references a prompt of an MCP server.
+type MCPPromptRef struct {
+	// +kubebuilder:validation:Required
+	MCPServerRef MCPServerRef `json:"mcpServerRef"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+}
+
 type AgentModelRef struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
@@ -77,8 +120,11 @@ type ExecutionEngineRef struct {
 	Namespace string `json:"namespace,omitempty"`
 }
 type AgentSpec struct {
-	Prompt      string `json:"prompt,omitempty"`
-	Description string `json:"description,omitempty"`
+	Prompt string `json:"prompt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// PromptFrom reads the prompt from another source instead of the prompt field
+	PromptFrom  *AgentPromptSource `json:"promptFrom,omitempty"`
+	Description string             `json:"description,omitempty"`
 	// +kubebuilder:validation:Optional
 	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
 	// +kubebuilder:validation:Optional
@@ -93,6 +139,9 @@ type AgentSpec struct {
 	OutputSchema *runtime.RawExtension `json:"outputSchema,omitempty"`
 	// +kubebuilder:validation:Optional
 	Overrides []Override `json:"overrides,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Resources of MCP servers attached to the prompt as context
+	Resources []AgentResource `json:"resources,omitempty"`
 }
 
 type AgentStatus struct {
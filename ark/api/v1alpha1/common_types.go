@@ -84,6 +84,28 @@ type Header struct {
 	Value HeaderValue `json:"value"`
 }
 
+// Auth configures how requests to an external endpoint are authenticated.
+type Auth struct {
+	// OAuth2 client credentials flow. A bearer token is fetched, cached and refreshed before it expires
+	// +kubebuilder:validation:Optional
+	OAuth2 *OAuth2ClientCredentials `json:"oauth2,omitempty"`
+}
+
+type OAuth2ClientCredentials struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	TokenURL string `json:"tokenURL"`
+	// +kubebuilder:validation:Required
+	ClientID ValueSource `json:"clientID"`
+	// +kubebuilder:validation:Required
+	ClientSecret ValueSource `json:"clientSecret"`
+	// +kubebuilder:validation:Optional
+	Scopes []string `json:"scopes,omitempty"`
+	// Audience is sent as the audience parameter of the token request
+	// +kubebuilder:validation:Optional
+	Audience string `json:"audience,omitempty"`
+}
+
 type Override struct {
 	// +kubebuilder:validation:Required
 	Headers []Header `json:"headers"`
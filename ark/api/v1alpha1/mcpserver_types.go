@@ -7,10 +7,13 @@ import (
 )
 
 type MCPServerSpec struct {
-	// +kubebuilder:validation:Required
+	// Address of the server. Required by the http and sse transports.
+	// +kubebuilder:validation:Optional
 	Address ValueSource `json:"address"`
 	// +kubebuilder:validation:Optional
 	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	Auth *Auth `json:"auth,omitempty"`
 	// Timeout specifies the maximum duration for MCP tool calls to this server.
 	// Use this to support long-running operations (e.g., "5m", "10m", "30m").
 	// Defaults to "30s" if not specified.
@@ -18,14 +21,85 @@ type MCPServerSpec struct {
 	// +kubebuilder:default="30s"
 	Timeout string `json:"timeout,omitempty"`
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=http;sse
+	// +kubebuilder:validation:Enum=http;sse;stdio
 	// +kubebuilder:default="http"
 	Transport string `json:"transport,omitempty"`
+	// Command launches the server in the controller pod. Required by the stdio transport, which speaks to the
+	// server over its stdin and stdout.
+	// +kubebuilder:validation:Optional
+	Command *MCPServerCommand `json:"command,omitempty"`
 	// +kubebuilder:validation:Optional
 	Description string `json:"description,omitempty"`
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="1m"
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// UnavailableGracePeriod is how long the tools of the server are kept, marked unavailable, while the
+	// server cannot be reached. They are deleted once the server has been unreachable for longer.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	UnavailableGracePeriod *metav1.Duration `json:"unavailableGracePeriod,omitempty"`
+	// Tools selects which tools of the server are published as Tools, and how they are named and described.
+	// All tools are published, named after the server, when not set.
+	// +kubebuilder:validation:Optional
+	Tools *MCPServerTools `json:"tools,omitempty"`
+}
+
+// MCPServerCommand is the command of a stdio MCP server.
+type MCPServerCommand struct {
+	// Command is the executable. It must be available in the image of the controller, and allowed by its
+	// --mcp-stdio-allowed-commands flag.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Command string `json:"command"`
+	// +kubebuilder:validation:Optional
+	Args []string `json:"args,omitempty"`
+	// Env sets environment variables of the process, from values, Secrets or ConfigMaps
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Env []MCPServerEnvVar `json:"env,omitempty"`
+}
+
+// MCPServerEnvVar is an environment variable of the process of a stdio MCP server.
+type MCPServerEnvVar struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name        string `json:"name"`
+	ValueSource `json:",inline"`
+}
+
+// MCPServerTools selects and customizes the Tools generated for the tools of an MCP server.
+type MCPServerTools struct {
+	// Include publishes only the tools whose names match one of these glob patterns, such as "search_*".
+	// All tools are included when empty.
+	// +kubebuilder:validation:Optional
+	Include []string `json:"include,omitempty"`
+	// Exclude skips the tools whose names match one of these glob patterns, even if they are included.
+	// +kubebuilder:validation:Optional
+	Exclude []string `json:"exclude,omitempty"`
+	// NamePrefix is prepended to the tool names to name the generated Tools. Defaults to the name of the
+	// server followed by a dash.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^[a-z0-9][-a-z0-9.]*$`
+	NamePrefix string `json:"namePrefix,omitempty"`
+	// Overrides replace the description or annotations the server gives to individual tools.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Overrides []MCPToolOverride `json:"overrides,omitempty"`
+}
+
+// MCPToolOverride replaces what an MCP server says about one of its tools.
+type MCPToolOverride struct {
+	// Name of the tool on the MCP server
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+	// Description replaces the description given by the server
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// Annotations replace the annotations given by the server
+	// +kubebuilder:validation:Optional
+	Annotations *ToolAnnotations `json:"annotations,omitempty"`
 }
 
 // MCPServerStatus defines the observed state of MCPServer
@@ -38,16 +112,54 @@ type MCPServerStatus struct {
 	// +kubebuilder:validation:Optional
 	ToolCount int `json:"toolCount,omitempty"`
 
+	// ResourceCount represents the number of resources discovered from this MCP server
+	// +kubebuilder:validation:Optional
+	ResourceCount int `json:"resourceCount,omitempty"`
+
+	// PromptCount represents the number of prompts discovered from this MCP server
+	// +kubebuilder:validation:Optional
+	PromptCount int `json:"promptCount,omitempty"`
+
+	// LastSyncTime is when the tools of the server were last synchronized
+	// +kubebuilder:validation:Optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// SessionState is the state of the session the controller keeps open to the server to be notified of
+	// tool changes
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Enum=Connected;Polling;Disconnected
+	SessionState string `json:"sessionState,omitempty"`
+
+	// ProcessRestarts is the number of times the process of a stdio server exited or failed to start, since the
+	// controller started it
+	// +kubebuilder:validation:Optional
+	ProcessRestarts int32 `json:"processRestarts,omitempty"`
+
 	// Conditions represent the latest available observations of the MCP server's state
 	// +kubebuilder:validation:Optional
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+const (
+	// MCPSessionStateConnected means the server notifies the controller of tool changes
+	MCPSessionStateConnected = "Connected"
+	// MCPSessionStatePolling means the session is open, but the server does not notify tool changes, so tools
+	// are only synchronized on the poll interval
+	MCPSessionStatePolling = "Polling"
+	// MCPSessionStateDisconnected means no session is open to the server
+	MCPSessionStateDisconnected = "Disconnected"
+)
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.conditions[?(@.type=='Available')].status"
 // +kubebuilder:printcolumn:name="Discovering",type="string",JSONPath=".status.conditions[?(@.type=='Discovering')].status",description="Discovery status"
 // +kubebuilder:printcolumn:name="Tools",type="integer",JSONPath=".status.toolCount",description="Number of tools"
+// +kubebuilder:printcolumn:name="Resources",type="integer",JSONPath=".status.resourceCount",description="Number of resources",priority=1
+// +kubebuilder:printcolumn:name="Prompts",type="integer",JSONPath=".status.promptCount",description="Number of prompts",priority=1
+// +kubebuilder:printcolumn:name="Session",type="string",JSONPath=".status.sessionState",description="Session state",priority=1
+// +kubebuilder:printcolumn:name="Last Sync",type="date",JSONPath=".status.lastSyncTime",description="Last tool synchronization",priority=1
+// +kubebuilder:printcolumn:name="Restarts",type="integer",JSONPath=".status.processRestarts",description="Restarts of the stdio server process",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
 type MCPServer struct {
 	metav1.TypeMeta   `json:",inline"`
@@ -78,8 +78,18 @@ type ModelSpec struct {
 	// +kubebuilder:validation:Optional
 	// +kubebuilder:default="1m"
 	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+	// Capabilities lists the optional inputs the model accepts. Images returned by tools are only sent to
+	// models with the vision capability.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:items:Enum=vision
+	Capabilities []string `json:"capabilities,omitempty"`
 }
 
+// Model capability constants
+const (
+	ModelCapabilityVision = "vision"
+)
+
 type ModelStatus struct {
 	// +kubebuilder:validation:Optional
 	// ResolvedAddress contains the actual resolved base URL value
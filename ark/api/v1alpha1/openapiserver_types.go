@@ -0,0 +1,90 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// OpenAPIDocumentSource defines where the OpenAPI 3 document is read from. Exactly one source must be set.
+type OpenAPIDocumentSource struct {
+	// URL to fetch the document from
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	URL string `json:"url,omitempty"`
+	// ConfigMap key holding the document
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// Inline document in JSON or YAML
+	// +kubebuilder:validation:Optional
+	Inline string `json:"inline,omitempty"`
+}
+
+// OpenAPIOperationFilter selects the operations that become tools. Entries match the operationId
+// and support shell glob patterns such as "list*".
+type OpenAPIOperationFilter struct {
+	// Operations to include. All operations are included when empty
+	// +kubebuilder:validation:Optional
+	Include []string `json:"include,omitempty"`
+	// Operations to exclude, applied after include
+	// +kubebuilder:validation:Optional
+	Exclude []string `json:"exclude,omitempty"`
+}
+
+type OpenAPIServerSpec struct {
+	// +kubebuilder:validation:Required
+	Document OpenAPIDocumentSource `json:"document"`
+	// BaseURL overrides the first server URL of the document
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	BaseURL string `json:"baseURL,omitempty"`
+	// Headers sent with every generated tool call, for example for authentication
+	// +kubebuilder:validation:Optional
+	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=^[0-9]+[smh]?$
+	Timeout string `json:"timeout,omitempty"`
+	// +kubebuilder:validation:Optional
+	Operations *OpenAPIOperationFilter `json:"operations,omitempty"`
+	// +kubebuilder:validation:Optional
+	Description string `json:"description,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="5m"
+	PollInterval *metav1.Duration `json:"pollInterval,omitempty"`
+}
+
+// OpenAPIServerStatus defines the observed state of OpenAPIServer
+type OpenAPIServerStatus struct {
+	// ToolCount represents the number of tools generated from the document
+	// +kubebuilder:validation:Optional
+	ToolCount int `json:"toolCount,omitempty"`
+
+	// Conditions represent the latest available observations of the OpenAPI server's state
+	// +kubebuilder:validation:Optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Available",type="string",JSONPath=".status.conditions[?(@.type=='Available')].status"
+// +kubebuilder:printcolumn:name="Tools",type="integer",JSONPath=".status.toolCount",description="Number of tools"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age"
+type OpenAPIServer struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   OpenAPIServerSpec   `json:"spec,omitempty"`
+	Status OpenAPIServerStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+type OpenAPIServerList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []OpenAPIServer `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&OpenAPIServer{}, &OpenAPIServerList{})
+}
@@ -103,6 +103,17 @@ type Response struct {
 	// +kubebuilder:validation:Optional
 	// A2A contains optional A2A protocol metadata (contextId, taskId)
 	A2A *A2AMetadata `json:"a2a,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// TeamState is the final shared state of a team with state enabled, as a JSON object
+	TeamState *runtime.RawExtension `json:"teamState,omitempty"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// Vote is the outcome of a team with the vote strategy: the method, winner, votes and rationale, and the
+	// final answer of every member
+	Vote *runtime.RawExtension `json:"vote,omitempty"`
 }
 
 // +kubebuilder:object:root=true
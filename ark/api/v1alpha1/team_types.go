@@ -4,11 +4,46 @@ package v1alpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 )
 
+const (
+	HistoryPolicyFull      = "full"
+	HistoryPolicyUserInput = "userInput"
+	HistoryPolicyLastN     = "lastN"
+	HistoryPolicyMembers   = "members"
+	HistoryPolicySummary   = "summary"
+)
+
+type TeamMemberHistoryPolicy struct {
+	// +kubebuilder:validation:Enum=full;userInput;lastN;members;summary
+	// +kubebuilder:default=full
+	// Type selects which part of the accumulated team history the member sees
+	Type string `json:"type"`
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// LastN is the number of most recent messages kept for type lastN
+	LastN *int `json:"lastN,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Members whose messages are kept for type members. They must be agents of the team
+	Members []string `json:"members,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Summarizer is the agent that condenses the history for type summary
+	Summarizer string `json:"summarizer,omitempty"`
+	// +kubebuilder:validation:Optional
+	// SummaryPrompt overrides the default summarizer prompt template
+	SummaryPrompt string `json:"summaryPrompt,omitempty"`
+}
+
 type TeamMember struct {
 	Name string `json:"name"`
 	Type string `json:"type"`
+	// +kubebuilder:validation:Optional
+	// HistoryPolicy limits the team history passed to this member; the full history is passed when omitted
+	HistoryPolicy *TeamMemberHistoryPolicy `json:"historyPolicy,omitempty"`
+	// +kubebuilder:validation:Optional
+	// ModelRef overrides the model of an agent member for this team only
+	ModelRef *AgentModelRef `json:"modelRef,omitempty"`
 }
 
 type TeamSelectorSpec struct {
@@ -19,12 +54,40 @@ type TeamSelectorSpec struct {
 type TeamGraphEdge struct {
 	From string `json:"from"`
 	To   string `json:"to"`
+	// +kubebuilder:validation:Optional
+	// Condition is a jq expression over the team state; the edge is only followed when it evaluates to true
+	Condition string `json:"condition,omitempty"`
 }
 
 type TeamGraphSpec struct {
 	Edges []TeamGraphEdge `json:"edges"`
 }
 
+type TeamVoteSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=5
+	// DebateRounds is the number of extra rounds in which members see each other's answers and may revise their own
+	DebateRounds int `json:"debateRounds,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Judge is the agent that reviews member answers and picks the final one; majority rule is used when omitted
+	Judge string `json:"judge,omitempty"`
+	// +kubebuilder:validation:Optional
+	// JudgePrompt overrides the default judge prompt template
+	JudgePrompt string `json:"judgePrompt,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Field is the structured output field compared by majority rule; the whole answer is compared when omitted
+	Field string `json:"field,omitempty"`
+}
+
+type TeamStateSpec struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:pruning:PreserveUnknownFields
+	// +kubebuilder:validation:Schemaless
+	// Initial values of the team state as a JSON object
+	Initial *runtime.RawExtension `json:"initial,omitempty"`
+}
+
 type TeamSpec struct {
 	Members     []TeamMember      `json:"members"`
 	Strategy    string            `json:"strategy"`
@@ -32,6 +95,19 @@ type TeamSpec struct {
 	MaxTurns    *int              `json:"maxTurns,omitempty"`
 	Selector    *TeamSelectorSpec `json:"selector,omitempty"`
 	Graph       *TeamGraphSpec    `json:"graph,omitempty"`
+	Vote        *TeamVoteSpec     `json:"vote,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Instructions is a prompt template prepended to the system prompt of every agent member during team execution
+	Instructions string `json:"instructions,omitempty"`
+	// +kubebuilder:validation:Optional
+	// Parameters for template processing in the instructions field
+	Parameters []Parameter `json:"parameters,omitempty"`
+	// +kubebuilder:validation:Optional
+	// State enables a key/value blackboard that agent members read and write with the team_state_get and team_state_set tools
+	State *TeamStateSpec `json:"state,omitempty"`
+	// +kubebuilder:validation:Optional
+	// TerminateWhen is a jq expression over the team state; the team stops after the turn in which it evaluates to true
+	TerminateWhen string `json:"terminateWhen,omitempty"`
 }
 
 type TeamStatus struct {
@@ -3,6 +3,8 @@
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -50,6 +52,32 @@ type BuiltinToolRef struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
 	Name string `json:"name"`
+	// Kubernetes limits what the Kubernetes built-in tools (k8s_get, k8s_list, k8s_describe_events and
+	// k8s_logs) can read. Required for these tools.
+	// +kubebuilder:validation:Optional
+	Kubernetes *KubernetesToolAccess `json:"kubernetes,omitempty"`
+}
+
+// KubernetesToolAccess allow-lists the resources the Kubernetes built-in tools can read. Requests are made
+// with the identity of the query, so the RBAC of its service account applies as well.
+type KubernetesToolAccess struct {
+	// Resources the tool can read. k8s_logs requires Pod to be listed
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Resources []KubernetesResourceKind `json:"resources"`
+	// Namespaces the tool can read from. Defaults to the namespace of the tool
+	// +kubebuilder:validation:Optional
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+
+// KubernetesResourceKind identifies a kind of resource, such as apps/v1 Deployment.
+type KubernetesResourceKind struct {
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:default="v1"
+	APIVersion string `json:"apiVersion,omitempty"`
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Kind string `json:"kind"`
 }
 
 // ToolAnnotations contains optional additional tool information
@@ -83,12 +111,19 @@ type ToolAnnotations struct {
 
 type ToolSpec struct {
 	// +kubebuilder:validation:Required
-	// +kubebuilder:validation:Enum=http;mcp;agent;team;builtin
+	// +kubebuilder:validation:Enum=http;mcp;agent;team;builtin;graphql;wasm
 	Type string `json:"type"`
 	// Tool description
 	Description string `json:"description,omitempty"`
 	// Input schema for the tool
 	InputSchema *runtime.RawExtension `json:"inputSchema,omitempty"`
+	// LenientArguments repairs malformed JSON arguments produced by the model, such as trailing commas,
+	// single quotes and unterminated strings, before they are validated against the input schema
+	// +kubebuilder:validation:Optional
+	LenientArguments bool `json:"lenientArguments,omitempty"`
+	// Cache memoizes results of tools marked read-only or idempotent in their annotations
+	// +kubebuilder:validation:Optional
+	Cache *ToolCacheSpec `json:"cache,omitempty"`
 	// Optional additional tool information
 	Annotations *ToolAnnotations `json:"annotations,omitempty"`
 	// HTTP-specific configuration for HTTP-based tools
@@ -96,6 +131,14 @@ type ToolSpec struct {
 	// MCP-specific configuration for MCP server tools
 	// +kubebuilder:validation:Optional
 	MCP *MCPToolRef `json:"mcp,omitempty"`
+	// GraphQL-specific configuration for GraphQL tools.
+	// This field is required only if Type = "graphql".
+	// +kubebuilder:validation:Optional
+	GraphQL *GraphQLSpec `json:"graphql,omitempty"`
+	// Wasm-specific configuration for WebAssembly tools.
+	// This field is required only if Type = "wasm".
+	// +kubebuilder:validation:Optional
+	Wasm *WasmSpec `json:"wasm,omitempty"`
 	// Agent-specific configuration for agent tools.
 	// This field is required only if Type = "agent".
 	// +kubebuilder:validation:Optional
@@ -110,6 +153,17 @@ type ToolSpec struct {
 	Builtin *BuiltinToolRef `json:"builtin,omitempty"`
 }
 
+// ToolCacheSpec configures result caching. Results are shared across queries and keyed by the tool, its
+// canonicalized arguments, and the service account and MCP settings of the query.
+type ToolCacheSpec struct {
+	// TTL is how long a cached result is used
+	// +kubebuilder:validation:Required
+	TTL metav1.Duration `json:"ttl"`
+	// KeyFields limits the cache key to these top-level arguments. All arguments are used when empty
+	// +kubebuilder:validation:Optional
+	KeyFields []string `json:"keyFields,omitempty"`
+}
+
 type HTTPSpec struct {
 	// +kubebuilder:validation:Required
 	// +kubebuilder:validation:MinLength=1
@@ -119,6 +173,8 @@ type HTTPSpec struct {
 	// +kubebuilder:default="GET"
 	Method  string   `json:"method,omitempty"`
 	Headers []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	Auth *Auth `json:"auth,omitempty"`
 	// +kubebuilder:validation:Pattern=^[0-9]+[smh]?$
 	Timeout string `json:"timeout,omitempty"`
 	// Body template for POST/PUT/PATCH requests with golang template syntax
@@ -128,6 +184,92 @@ type HTTPSpec struct {
 	BodyParameters []Parameter `json:"bodyParameters,omitempty"`
 }
 
+// GraphQLSpec configures a tool that runs a GraphQL operation. When the tool has no input schema, it is
+// generated from the variable definitions of the operation.
+type GraphQLSpec struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	Endpoint string   `json:"endpoint"`
+	Headers  []Header `json:"headers,omitempty"`
+	// +kubebuilder:validation:Optional
+	Auth *Auth `json:"auth,omitempty"`
+	// +kubebuilder:validation:Pattern=^[0-9]+[smh]?$
+	Timeout string `json:"timeout,omitempty"`
+	// Query is the GraphQL document sent to the endpoint
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Query string `json:"query"`
+	// OperationName selects the operation to run when the document defines several
+	// +kubebuilder:validation:Optional
+	OperationName string `json:"operationName,omitempty"`
+	// Variables maps GraphQL variables to tool arguments. When empty, each argument is passed as the
+	// variable of the same name
+	// +kubebuilder:validation:Optional
+	Variables []GraphQLVariable `json:"variables,omitempty"`
+}
+
+// GraphQLVariable maps a GraphQL variable to a tool argument.
+type GraphQLVariable struct {
+	// Name of the variable in the GraphQL document, without the leading $
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// Argument is the top-level tool argument passed as the variable
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Argument string `json:"argument"`
+}
+
+// WasmSpec configures a tool that runs a WebAssembly (WASI) module in the controller. The arguments are
+// written to the standard input of the module and its standard output is returned as the result, which
+// must be JSON. The module has no access to the filesystem or the network.
+type WasmSpec struct {
+	// Module is the source of the WebAssembly module
+	// +kubebuilder:validation:Required
+	Module WasmModuleSource `json:"module"`
+	// MaxMemory limits the linear memory of the module. Defaults to 64Mi
+	// +kubebuilder:validation:Optional
+	MaxMemory *resource.Quantity `json:"maxMemory,omitempty"`
+	// Timeout limits how long the module runs. Defaults to 30s
+	// +kubebuilder:validation:Pattern=^[0-9]+[smh]?$
+	Timeout string `json:"timeout,omitempty"`
+	// Env sets environment variables of the module
+	// +kubebuilder:validation:Optional
+	Env []WasmEnvVar `json:"env,omitempty"`
+}
+
+// WasmModuleSource locates a WebAssembly module. Exactly one of configMapKeyRef, url and image must be set.
+type WasmModuleSource struct {
+	// ConfigMapKeyRef selects the module from the binaryData of a ConfigMap in the namespace of the tool
+	// +kubebuilder:validation:Optional
+	ConfigMapKeyRef *corev1.ConfigMapKeySelector `json:"configMapKeyRef,omitempty"`
+	// URL downloads the module over HTTP(S)
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^https?://.*"
+	URL string `json:"url,omitempty"`
+	// Image pulls the module from an OCI registry, such as ghcr.io/acme/calculator:v1. The artifact
+	// must have a single layer or a layer with a WebAssembly media type
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+	// ImagePullSecret names a kubernetes.io/dockerconfigjson Secret with credentials for the registry
+	// +kubebuilder:validation:Optional
+	ImagePullSecret *corev1.LocalObjectReference `json:"imagePullSecret,omitempty"`
+	// SHA256 is the expected hex-encoded SHA-256 digest of the module. Modules with another digest are rejected
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern="^[a-f0-9]{64}$"
+	SHA256 string `json:"sha256,omitempty"`
+}
+
+// WasmEnvVar is an environment variable of a WebAssembly module.
+type WasmEnvVar struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+	// +kubebuilder:validation:Optional
+	Value string `json:"value,omitempty"`
+}
+
 // Tool type constants
 const (
 	ToolTypeHTTP    = "http"
@@ -135,11 +277,14 @@ const (
 	ToolTypeAgent   = "agent"
 	ToolTypeTeam    = "team"
 	ToolTypeBuiltin = "builtin"
+	ToolTypeGraphQL = "graphql"
+	ToolTypeWasm    = "wasm"
 )
 
 // Tool state constants
 const (
-	ToolStateReady = "Ready"
+	ToolStateReady       = "Ready"
+	ToolStateUnavailable = "Unavailable"
 )
 
 type ToolStatus struct {
@@ -175,6 +320,11 @@ func (in *ToolSpec) DeepCopyInto(out *ToolSpec) {
 		*out = new(runtime.RawExtension)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Cache != nil {
+		in, out := &in.Cache, &out.Cache
+		*out = new(ToolCacheSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Annotations != nil {
 		in, out := &in.Annotations, &out.Annotations
 		*out = new(ToolAnnotations)
@@ -190,6 +340,16 @@ func (in *ToolSpec) DeepCopyInto(out *ToolSpec) {
 		*out = new(MCPToolRef)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.GraphQL != nil {
+		in, out := &in.GraphQL, &out.GraphQL
+		*out = new(GraphQLSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Wasm != nil {
+		in, out := &in.Wasm, &out.Wasm
+		*out = new(WasmSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Agent != nil {
 		in, out := &in.Agent, &out.Agent
 		*out = new(AgentToolRef)
@@ -230,6 +390,11 @@ func (in *HTTPSpec) DeepCopyInto(out *HTTPSpec) {
 		*out = make([]Header, len(*in))
 		copy(*out, *in)
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Auth)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.BodyParameters != nil {
 		in, out := &in.BodyParameters, &out.BodyParameters
 		*out = make([]Parameter, len(*in))
@@ -0,0 +1,91 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ToolPolicy effect constants
+const (
+	ToolPolicyEffectAllow = "Allow"
+	ToolPolicyEffectDeny  = "Deny"
+)
+
+// ToolPolicy subject kind constants
+const (
+	ToolPolicySubjectAgent          = "Agent"
+	ToolPolicySubjectTeam           = "Team"
+	ToolPolicySubjectServiceAccount = "ServiceAccount"
+)
+
+// ToolPolicySpec defines which subjects may use the selected Tools of the namespace.
+type ToolPolicySpec struct {
+	// Tools lists the names of the Tools the policy applies to
+	// +kubebuilder:validation:Optional
+	Tools []string `json:"tools,omitempty"`
+	// ToolSelector selects the Tools the policy applies to by label. The policy applies to all Tools of the
+	// namespace when neither tools nor toolSelector is set
+	// +kubebuilder:validation:Optional
+	ToolSelector *metav1.LabelSelector `json:"toolSelector,omitempty"`
+	// Rules allow or deny the use of the selected Tools. A matching Deny rule always wins. When a policy has
+	// Allow rules, the selected Tools can only be used by subjects that match one of them
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinItems=1
+	Rules []ToolPolicyRule `json:"rules"`
+}
+
+// ToolPolicyRule allows or denies the use of Tools to subjects.
+type ToolPolicyRule struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Allow;Deny
+	Effect string `json:"effect"`
+	// Subjects the rule applies to. The rule applies to every caller when empty
+	// +kubebuilder:validation:Optional
+	Subjects []ToolPolicySubject `json:"subjects,omitempty"`
+	// Condition is a CEL expression over the tool call that must be true for the rule to apply. Available
+	// variables are args (the arguments of the call), tool, agent, team and serviceAccount. Rules with a
+	// condition are evaluated when a tool is called
+	// +kubebuilder:validation:Optional
+	Condition string `json:"condition,omitempty"`
+	// Message is returned to the agent when the rule denies a call
+	// +kubebuilder:validation:Optional
+	Message string `json:"message,omitempty"`
+}
+
+// ToolPolicySubject identifies a caller of Tools. Exactly one of name and selector must be set.
+type ToolPolicySubject struct {
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Enum=Agent;Team;ServiceAccount
+	Kind string `json:"kind"`
+	// Name of the agent, team or service account of the query
+	// +kubebuilder:validation:Optional
+	Name string `json:"name,omitempty"`
+	// Selector matches agents by label. Only supported for the Agent kind
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Age of the policy"
+
+// ToolPolicy is the Schema for the toolpolicies API.
+type ToolPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ToolPolicySpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// ToolPolicyList contains a list of ToolPolicy.
+type ToolPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ToolPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ToolPolicy{}, &ToolPolicyList{})
+}
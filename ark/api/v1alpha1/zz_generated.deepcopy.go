@@ -351,6 +351,26 @@ func (in *AgentModelRef) DeepCopy() *AgentModelRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentPromptSource) DeepCopyInto(out *AgentPromptSource) {
+	*out = *in
+	if in.MCPPrompt != nil {
+		in, out := &in.MCPPrompt, &out.MCPPrompt
+		*out = new(MCPPromptRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentPromptSource.
+func (in *AgentPromptSource) DeepCopy() *AgentPromptSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentPromptSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentRef) DeepCopyInto(out *AgentRef) {
 	*out = *in
@@ -366,9 +386,30 @@ func (in *AgentRef) DeepCopy() *AgentRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentResource) DeepCopyInto(out *AgentResource) {
+	*out = *in
+	in.MCPServerRef.DeepCopyInto(&out.MCPServerRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentResource.
+func (in *AgentResource) DeepCopy() *AgentResource {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentResource)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 	*out = *in
+	if in.PromptFrom != nil {
+		in, out := &in.PromptFrom, &out.PromptFrom
+		*out = new(AgentPromptSource)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.ModelRef != nil {
 		in, out := &in.ModelRef, &out.ModelRef
 		*out = new(AgentModelRef)
@@ -405,6 +446,11 @@ func (in *AgentSpec) DeepCopyInto(out *AgentSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]AgentResource, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentSpec.
@@ -454,6 +500,11 @@ func (in *AgentTool) DeepCopyInto(out *AgentTool) {
 		*out = new(ToolPartial)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(AgentToolSelector)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentTool.
@@ -481,6 +532,52 @@ func (in *AgentToolRef) DeepCopy() *AgentToolRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentToolSelector) DeepCopyInto(out *AgentToolSelector) {
+	*out = *in
+	in.LabelSelector.DeepCopyInto(&out.LabelSelector)
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentToolSelector.
+func (in *AgentToolSelector) DeepCopy() *AgentToolSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentToolSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Auth) DeepCopyInto(out *Auth) {
+	*out = *in
+	if in.OAuth2 != nil {
+		in, out := &in.OAuth2, &out.OAuth2
+		*out = new(OAuth2ClientCredentials)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Auth.
+func (in *Auth) DeepCopy() *Auth {
+	if in == nil {
+		return nil
+	}
+	out := new(Auth)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AzureModelConfig) DeepCopyInto(out *AzureModelConfig) {
 	*out = *in
@@ -705,6 +802,11 @@ func (in *BedrockModelConfig) DeepCopy() *BedrockModelConfig {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BuiltinToolRef) DeepCopyInto(out *BuiltinToolRef) {
 	*out = *in
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(KubernetesToolAccess)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BuiltinToolRef.
@@ -1099,6 +1201,53 @@ func (in *ExpressionRule) DeepCopy() *ExpressionRule {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphQLSpec) DeepCopyInto(out *GraphQLSpec) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make([]GraphQLVariable, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphQLSpec.
+func (in *GraphQLSpec) DeepCopy() *GraphQLSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphQLSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GraphQLVariable) DeepCopyInto(out *GraphQLVariable) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GraphQLVariable.
+func (in *GraphQLVariable) DeepCopy() *GraphQLVariable {
+	if in == nil {
+		return nil
+	}
+	out := new(GraphQLVariable)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HTTPSpec.
 func (in *HTTPSpec) DeepCopy() *HTTPSpec {
 	if in == nil {
@@ -1175,6 +1324,62 @@ func (in *HeaderValueSource) DeepCopy() *HeaderValueSource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesResourceKind) DeepCopyInto(out *KubernetesResourceKind) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesResourceKind.
+func (in *KubernetesResourceKind) DeepCopy() *KubernetesResourceKind {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesResourceKind)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesToolAccess) DeepCopyInto(out *KubernetesToolAccess) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]KubernetesResourceKind, len(*in))
+		copy(*out, *in)
+	}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesToolAccess.
+func (in *KubernetesToolAccess) DeepCopy() *KubernetesToolAccess {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesToolAccess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPromptRef) DeepCopyInto(out *MCPPromptRef) {
+	*out = *in
+	in.MCPServerRef.DeepCopyInto(&out.MCPServerRef)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptRef.
+func (in *MCPPromptRef) DeepCopy() *MCPPromptRef {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPPromptRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServer) DeepCopyInto(out *MCPServer) {
 	*out = *in
@@ -1202,6 +1407,49 @@ func (in *MCPServer) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerCommand) DeepCopyInto(out *MCPServerCommand) {
+	*out = *in
+	if in.Args != nil {
+		in, out := &in.Args, &out.Args
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]MCPServerEnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerCommand.
+func (in *MCPServerCommand) DeepCopy() *MCPServerCommand {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerCommand)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerEnvVar) DeepCopyInto(out *MCPServerEnvVar) {
+	*out = *in
+	in.ValueSource.DeepCopyInto(&out.ValueSource)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerEnvVar.
+func (in *MCPServerEnvVar) DeepCopy() *MCPServerEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerList) DeepCopyInto(out *MCPServerList) {
 	*out = *in
@@ -1255,11 +1503,31 @@ func (in *MCPServerSpec) DeepCopyInto(out *MCPServerSpec) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Auth != nil {
+		in, out := &in.Auth, &out.Auth
+		*out = new(Auth)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = new(MCPServerCommand)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PollInterval != nil {
 		in, out := &in.PollInterval, &out.PollInterval
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.UnavailableGracePeriod != nil {
+		in, out := &in.UnavailableGracePeriod, &out.UnavailableGracePeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = new(MCPServerTools)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerSpec.
@@ -1275,6 +1543,10 @@ func (in *MCPServerSpec) DeepCopy() *MCPServerSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
 	*out = *in
+	if in.LastSyncTime != nil {
+		in, out := &in.LastSyncTime, &out.LastSyncTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1294,6 +1566,57 @@ func (in *MCPServerStatus) DeepCopy() *MCPServerStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerTools) DeepCopyInto(out *MCPServerTools) {
+	*out = *in
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = make([]MCPToolOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerTools.
+func (in *MCPServerTools) DeepCopy() *MCPServerTools {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerTools)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPToolOverride) DeepCopyInto(out *MCPToolOverride) {
+	*out = *in
+	if in.Annotations != nil {
+		in, out := &in.Annotations, &out.Annotations
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolOverride.
+func (in *MCPToolOverride) DeepCopy() *MCPToolOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPToolOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPToolRef.
 func (in *MCPToolRef) DeepCopy() *MCPToolRef {
 	if in == nil {
@@ -1520,6 +1843,11 @@ func (in *ModelSpec) DeepCopyInto(out *ModelSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelSpec.
@@ -1554,6 +1882,28 @@ func (in *ModelStatus) DeepCopy() *ModelStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuth2ClientCredentials) DeepCopyInto(out *OAuth2ClientCredentials) {
+	*out = *in
+	in.ClientID.DeepCopyInto(&out.ClientID)
+	in.ClientSecret.DeepCopyInto(&out.ClientSecret)
+	if in.Scopes != nil {
+		in, out := &in.Scopes, &out.Scopes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuth2ClientCredentials.
+func (in *OAuth2ClientCredentials) DeepCopy() *OAuth2ClientCredentials {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuth2ClientCredentials)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OpenAIModelConfig) DeepCopyInto(out *OpenAIModelConfig) {
 	*out = *in
@@ -1586,40 +1936,199 @@ func (in *OpenAIModelConfig) DeepCopy() *OpenAIModelConfig {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Override) DeepCopyInto(out *Override) {
+func (in *OpenAPIDocumentSource) DeepCopyInto(out *OpenAPIDocumentSource) {
 	*out = *in
-	if in.Headers != nil {
-		in, out := &in.Headers, &out.Headers
-		*out = make([]Header, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.LabelSelector != nil {
-		in, out := &in.LabelSelector, &out.LabelSelector
-		*out = new(v1.LabelSelector)
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
 		(*in).DeepCopyInto(*out)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Override.
-func (in *Override) DeepCopy() *Override {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIDocumentSource.
+func (in *OpenAPIDocumentSource) DeepCopy() *OpenAPIDocumentSource {
 	if in == nil {
 		return nil
 	}
-	out := new(Override)
+	out := new(OpenAPIDocumentSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Parameter) DeepCopyInto(out *Parameter) {
+func (in *OpenAPIOperationFilter) DeepCopyInto(out *OpenAPIOperationFilter) {
 	*out = *in
-	if in.ValueFrom != nil {
-		in, out := &in.ValueFrom, &out.ValueFrom
-		*out = new(ValueFromSource)
-		(*in).DeepCopyInto(*out)
-	}
+	if in.Include != nil {
+		in, out := &in.Include, &out.Include
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Exclude != nil {
+		in, out := &in.Exclude, &out.Exclude
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIOperationFilter.
+func (in *OpenAPIOperationFilter) DeepCopy() *OpenAPIOperationFilter {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIOperationFilter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIServer) DeepCopyInto(out *OpenAPIServer) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIServer.
+func (in *OpenAPIServer) DeepCopy() *OpenAPIServer {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenAPIServer) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIServerList) DeepCopyInto(out *OpenAPIServerList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OpenAPIServer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIServerList.
+func (in *OpenAPIServerList) DeepCopy() *OpenAPIServerList {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIServerList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *OpenAPIServerList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIServerSpec) DeepCopyInto(out *OpenAPIServerSpec) {
+	*out = *in
+	in.Document.DeepCopyInto(&out.Document)
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Operations != nil {
+		in, out := &in.Operations, &out.Operations
+		*out = new(OpenAPIOperationFilter)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PollInterval != nil {
+		in, out := &in.PollInterval, &out.PollInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIServerSpec.
+func (in *OpenAPIServerSpec) DeepCopy() *OpenAPIServerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIServerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OpenAPIServerStatus) DeepCopyInto(out *OpenAPIServerStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OpenAPIServerStatus.
+func (in *OpenAPIServerStatus) DeepCopy() *OpenAPIServerStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OpenAPIServerStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Override) DeepCopyInto(out *Override) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]Header, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Override.
+func (in *Override) DeepCopy() *Override {
+	if in == nil {
+		return nil
+	}
+	out := new(Override)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Parameter) DeepCopyInto(out *Parameter) {
+	*out = *in
+	if in.ValueFrom != nil {
+		in, out := &in.ValueFrom, &out.ValueFrom
+		*out = new(ValueFromSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Parameter.
@@ -1910,6 +2419,16 @@ func (in *Response) DeepCopyInto(out *Response) {
 		*out = new(A2AMetadata)
 		**out = **in
 	}
+	if in.TeamState != nil {
+		in, out := &in.TeamState, &out.TeamState
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Vote != nil {
+		in, out := &in.Vote, &out.Vote
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Response.
@@ -2034,6 +2553,16 @@ func (in *TeamList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamMember) DeepCopyInto(out *TeamMember) {
 	*out = *in
+	if in.HistoryPolicy != nil {
+		in, out := &in.HistoryPolicy, &out.HistoryPolicy
+		*out = new(TeamMemberHistoryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelRef != nil {
+		in, out := &in.ModelRef, &out.ModelRef
+		*out = new(AgentModelRef)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMember.
@@ -2046,6 +2575,31 @@ func (in *TeamMember) DeepCopy() *TeamMember {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamMemberHistoryPolicy) DeepCopyInto(out *TeamMemberHistoryPolicy) {
+	*out = *in
+	if in.LastN != nil {
+		in, out := &in.LastN, &out.LastN
+		*out = new(int)
+		**out = **in
+	}
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamMemberHistoryPolicy.
+func (in *TeamMemberHistoryPolicy) DeepCopy() *TeamMemberHistoryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamMemberHistoryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamSelectorSpec) DeepCopyInto(out *TeamSelectorSpec) {
 	*out = *in
@@ -2067,7 +2621,9 @@ func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
 	if in.Members != nil {
 		in, out := &in.Members, &out.Members
 		*out = make([]TeamMember, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.MaxTurns != nil {
 		in, out := &in.MaxTurns, &out.MaxTurns
@@ -2084,6 +2640,23 @@ func (in *TeamSpec) DeepCopyInto(out *TeamSpec) {
 		*out = new(TeamGraphSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Vote != nil {
+		in, out := &in.Vote, &out.Vote
+		*out = new(TeamVoteSpec)
+		**out = **in
+	}
+	if in.Parameters != nil {
+		in, out := &in.Parameters, &out.Parameters
+		*out = make([]Parameter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.State != nil {
+		in, out := &in.State, &out.State
+		*out = new(TeamStateSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamSpec.
@@ -2096,6 +2669,26 @@ func (in *TeamSpec) DeepCopy() *TeamSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamStateSpec) DeepCopyInto(out *TeamStateSpec) {
+	*out = *in
+	if in.Initial != nil {
+		in, out := &in.Initial, &out.Initial
+		*out = new(runtime.RawExtension)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamStateSpec.
+func (in *TeamStateSpec) DeepCopy() *TeamStateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamStateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TeamStatus) DeepCopyInto(out *TeamStatus) {
 	*out = *in
@@ -2128,6 +2721,21 @@ func (in *TeamToolRef) DeepCopy() *TeamToolRef {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TeamVoteSpec) DeepCopyInto(out *TeamVoteSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TeamVoteSpec.
+func (in *TeamVoteSpec) DeepCopy() *TeamVoteSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TeamVoteSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TokenUsage) DeepCopyInto(out *TokenUsage) {
 	*out = *in
@@ -2180,6 +2788,27 @@ func (in *ToolAnnotations) DeepCopy() *ToolAnnotations {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolCacheSpec) DeepCopyInto(out *ToolCacheSpec) {
+	*out = *in
+	out.TTL = in.TTL
+	if in.KeyFields != nil {
+		in, out := &in.KeyFields, &out.KeyFields
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolCacheSpec.
+func (in *ToolCacheSpec) DeepCopy() *ToolCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolFunction) DeepCopyInto(out *ToolFunction) {
 	*out = *in
@@ -2254,6 +2883,138 @@ func (in *ToolPartial) DeepCopy() *ToolPartial {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolPolicy) DeepCopyInto(out *ToolPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolPolicy.
+func (in *ToolPolicy) DeepCopy() *ToolPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ToolPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolPolicyList) DeepCopyInto(out *ToolPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ToolPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolPolicyList.
+func (in *ToolPolicyList) DeepCopy() *ToolPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ToolPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolPolicyRule) DeepCopyInto(out *ToolPolicyRule) {
+	*out = *in
+	if in.Subjects != nil {
+		in, out := &in.Subjects, &out.Subjects
+		*out = make([]ToolPolicySubject, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolPolicyRule.
+func (in *ToolPolicyRule) DeepCopy() *ToolPolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolPolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolPolicySpec) DeepCopyInto(out *ToolPolicySpec) {
+	*out = *in
+	if in.Tools != nil {
+		in, out := &in.Tools, &out.Tools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ToolSelector != nil {
+		in, out := &in.ToolSelector, &out.ToolSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]ToolPolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolPolicySpec.
+func (in *ToolPolicySpec) DeepCopy() *ToolPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolPolicySubject) DeepCopyInto(out *ToolPolicySubject) {
+	*out = *in
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolPolicySubject.
+func (in *ToolPolicySubject) DeepCopy() *ToolPolicySubject {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolPolicySubject)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolSpec.
 func (in *ToolSpec) DeepCopy() *ToolSpec {
 	if in == nil {
@@ -2333,3 +3094,69 @@ func (in *ValueSource) DeepCopy() *ValueSource {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmEnvVar) DeepCopyInto(out *WasmEnvVar) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmEnvVar.
+func (in *WasmEnvVar) DeepCopy() *WasmEnvVar {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmEnvVar)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmModuleSource) DeepCopyInto(out *WasmModuleSource) {
+	*out = *in
+	if in.ConfigMapKeyRef != nil {
+		in, out := &in.ConfigMapKeyRef, &out.ConfigMapKeyRef
+		*out = new(corev1.ConfigMapKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecret != nil {
+		in, out := &in.ImagePullSecret, &out.ImagePullSecret
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmModuleSource.
+func (in *WasmModuleSource) DeepCopy() *WasmModuleSource {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmModuleSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WasmSpec) DeepCopyInto(out *WasmSpec) {
+	*out = *in
+	in.Module.DeepCopyInto(&out.Module)
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]WasmEnvVar, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WasmSpec.
+func (in *WasmSpec) DeepCopy() *WasmSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WasmSpec)
+	in.DeepCopyInto(out)
+	return out
+}
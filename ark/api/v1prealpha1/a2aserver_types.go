@@ -4,6 +4,8 @@ package v1prealpha1
 
 import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 )
 
 type A2AServerSpec struct {
@@ -15,6 +17,10 @@ type A2AServerSpec struct {
 	// +kubebuilder:validation:Optional
 	Headers []Header `json:"headers,omitempty"`
 
+	// Auth configures OAuth2 authentication for requests to the A2A server
+	// +kubebuilder:validation:Optional
+	Auth *arkv1alpha1.Auth `json:"auth,omitempty"`
+
 	// Description of the A2A server
 	// +kubebuilder:validation:Optional
 	Description string `json:"description,omitempty"`
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -31,6 +32,8 @@ import (
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 	"mckinsey.com/ark/internal/controller"
 	eventingconfig "mckinsey.com/ark/internal/eventing/config"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/mcpgateway"
 	telemetryconfig "mckinsey.com/ark/internal/telemetry/config"
 	webhookv1 "mckinsey.com/ark/internal/webhook/v1"
 	webhookv1prealpha1 "mckinsey.com/ark/internal/webhook/v1prealpha1"
@@ -63,6 +66,11 @@ type config struct {
 	probeAddr                                        string
 	secureMetrics                                    bool
 	enableHTTP2                                      bool
+	toolCacheSize                                    int
+	mcpSessionPool                                   genai.MCPSessionPoolOptions
+	mcpGatewayAddr                                   string
+	mcpStdioAllowedCommands                          string
+	queryImpersonation                               bool
 }
 
 func main() {
@@ -76,6 +84,13 @@ func main() {
 
 	setupLog.Info("starting ark controller", "version", Version, "commit", GitCommit)
 
+	genai.DefaultToolResultCache = genai.NewToolResultCache(result.toolCacheSize)
+	genai.DefaultMCPSessionPool = genai.NewMCPSessionPool(result.mcpSessionPool)
+	genai.DefaultMCPStdioSupervisor = genai.NewMCPStdioSupervisor(genai.MCPStdioSupervisorOptions{
+		AllowedCommands: parseList(result.mcpStdioAllowedCommands),
+	})
+	webhookv1.QueryImpersonation = result.queryImpersonation
+
 	mgr, metricsCertWatcher, webhookCertWatcher := setupManager(result.config)
 
 	// Initialize telemetry provider with direct (non-cached) client for broker discovery
@@ -98,6 +113,7 @@ func main() {
 
 	setupControllers(mgr, telemetryProvider, eventingProvider)
 	setupWebhooks(mgr)
+	setupMCPGateway(mgr, result.mcpGatewayAddr)
 	startManager(mgr, metricsCertWatcher, webhookCertWatcher)
 }
 
@@ -125,6 +141,20 @@ func parseFlags() struct {
 	flag.StringVar(&cfg.metricsCertKey, "metrics-cert-key", "tls.key", "The name of the metrics server key file.")
 	flag.BoolVar(&cfg.enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
+	flag.IntVar(&cfg.toolCacheSize, "tool-cache-size", genai.DefaultToolCacheSize,
+		"The maximum number of tool results kept in the shared tool result cache.")
+	flag.IntVar(&cfg.mcpSessionPool.MaxSessions, "mcp-max-sessions", genai.DefaultMCPMaxSessions,
+		"The maximum number of MCP sessions kept in the shared MCP session pool.")
+	flag.DurationVar(&cfg.mcpSessionPool.IdleTimeout, "mcp-session-idle-timeout", genai.DefaultMCPSessionIdleTimeout,
+		"How long an unused MCP session is kept in the shared MCP session pool.")
+	flag.DurationVar(&cfg.mcpSessionPool.HealthCheckInterval, "mcp-session-health-check-interval", genai.DefaultMCPSessionHealthCheckInterval,
+		"How long a pooled MCP session is reused before it is pinged again.")
+	flag.StringVar(&cfg.mcpGatewayAddr, "mcp-gateway-bind-address", "0", "The address the MCP endpoint serving "+
+		"agents and teams labeled mcp/expose=true binds to. Use \"0\" to disable it.")
+	flag.StringVar(&cfg.mcpStdioAllowedCommands, "mcp-stdio-allowed-commands", "", "Comma-separated commands that "+
+		"MCP servers of the stdio transport may run in the controller pod. The stdio transport is disabled when empty.")
+	flag.BoolVar(&cfg.queryImpersonation, "query-impersonation", true, "Whether the controller may impersonate "+
+		"the service account of queries. Without it, Kubernetes tools may only read the namespace of the tool.")
 	flag.BoolVar(&showVersion, "version", false, "Show version information and exit")
 
 	zapOpts := zap.Options{Development: false}
@@ -138,6 +168,17 @@ func parseFlags() struct {
 	}{cfg, zapOpts, showVersion}
 }
 
+// parseList splits a comma-separated flag value, ignoring empty entries.
+func parseList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func setupManager(cfg config) (ctrl.Manager, *certwatcher.CertWatcher, *certwatcher.CertWatcher) {
 	tlsOpts := setupTLS(cfg.enableHTTP2)
 	webhookServer, webhookCertWatcher := setupWebhookServer(cfg, tlsOpts)
@@ -252,6 +293,7 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 		{"Query", &controller.QueryReconciler{
 			Client:    mgr.GetClient(),
 			Scheme:    mgr.GetScheme(),
+			Config:    mgr.GetConfig(),
 			Telemetry: telemetryProvider,
 			Eventing:  eventingProvider,
 		}},
@@ -267,6 +309,11 @@ func setupControllers(mgr ctrl.Manager, telemetryProvider *telemetryconfig.Provi
 			Scheme:   mgr.GetScheme(),
 			Eventing: eventingProvider,
 		}},
+		{"OpenAPIServer", &controller.OpenAPIServerReconciler{
+			Client:   mgr.GetClient(),
+			Scheme:   mgr.GetScheme(),
+			Eventing: eventingProvider,
+		}},
 		{"Model", &controller.ModelReconciler{
 			Client:    mgr.GetClient(),
 			Scheme:    mgr.GetScheme(),
@@ -309,8 +356,10 @@ func setupWebhooks(mgr ctrl.Manager) {
 		{"Agent", webhookv1.SetupAgentWebhookWithManager},
 		{"Query", webhookv1.SetupQueryWebhookWithManager},
 		{"Tool", webhookv1.SetupToolWebhookWithManager},
+		{"ToolPolicy", webhookv1.SetupToolPolicyWebhookWithManager},
 		{"Model", webhookv1.SetupModelWebhookWithManager},
 		{"MCPServer", webhookv1.SetupMCPServerWebhookWithManager},
+		{"OpenAPIServer", webhookv1.SetupOpenAPIServerWebhookWithManager},
 		{"Evaluator", webhookv1.SetupEvaluatorWebhookWithManager},
 		{"Evaluation", webhookv1.SetupEvaluationWebhookWithManager},
 		{"A2AServer", webhookv1prealpha1.SetupA2AServerWebhookWithManager},
@@ -325,6 +374,18 @@ func setupWebhooks(mgr ctrl.Manager) {
 	}
 }
 
+func setupMCPGateway(mgr ctrl.Manager, bindAddress string) {
+	if bindAddress == "0" {
+		return
+	}
+
+	gateway := &mcpgateway.Server{Client: mgr.GetClient(), BindAddress: bindAddress, Version: Version}
+	if err := mgr.Add(gateway); err != nil {
+		setupLog.Error(err, "unable to add MCP gateway to manager")
+		os.Exit(1)
+	}
+}
+
 func startManager(mgr ctrl.Manager, metricsCertWatcher, webhookCertWatcher *certwatcher.CertWatcher) {
 	if metricsCertWatcher != nil {
 		setupLog.Info("Adding metrics certificate watcher to manager")
@@ -334,6 +395,16 @@ func startManager(mgr ctrl.Manager, metricsCertWatcher, webhookCertWatcher *cert
 		}
 	}
 
+	if err := mgr.Add(genai.DefaultMCPSessionPool); err != nil {
+		setupLog.Error(err, "unable to add MCP session pool to manager")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(genai.DefaultMCPStdioSupervisor); err != nil {
+		setupLog.Error(err, "unable to add MCP stdio supervisor to manager")
+		os.Exit(1)
+	}
+
 	if webhookCertWatcher != nil {
 		setupLog.Info("Adding webhook certificate watcher to manager")
 		if err := mgr.Add(webhookCertWatcher); err != nil {
@@ -14,10 +14,11 @@ const (
 
 // A2A annotations
 const (
-	A2AServerName    = ARKPrefix + "a2a-server-name"
-	A2AServerAddress = ARKPrefix + "a2a-server-address"
-	A2AServerSkills  = ARKPrefix + "a2a-server-skills"
-	A2AContextID     = ARKPrefix + "a2a-context-id"
+	A2AServerName      = ARKPrefix + "a2a-server-name"
+	A2AServerAddress   = ARKPrefix + "a2a-server-address"
+	A2AServerSkills    = ARKPrefix + "a2a-server-skills"
+	A2AServerStreaming = ARKPrefix + "a2a-server-streaming"
+	A2AContextID       = ARKPrefix + "a2a-context-id"
 )
 
 // MCP annotations
@@ -44,6 +45,7 @@ const (
 const (
 	Finalizer            = ARKPrefix + "finalizer"
 	TriggeredFrom        = ARKPrefix + "triggered-from"
+	RequestedBy          = ARKPrefix + "requested-by"
 	LocalhostGatewayPort = ARKPrefix + "localhost-gateway-port"
 )
 
@@ -2,16 +2,32 @@ package common
 
 import (
 	"bytes"
+	"encoding/json"
 	"text/template"
 )
 
+// templateFuncs are available in all resolved templates.
+var templateFuncs = template.FuncMap{
+	// toJson renders a value as JSON, for example to pass tool arguments through as a request body
+	"toJson": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ValidateTemplate checks that a Go template can be parsed with the available template functions.
+func ValidateTemplate(tmpl string) error {
+	_, err := template.New("template").Funcs(templateFuncs).Parse(tmpl)
+	return err
+}
+
 // ResolveTemplate resolves Go template strings using provided data.
 // Returns the resolved string or the original template if an error occurs.
 func ResolveTemplate(tmpl string, data map[string]any) (string, error) {
 	if tmpl == "" {
 		return "", nil
 	}
-	t, err := template.New("template").Parse(tmpl)
+	t, err := template.New("template").Funcs(templateFuncs).Parse(tmpl)
 	if err != nil {
 		return "", err
 	}
@@ -97,7 +97,7 @@ func (r *A2AServerReconciler) processServer(ctx context.Context, a2aServer arkv1
 	// Use the already resolved address from status
 	resolvedAddress := a2aServer.Status.LastResolvedAddress
 	// Don't pass recorder - we handle events at controller level based on actual changes
-	agentCard, err := genai.DiscoverA2AAgents(ctx, r.Client, resolvedAddress, a2aServer.Spec.Headers, a2aServer.Namespace)
+	agentCard, err := genai.DiscoverA2AAgents(ctx, r.Client, resolvedAddress, a2aServer.Spec.Headers, a2aServer.Spec.Auth, a2aServer.Namespace)
 	if err != nil {
 		if err := r.reconcileConditionsDiscoveryFailed(ctx, &a2aServer, err, resolvedAddress); err != nil {
 			return ctrl.Result{}, err
@@ -260,6 +260,9 @@ func (r *A2AServerReconciler) buildAgentWithSkills(a2aServer *arkv1prealpha1.A2A
 		annotations.A2AServerAddress: a2aServer.Status.LastResolvedAddress,
 		annotations.A2AServerSkills:  string(skillsJSON),
 	}
+	if agentCard.Capabilities.Streaming != nil && *agentCard.Capabilities.Streaming {
+		agentAnnotations[annotations.A2AServerStreaming] = "true"
+	}
 
 	// Inherit ark.mckinsey.com annotations from A2AServer to Agent
 	// AAS-2657: Will replace with more idiomatic K8s spec.template pattern
@@ -310,8 +313,9 @@ func (r *A2AServerReconciler) createOrUpdateAgent(ctx context.Context, agent *ar
 		return false, fmt.Errorf("failed to get agent %s: %w", agentName, getErr)
 	}
 
-	// Only update if skills annotation has changed
-	if existingAgent.Annotations[annotations.A2AServerSkills] != agent.Annotations[annotations.A2AServerSkills] {
+	// Only update if the skills or the streaming capability have changed
+	if existingAgent.Annotations[annotations.A2AServerSkills] != agent.Annotations[annotations.A2AServerSkills] ||
+		existingAgent.Annotations[annotations.A2AServerStreaming] != agent.Annotations[annotations.A2AServerStreaming] {
 		existingAgent.Spec = agent.Spec
 		existingAgent.Annotations = agent.Annotations
 		if err := r.Update(ctx, existingAgent); err != nil {
@@ -141,7 +141,7 @@ func (r *A2ATaskReconciler) createA2AClient(ctx context.Context, a2aTask *arkv1a
 
 	agentName := a2aTask.Spec.AgentRef.Name
 
-	return genai.CreateA2AClient(ctx, r.Client, a2aServerAddress, a2aServer.Spec.Headers, serverNamespace, agentName, r.Eventing.A2aRecorder())
+	return genai.CreateA2AClient(ctx, r.Client, a2aServerAddress, a2aServer.Spec.Headers, a2aServer.Spec.Auth, serverNamespace, agentName, r.Eventing.A2aRecorder())
 }
 
 // queryTaskStatus queries the A2A server for task status
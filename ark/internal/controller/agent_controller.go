@@ -9,6 +9,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -20,6 +21,7 @@ import (
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	arkv1prealpha1 "mckinsey.com/ark/api/v1prealpha1"
 	"mckinsey.com/ark/internal/eventing"
+	"mckinsey.com/ark/internal/genai"
 )
 
 const (
@@ -147,7 +149,17 @@ func (r *AgentReconciler) checkModelDependency(ctx context.Context, agent *arkv1
 
 // checkToolDependencies validates tool dependencies
 func (r *AgentReconciler) checkToolDependencies(ctx context.Context, agent *arkv1alpha1.Agent) (bool, string) {
-	for _, toolSpec := range agent.Spec.Tools {
+	for i, toolSpec := range agent.Spec.Tools {
+		if toolSpec.Type == genai.AgentToolTypeSelector {
+			tools, err := genai.ListSelectedTools(ctx, r.Client, agent.Namespace, toolSpec.Selector)
+			if err != nil {
+				return false, fmt.Sprintf("Error checking tools of tool[%d]: %v", i, err)
+			}
+			if len(tools) == 0 {
+				return false, fmt.Sprintf("No tools in namespace '%s' match the selector of tool[%d]", agent.Namespace, i)
+			}
+			continue
+		}
 		if toolSpec.Type == "custom" && toolSpec.Name != "" {
 
 			toolName := toolSpec.GetToolCRDName()
@@ -266,7 +278,7 @@ func (r *AgentReconciler) findAgentsForTool(ctx context.Context, obj client.Obje
 	}
 
 	return r.findAgentsForDependency(ctx, tool.Name, tool.Namespace, "tool", func(agent *arkv1alpha1.Agent) bool {
-		return r.agentDependsOnTool(agent, tool.Name)
+		return r.agentDependsOnTool(agent, tool.Name) || r.agentSelectsTool(agent, tool)
 	})
 }
 
@@ -335,6 +347,23 @@ func (r *AgentReconciler) agentDependsOnTool(agent *arkv1alpha1.Agent, toolName
 	return false
 }
 
+// agentSelectsTool checks if a selector tool of an agent matches a specific tool
+func (r *AgentReconciler) agentSelectsTool(agent *arkv1alpha1.Agent, tool *arkv1alpha1.Tool) bool {
+	for _, toolSpec := range agent.Spec.Tools {
+		if toolSpec.Type != genai.AgentToolTypeSelector || toolSpec.Selector == nil {
+			continue
+		}
+		selector, err := metav1.LabelSelectorAsSelector(&toolSpec.Selector.LabelSelector)
+		if err != nil {
+			continue
+		}
+		if selector.Matches(labels.Set(tool.Labels)) && genai.ToolNameSelected(toolSpec.Selector, tool.Name) {
+			return true
+		}
+	}
+	return false
+}
+
 // agentDependsOnModel checks if an agent depends on a specific model
 func (r *AgentReconciler) agentDependsOnModel(agent *arkv1alpha1.Agent, modelName string) bool {
 	return agent.Spec.ModelRef != nil && agent.Spec.ModelRef.Name == modelName
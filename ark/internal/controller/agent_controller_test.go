@@ -227,6 +227,35 @@ var _ = Describe("Agent Controller", func() {
 			Expect(controllerReconciler.agentDependsOnTool(partialToolAgent, "unrelated-tool")).To(BeFalse())
 		})
 
+		It("should match tools pulled in by selector tools", func() {
+			selectorAgent := &arkv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-selector-agent", Namespace: "default"},
+				Spec: arkv1alpha1.AgentSpec{
+					Tools: []arkv1alpha1.AgentTool{{
+						Type: "selector",
+						Selector: &arkv1alpha1.AgentToolSelector{
+							LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"mcp/server": "github"}},
+							Exclude:       []string{"github-delete-*"},
+						},
+					}},
+				},
+			}
+			githubTool := func(name string) *arkv1alpha1.Tool {
+				return &arkv1alpha1.Tool{ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: "default",
+					Labels:    map[string]string{"mcp/server": "github"},
+				}}
+			}
+
+			controllerReconciler := &AgentReconciler{Client: k8sClient, Scheme: k8sClient.Scheme(), Eventing: eventnoop.NewProvider()}
+			Expect(controllerReconciler.agentSelectsTool(selectorAgent, githubTool("github-create-issue"))).To(BeTrue())
+			Expect(controllerReconciler.agentSelectsTool(selectorAgent, githubTool("github-delete-repo"))).To(BeFalse())
+			Expect(controllerReconciler.agentSelectsTool(selectorAgent, &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "github-create-issue", Namespace: "default"},
+			})).To(BeFalse())
+		})
+
 		It("should fail reconciliation when partial tool CRD is missing", func() {
 			const missingToolAgentName = "test-missing-tool-agent"
 			missingToolAgentTypeNamespacedName := types.NamespacedName{
@@ -6,6 +6,8 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
@@ -14,10 +16,15 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/annotations"
@@ -31,6 +38,12 @@ const (
 	// Condition types
 	MCPServerAvailable   = "Available"
 	MCPServerDiscovering = "Discovering"
+	MCPServerToolsStale  = "ToolsStale"
+	// MCPServerProcessRunning reports the process of stdio servers
+	MCPServerProcessRunning = "ProcessRunning"
+
+	// defaultUnavailableGracePeriod applies to servers created before the grace period was configurable
+	defaultUnavailableGracePeriod = 5 * time.Minute
 )
 
 type MCPServerReconciler struct {
@@ -38,6 +51,9 @@ type MCPServerReconciler struct {
 	Scheme   *runtime.Scheme
 	Eventing eventing.Provider
 	resolver *common.ValueSourceResolver
+	sessions *mcpServerSessions
+	// stdio runs the processes of stdio servers, defaulting to the supervisor shared with queries
+	stdio *genai.MCPStdioSupervisor
 }
 
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=mcpservers,verbs=get;list;watch;create;update;patch;delete
@@ -57,6 +73,8 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		if errors.IsNotFound(err) {
 			// MCPServer was deleted, tools will be garbage collected due to owner references
 			log.Info("MCPServer deleted, associated tools will be garbage collected", "server", req.Name)
+			r.getSessions().close(ctx, req.NamespacedName)
+			r.getStdio().Stop(ctx, req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "unable to fetch MCPServer")
@@ -65,11 +83,10 @@ func (r *MCPServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	// Initialize conditions if empty
 	if len(mcpServer.Status.Conditions) == 0 {
+		// Status updates do not trigger reconciles, so discovery continues right away
 		if err := r.reconcileConditionsInitializing(ctx, &mcpServer); err != nil {
 			return ctrl.Result{}, err
 		}
-		// Return early to avoid double reconciliation, let the status update trigger next reconcile
-		return ctrl.Result{}, nil
 	}
 
 	return r.processServer(ctx, mcpServer)
@@ -82,6 +99,20 @@ func (r *MCPServerReconciler) getResolver() *common.ValueSourceResolver {
 	return r.resolver
 }
 
+func (r *MCPServerReconciler) getSessions() *mcpServerSessions {
+	if r.sessions == nil {
+		r.sessions = newMCPServerSessions()
+	}
+	return r.sessions
+}
+
+func (r *MCPServerReconciler) getStdio() *genai.MCPStdioSupervisor {
+	if r.stdio == nil {
+		r.stdio = genai.DefaultMCPStdioSupervisor
+	}
+	return r.stdio
+}
+
 func (r *MCPServerReconciler) listAllMCPTools(ctx context.Context, mcpServerNamespace, mcpServerName string) ([]arkv1alpha1.Tool, error) {
 	listOpts := []client.ListOption{
 		client.InNamespace(mcpServerNamespace),
@@ -105,6 +136,10 @@ func (r *MCPServerReconciler) deleteAllMCPTools(ctx context.Context, mcpServerNa
 }
 
 func (r *MCPServerReconciler) processServer(ctx context.Context, mcpServer arkv1alpha1.MCPServer) (ctrl.Result, error) {
+	if mcpServer.Spec.Transport == genai.MCPStdioTransport {
+		return r.processStdioServer(ctx, mcpServer)
+	}
+
 	resolver := r.getResolver()
 	resolvedAddress, err := resolver.ResolveValueSource(ctx, mcpServer.Spec.Address, mcpServer.Namespace)
 	if err != nil {
@@ -115,35 +150,207 @@ func (r *MCPServerReconciler) processServer(ctx context.Context, mcpServer arkv1
 	}
 
 	mcpServer.Status.ResolvedAddress = resolvedAddress
-	mcpClient, err := r.createMCPClient(ctx, &mcpServer)
+	timeout, err := mcpServerTimeout(&mcpServer)
 	if err != nil {
-		if err := r.reconcileConditionsClientCreationFailed(ctx, &mcpServer, err); err != nil {
-			return ctrl.Result{}, err
-		}
+		return r.reconcileUnreachableServer(ctx, &mcpServer, err, false)
+	}
+	mcpClient, err := r.getSessions().get(ctx, &mcpServer, func(ctx context.Context, onToolListChanged func()) (*genai.MCPClient, error) {
+		return r.createMCPClient(ctx, &mcpServer, timeout, onToolListChanged)
+	})
+	if err != nil {
+		return r.reconcileUnreachableServer(ctx, &mcpServer, err, false)
+	}
+	return r.syncTools(ctx, mcpServer, mcpClient, timeout, false)
+}
 
-		if err := r.deleteAllMCPTools(ctx, mcpServer.Namespace, mcpServer.Name); err != nil {
-			return ctrl.Result{}, err
+// processStdioServer syncs the tools of a stdio server from the session of its process, which is started when
+// it is not running.
+func (r *MCPServerReconciler) processStdioServer(ctx context.Context, mcpServer arkv1alpha1.MCPServer) (ctrl.Result, error) {
+	timeout, err := mcpServerTimeout(&mcpServer)
+	if err != nil {
+		return r.reconcileUnreachableServer(ctx, &mcpServer, err, false)
+	}
+	command, err := r.getStdio().ResolveCommand(ctx, r.Client, &mcpServer)
+	if err != nil {
+		return r.reconcileUnreachableServer(ctx, &mcpServer, err, false)
+	}
+
+	key := types.NamespacedName{Namespace: mcpServer.Namespace, Name: mcpServer.Name}
+	mcpClient, clientErr := r.getStdio().Client(ctx, key, command, timeout)
+	processChanged := r.reconcileProcessStatus(ctx, &mcpServer, r.getStdio().Status(key))
+	if clientErr != nil {
+		return r.reconcileUnreachableServer(ctx, &mcpServer, clientErr, processChanged)
+	}
+	return r.syncTools(ctx, mcpServer, mcpClient, timeout, processChanged)
+}
+
+// reconcileProcessStatus records the restarts of the process of a stdio server, and the end of its stderr when
+// it is not running. It returns whether the status changed.
+func (r *MCPServerReconciler) reconcileProcessStatus(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, process genai.MCPStdioProcessStatus) bool {
+	changed := false
+	if process.Restarts != mcpServer.Status.ProcessRestarts {
+		// Restarts are counted since the controller started the process, so they go down when it restarts
+		if process.Restarts > 0 {
+			r.Eventing.MCPServerRecorder().ProcessExited(ctx, mcpServer, process.LastExit)
 		}
-		return ctrl.Result{RequeueAfter: mcpServer.Spec.PollInterval.Duration}, nil
+		mcpServer.Status.ProcessRestarts = process.Restarts
+		changed = true
 	}
 
-	mcpTools, err := mcpClient.ListTools(ctx)
+	if process.Running {
+		return r.reconcileCondition(mcpServer, MCPServerProcessRunning, metav1.ConditionTrue, "Running", "The process of the server is running") || changed
+	}
+	if process.LastExit != "" {
+		message := fmt.Sprintf("Restarting at %s after the process %s", process.RetryAt.UTC().Format(time.RFC3339), process.LastExit)
+		return r.reconcileCondition(mcpServer, MCPServerProcessRunning, metav1.ConditionFalse, "BackOff", message) || changed
+	}
+	return changed
+}
+
+// syncTools publishes the tools of the server as Tools. statusChanged forces the update of the status.
+func (r *MCPServerReconciler) syncTools(ctx context.Context, mcpServer arkv1alpha1.MCPServer, mcpClient *genai.MCPClient, timeout time.Duration, statusChanged bool) (ctrl.Result, error) {
+	// Requests of long-lived sessions are not bounded by the HTTP timeout
+	discoveryCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	mcpTools, err := mcpClient.ListTools(discoveryCtx)
 	if err != nil {
-		if err := r.reconcileConditionsToolListingFailed(ctx, &mcpServer, err); err != nil {
+		// The session is reconnected by the next reconcile
+		r.getSessions().close(ctx, types.NamespacedName{Namespace: mcpServer.Namespace, Name: mcpServer.Name})
+		sessionChanged := setSessionState(&mcpServer, arkv1alpha1.MCPSessionStateDisconnected)
+		if err := r.reconcileConditionsToolListingFailed(ctx, &mcpServer, err, sessionChanged || statusChanged); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{RequeueAfter: mcpServer.Spec.PollInterval.Duration}, nil
 	}
 
-	toolsChanged, err := r.createTools(ctx, &mcpServer, mcpTools)
-	if err != nil {
+	mcpTools = publishedMCPTools(&mcpServer, mcpTools)
+	if _, err := r.createTools(ctx, &mcpServer, mcpTools); err != nil {
 		if err := r.reconcileConditionsToolCreationFailed(ctx, &mcpServer, err); err != nil {
 			return ctrl.Result{}, err
 		}
 		return ctrl.Result{RequeueAfter: mcpServer.Spec.PollInterval.Duration}, nil
 	}
 
-	return r.finalizeMCPServerProcessing(ctx, mcpServer, len(mcpTools), toolsChanged)
+	if err := r.reconcileRecoveredTools(ctx, &mcpServer); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	r.discoverResourcesAndPrompts(discoveryCtx, &mcpServer, mcpClient)
+
+	sessionState := arkv1alpha1.MCPSessionStatePolling
+	if mcpClient.NotifiesToolListChanges() {
+		sessionState = arkv1alpha1.MCPSessionStateConnected
+	}
+	setSessionState(&mcpServer, sessionState)
+	mcpServer.Status.LastSyncTime = &metav1.Time{Time: time.Now()}
+	return r.finalizeMCPServerProcessing(ctx, mcpServer, len(mcpTools))
+}
+
+// setSessionState records the state of the session to the server, and returns whether it changed.
+func setSessionState(mcpServer *arkv1alpha1.MCPServer, state string) bool {
+	changed := mcpServer.Status.SessionState != state
+	mcpServer.Status.SessionState = state
+	return changed
+}
+
+// reconcileUnreachableServer keeps the tools of a server that cannot be reached, marked unavailable, until the
+// server has been unreachable for longer than its grace period, and deletes them afterwards.
+func (r *MCPServerReconciler) reconcileUnreachableServer(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, clientErr error, statusChanged bool) (ctrl.Result, error) {
+	requeueAfter := mcpServer.Spec.PollInterval.Duration
+
+	tools, err := r.listAllMCPTools(ctx, mcpServer.Namespace, mcpServer.Name)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to list tools for MCPServer %s: %w", mcpServer.Name, err)
+	}
+
+	statusChanged = setSessionState(mcpServer, arkv1alpha1.MCPSessionStateDisconnected) || statusChanged
+	if len(tools) > 0 {
+		gracePeriod := unavailableGracePeriod(mcpServer)
+		unreachableSince := time.Now()
+		if stale := meta.FindStatusCondition(mcpServer.Status.Conditions, MCPServerToolsStale); stale != nil && stale.Status == metav1.ConditionTrue {
+			unreachableSince = stale.LastTransitionTime.Time
+		}
+
+		if remaining := gracePeriod - time.Since(unreachableSince); remaining > 0 {
+			if err := r.setToolsState(ctx, tools, "", arkv1alpha1.ToolStateUnavailable, fmt.Sprintf("MCP server %s is unreachable", mcpServer.Name)); err != nil {
+				return ctrl.Result{}, err
+			}
+			statusChanged = r.reconcileCondition(mcpServer, MCPServerToolsStale, metav1.ConditionTrue, "ServerUnreachable",
+				fmt.Sprintf("Keeping %d tools until the server has been unreachable for %s", len(tools), gracePeriod)) || statusChanged
+			requeueAfter = min(requeueAfter, remaining)
+		} else {
+			if err := r.deleteAllMCPTools(ctx, mcpServer.Namespace, mcpServer.Name); err != nil {
+				return ctrl.Result{}, err
+			}
+			message := fmt.Sprintf("Deleted %d tools after the server was unreachable for %s", len(tools), gracePeriod)
+			statusChanged = r.reconcileCondition(mcpServer, MCPServerToolsStale, metav1.ConditionFalse, "ToolsDeleted", message) || statusChanged
+			r.Eventing.MCPServerRecorder().StaleToolsDeleted(ctx, mcpServer, message)
+		}
+	}
+
+	if err := r.reconcileConditionsClientCreationFailed(ctx, mcpServer, len(tools), clientErr, statusChanged); err != nil {
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// reconcileRecoveredTools marks the tools kept while the server was unreachable as ready again.
+func (r *MCPServerReconciler) reconcileRecoveredTools(ctx context.Context, mcpServer *arkv1alpha1.MCPServer) error {
+	if !meta.IsStatusConditionTrue(mcpServer.Status.Conditions, MCPServerToolsStale) {
+		return nil
+	}
+
+	tools, err := r.listAllMCPTools(ctx, mcpServer.Namespace, mcpServer.Name)
+	if err != nil {
+		return fmt.Errorf("failed to list tools for MCPServer %s: %w", mcpServer.Name, err)
+	}
+	if err := r.setToolsState(ctx, tools, arkv1alpha1.ToolStateUnavailable, arkv1alpha1.ToolStateReady, "Tool configuration is valid"); err != nil {
+		return err
+	}
+	r.reconcileCondition(mcpServer, MCPServerToolsStale, metav1.ConditionFalse, "ServerReachable", "The server is reachable again")
+	return nil
+}
+
+// setToolsState moves the tools in the from state, or in any other state if from is empty, to the given state.
+func (r *MCPServerReconciler) setToolsState(ctx context.Context, tools []arkv1alpha1.Tool, from, state, message string) error {
+	for i := range tools {
+		tool := &tools[i]
+		if tool.Status.State == state || (from != "" && tool.Status.State != from) {
+			continue
+		}
+		tool.Status.State = state
+		tool.Status.Message = message
+		if err := r.Status().Update(ctx, tool); err != nil {
+			return fmt.Errorf("failed to update status of tool %s: %w", tool.Name, err)
+		}
+	}
+	return nil
+}
+
+func unavailableGracePeriod(mcpServer *arkv1alpha1.MCPServer) time.Duration {
+	if mcpServer.Spec.UnavailableGracePeriod == nil {
+		return defaultUnavailableGracePeriod
+	}
+	return mcpServer.Spec.UnavailableGracePeriod.Duration
+}
+
+// discoverResourcesAndPrompts records the number of resources and prompts of the server in its status. Servers
+// that fail to list them keep their previous counts, as their tools are still usable.
+func (r *MCPServerReconciler) discoverResourcesAndPrompts(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, mcpClient *genai.MCPClient) {
+	log := logf.FromContext(ctx)
+
+	if resources, err := mcpClient.ListResources(ctx); err != nil {
+		log.Info("failed to list MCP resources", "server", mcpServer.Name, "error", err.Error())
+	} else {
+		mcpServer.Status.ResourceCount = len(resources)
+	}
+
+	if prompts, err := mcpClient.ListPrompts(ctx); err != nil {
+		log.Info("failed to list MCP prompts", "server", mcpServer.Name, "error", err.Error())
+	} else {
+		mcpServer.Status.PromptCount = len(prompts)
+	}
 }
 
 // reconcileCondition updates a condition on the MCPServer
@@ -181,28 +388,42 @@ func (r *MCPServerReconciler) reconcileConditionsAddressResolutionFailed(ctx con
 	return nil
 }
 
-// reconcileConditionsClientCreationFailed updates conditions when client creation fails
-func (r *MCPServerReconciler) reconcileConditionsClientCreationFailed(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, err error) error {
+// reconcileConditionsClientCreationFailed updates conditions when client creation fails, keeping the counts of
+// tools kept during the grace period of the server. statusChanged forces the update of the status.
+func (r *MCPServerReconciler) reconcileConditionsClientCreationFailed(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, keptTools int, err error, statusChanged bool) error {
 	log := logf.FromContext(ctx)
-	mcpServer.Status.ToolCount = 0
+	countsChanged := false
+	if !meta.IsStatusConditionTrue(mcpServer.Status.Conditions, MCPServerToolsStale) {
+		countsChanged = mcpServer.Status.ToolCount != 0 || mcpServer.Status.ResourceCount != 0 || mcpServer.Status.PromptCount != 0
+		mcpServer.Status.ToolCount = 0
+		mcpServer.Status.ResourceCount = 0
+		mcpServer.Status.PromptCount = 0
+	} else {
+		countsChanged = mcpServer.Status.ToolCount != keptTools
+		mcpServer.Status.ToolCount = keptTools
+	}
 	changed1 := r.reconcileCondition(mcpServer, MCPServerAvailable, metav1.ConditionFalse, "ClientCreationFailed", "Server not ready due to client creation failure")
 	changed2 := r.reconcileCondition(mcpServer, MCPServerDiscovering, metav1.ConditionFalse, "ClientCreationFailed", "Cannot attempt discovery due to client creation failure")
 	if changed1 || changed2 {
 		log.Error(err, "mcp client creation failed", "server", mcpServer.Name)
 		r.Eventing.MCPServerRecorder().ClientCreationFailed(ctx, mcpServer, fmt.Sprintf("Failed to create MCP client: %v", err))
+	}
+	if changed1 || changed2 || countsChanged || statusChanged {
 		return r.updateStatus(ctx, mcpServer)
 	}
 	return nil
 }
 
 // reconcileConditionsToolListingFailed updates conditions when tool listing fails
-func (r *MCPServerReconciler) reconcileConditionsToolListingFailed(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, err error) error {
+func (r *MCPServerReconciler) reconcileConditionsToolListingFailed(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, err error, sessionChanged bool) error {
 	log := logf.FromContext(ctx)
 	changed1 := r.reconcileCondition(mcpServer, MCPServerDiscovering, metav1.ConditionTrue, "ServerConnectedAndToolListingFailed", err.Error())
 	changed2 := r.reconcileCondition(mcpServer, MCPServerAvailable, metav1.ConditionFalse, "ToolListingFailed", "Server not ready due to tool listing failure")
 	if changed1 || changed2 {
 		log.Error(err, "tool listing failed", "server", mcpServer.Name)
 		r.Eventing.MCPServerRecorder().ToolListingFailed(ctx, mcpServer, fmt.Sprintf("Failed to list tools: %v", err))
+	}
+	if changed1 || changed2 || sessionChanged {
 		return r.updateStatus(ctx, mcpServer)
 	}
 	return nil
@@ -221,20 +442,13 @@ func (r *MCPServerReconciler) reconcileConditionsToolCreationFailed(ctx context.
 	return nil
 }
 
-// reconcileConditionsReady updates conditions when MCPServer is ready
-func (r *MCPServerReconciler) reconcileConditionsReady(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, toolCount int, toolsChanged bool) error {
+// reconcileConditionsReady updates conditions when MCPServer is ready. The status is always updated, to record
+// the time of the sync.
+func (r *MCPServerReconciler) reconcileConditionsReady(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, toolCount int) error {
 	mcpServer.Status.ToolCount = toolCount
-	changed1 := r.reconcileCondition(mcpServer, MCPServerDiscovering, metav1.ConditionFalse, "DiscoveryComplete", "Tool discovery completed")
-	changed2 := r.reconcileCondition(mcpServer, MCPServerAvailable, metav1.ConditionTrue, "ToolsDiscovered", fmt.Sprintf("Successfully discovered %d tools", toolCount))
-
-	if changed1 || changed2 || toolsChanged {
-		if changed1 || changed2 {
-			if err := r.updateStatus(ctx, mcpServer); err != nil {
-				return err
-			}
-		}
-	}
-	return nil
+	r.reconcileCondition(mcpServer, MCPServerDiscovering, metav1.ConditionFalse, "DiscoveryComplete", "Tool discovery completed")
+	r.reconcileCondition(mcpServer, MCPServerAvailable, metav1.ConditionTrue, "ToolsDiscovered", fmt.Sprintf("Successfully discovered %d tools", toolCount))
+	return r.updateStatus(ctx, mcpServer)
 }
 
 // updateStatus updates the MCPServer status
@@ -249,7 +463,19 @@ func (r *MCPServerReconciler) updateStatus(ctx context.Context, mcpServer *arkv1
 	return err
 }
 
-func (r *MCPServerReconciler) createMCPClient(ctx context.Context, mcpServer *arkv1alpha1.MCPServer) (*genai.MCPClient, error) {
+// mcpServerTimeout parses the timeout of the server, defaulting to 30s if not specified.
+func mcpServerTimeout(mcpServer *arkv1alpha1.MCPServer) (time.Duration, error) {
+	if mcpServer.Spec.Timeout == "" {
+		return 30 * time.Second, nil
+	}
+	timeout, err := time.ParseDuration(mcpServer.Spec.Timeout)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse timeout %s: %w", mcpServer.Spec.Timeout, err)
+	}
+	return timeout, nil
+}
+
+func (r *MCPServerReconciler) createMCPClient(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, timeout time.Duration, onToolListChanged func()) (*genai.MCPClient, error) {
 	mcpURL, err := genai.BuildMCPServerURL(ctx, r.Client, mcpServer)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build MCP server URL: %v", err)
@@ -264,18 +490,12 @@ func (r *MCPServerReconciler) createMCPClient(ctx context.Context, mcpServer *ar
 		headers = resolvedHeaders
 	}
 
-	// Parse timeout from MCPServer spec (default to 30s if not specified)
-	timeout := 30 * time.Second
-	if mcpServer.Spec.Timeout != "" {
-		parsedTimeout, err := time.ParseDuration(mcpServer.Spec.Timeout)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse timeout %s: %w", mcpServer.Spec.Timeout, err)
-		}
-		timeout = parsedTimeout
+	tokenSource, err := genai.ResolveAuthTokenSource(ctx, r.Client, mcpServer.Spec.Auth, mcpServer.Namespace)
+	if err != nil {
+		return nil, err
 	}
 
-	// MCP settings are not needed for listing tools, etc.
-	mcpClient, err := genai.NewMCPClient(ctx, mcpURL, headers, mcpServer.Spec.Transport, timeout, genai.MCPSettings{})
+	mcpClient, err := genai.NewMCPWatchClient(ctx, mcpURL, headers, tokenSource, mcpServer.Spec.Transport, timeout, onToolListChanged)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MCP client: %w", err)
 	}
@@ -290,8 +510,8 @@ func (r *MCPServerReconciler) resolveHeaders(ctx context.Context, mcpServer *ark
 	return headers, nil
 }
 
-func (r *MCPServerReconciler) finalizeMCPServerProcessing(ctx context.Context, mcpServer arkv1alpha1.MCPServer, toolCount int, toolsChanged bool) (ctrl.Result, error) {
-	if err := r.reconcileConditionsReady(ctx, &mcpServer, toolCount, toolsChanged); err != nil {
+func (r *MCPServerReconciler) finalizeMCPServerProcessing(ctx context.Context, mcpServer arkv1alpha1.MCPServer, toolCount int) (ctrl.Result, error) {
+	if err := r.reconcileConditionsReady(ctx, &mcpServer, toolCount); err != nil {
 		return ctrl.Result{}, err
 	}
 
@@ -313,9 +533,21 @@ func (r *MCPServerReconciler) createTools(ctx context.Context, mcpServer *arkv1a
 		toolMap[tool.Name] = false
 	}
 
+	desiredTools := make([]*arkv1alpha1.Tool, 0, len(mcpTools))
 	for _, mcpTool := range mcpTools {
-		toolName := r.generateToolName(mcpServer.Name, mcpTool.Name)
-		tool := r.buildToolCRD(mcpServer, *mcpTool, toolName)
+		toolName := r.generateToolName(mcpServer, mcpTool.Name)
+		desiredTools = append(desiredTools, r.buildToolCRD(mcpServer, *mcpTool, toolName))
+	}
+
+	// Tools kept while the server was unreachable may no longer match what the server offers
+	if meta.IsStatusConditionTrue(mcpServer.Status.Conditions, MCPServerToolsStale) {
+		for _, drift := range describeToolDrift(existingTools, desiredTools) {
+			r.Eventing.MCPServerRecorder().ToolSchemaDrifted(ctx, mcpServer, drift)
+		}
+	}
+
+	for _, tool := range desiredTools {
+		toolName := tool.Name
 		toolMap[toolName] = true
 		toolChanged, err := r.createOrUpdateSingleTool(ctx, tool, toolName, mcpServer.Name)
 		if err != nil {
@@ -347,6 +579,94 @@ func (r *MCPServerReconciler) createTools(ctx context.Context, mcpServer *arkv1a
 	return changed, nil
 }
 
+// describeToolDrift describes how the tools the server offers differ from the existing tools of the server.
+func describeToolDrift(existingTools []arkv1alpha1.Tool, desiredTools []*arkv1alpha1.Tool) []string {
+	existing := make(map[string]arkv1alpha1.ToolSpec, len(existingTools))
+	for _, tool := range existingTools {
+		existing[tool.Name] = tool.Spec
+	}
+
+	var drift []string
+	for _, tool := range desiredTools {
+		spec, ok := existing[tool.Name]
+		delete(existing, tool.Name)
+		switch {
+		case !ok:
+			drift = append(drift, fmt.Sprintf("Tool %s was added", tool.Name))
+		case !rawExtensionsEqual(spec.InputSchema, tool.Spec.InputSchema):
+			drift = append(drift, fmt.Sprintf("Input schema of tool %s changed", tool.Name))
+		case spec.Description != tool.Spec.Description:
+			drift = append(drift, fmt.Sprintf("Description of tool %s changed", tool.Name))
+		}
+	}
+
+	removed := make([]string, 0, len(existing))
+	for name := range existing {
+		removed = append(removed, name)
+	}
+	sort.Strings(removed)
+	for _, name := range removed {
+		drift = append(drift, fmt.Sprintf("Tool %s was removed", name))
+	}
+	return drift
+}
+
+// rawExtensionsEqual compares JSON documents regardless of the order of their keys, as the API server may
+// return them in a different order than the server sent them.
+func rawExtensionsEqual(a, b *runtime.RawExtension) bool {
+	decode := func(ext *runtime.RawExtension) any {
+		var value any
+		if ext != nil {
+			_ = json.Unmarshal(ext.Raw, &value)
+		}
+		return value
+	}
+	return reflect.DeepEqual(decode(a), decode(b))
+}
+
+// publishedMCPTools returns the tools of the server selected by the include and exclude patterns of its spec.
+func publishedMCPTools(mcpServer *arkv1alpha1.MCPServer, mcpTools []*mcp.Tool) []*mcp.Tool {
+	selection := mcpServer.Spec.Tools
+	if selection == nil {
+		return mcpTools
+	}
+
+	published := make([]*mcp.Tool, 0, len(mcpTools))
+	for _, mcpTool := range mcpTools {
+		if genai.ToolNameMatches(selection.Include, selection.Exclude, mcpTool.Name) {
+			published = append(published, mcpTool)
+		}
+	}
+	return published
+}
+
+// mcpToolOverride returns the override of the spec for a tool of the server, if any.
+func mcpToolOverride(mcpServer *arkv1alpha1.MCPServer, mcpToolName string) *arkv1alpha1.MCPToolOverride {
+	if mcpServer.Spec.Tools == nil {
+		return nil
+	}
+	for i := range mcpServer.Spec.Tools.Overrides {
+		if mcpServer.Spec.Tools.Overrides[i].Name == mcpToolName {
+			return &mcpServer.Spec.Tools.Overrides[i]
+		}
+	}
+	return nil
+}
+
+// convertToolAnnotations converts MCP tool annotations, keeping the defaults of hints the server leaves unset.
+func convertToolAnnotations(mcpAnnotations *mcp.ToolAnnotations) *arkv1alpha1.ToolAnnotations {
+	if mcpAnnotations == nil {
+		return nil
+	}
+	return &arkv1alpha1.ToolAnnotations{
+		DestructiveHint: mcpAnnotations.DestructiveHint == nil || *mcpAnnotations.DestructiveHint,
+		IdempotentHint:  mcpAnnotations.IdempotentHint,
+		OpenWorldHint:   mcpAnnotations.OpenWorldHint == nil || *mcpAnnotations.OpenWorldHint,
+		ReadOnlyHint:    mcpAnnotations.ReadOnlyHint,
+		Title:           mcpAnnotations.Title,
+	}
+}
+
 func (r *MCPServerReconciler) buildToolCRD(mcpServer *arkv1alpha1.MCPServer, mcpTool mcp.Tool, toolName string) *arkv1alpha1.Tool {
 	toolAnnotations := make(map[string]string)
 
@@ -371,6 +691,7 @@ func (r *MCPServerReconciler) buildToolCRD(mcpServer *arkv1alpha1.MCPServer, mcp
 			Type:        "mcp",
 			Description: mcpTool.Description,
 			InputSchema: r.convertInputSchemaToRawExtension(mcpTool.InputSchema),
+			Annotations: convertToolAnnotations(mcpTool.Annotations),
 			MCP: &arkv1alpha1.MCPToolRef{
 				MCPServerRef: arkv1alpha1.MCPServerRef{
 					Name:      mcpServer.Name,
@@ -381,6 +702,15 @@ func (r *MCPServerReconciler) buildToolCRD(mcpServer *arkv1alpha1.MCPServer, mcp
 		},
 	}
 
+	if override := mcpToolOverride(mcpServer, mcpTool.Name); override != nil {
+		if override.Description != "" {
+			tool.Spec.Description = override.Description
+		}
+		if override.Annotations != nil {
+			tool.Spec.Annotations = override.Annotations.DeepCopy()
+		}
+	}
+
 	_ = controllerutil.SetControllerReference(mcpServer, tool, r.Scheme)
 	return tool
 }
@@ -417,14 +747,17 @@ func (r *MCPServerReconciler) createOrUpdateSingleTool(ctx context.Context, tool
 	return true, nil
 }
 
-func (r *MCPServerReconciler) generateToolName(mcpServerName, toolName string) string {
+func (r *MCPServerReconciler) generateToolName(mcpServer *arkv1alpha1.MCPServer, toolName string) string {
 	// Sanitize tool name to comply with Kubernetes RFC 1123 subdomain rules:
 	// - Only lowercase alphanumeric characters, '-' or '.'
 	// - Must start and end with alphanumeric character
 	sanitizedToolName := strings.ReplaceAll(toolName, "_", "-")
 	sanitizedToolName = strings.ToLower(sanitizedToolName)
 
-	return fmt.Sprintf("%s-%s", mcpServerName, sanitizedToolName)
+	if mcpServer.Spec.Tools != nil && mcpServer.Spec.Tools.NamePrefix != "" {
+		return mcpServer.Spec.Tools.NamePrefix + sanitizedToolName
+	}
+	return fmt.Sprintf("%s-%s", mcpServer.Name, sanitizedToolName)
 }
 
 func (r *MCPServerReconciler) convertInputSchemaToRawExtension(schema any) *runtime.RawExtension {
@@ -440,8 +773,19 @@ func (r *MCPServerReconciler) convertInputSchemaToRawExtension(schema any) *runt
 }
 
 func (r *MCPServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	sessions := r.getSessions()
+	if err := mgr.Add(sessions); err != nil {
+		return err
+	}
+	// Stdio servers are reconciled when their process exits or restarts, and when their tools change
+	r.getStdio().SetListener(func(key types.NamespacedName) {
+		sessions.enqueue(context.Background(), key)
+	})
+
+	// Status updates do not trigger reconciles, as every sync records its time in the status
 	return ctrl.NewControllerManagedBy(mgr).
-		For(&arkv1alpha1.MCPServer{}).
+		For(&arkv1alpha1.MCPServer{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		WatchesRawSource(source.Channel(sessions.events, &handler.EnqueueRequestForObject{})).
 		Named("mcpserver").
 		Complete(r)
 }
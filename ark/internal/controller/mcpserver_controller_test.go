@@ -0,0 +1,160 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("MCPServer Controller Tool Drift", func() {
+	newTool := func(name, description, schema string) arkv1alpha1.Tool {
+		return arkv1alpha1.Tool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec: arkv1alpha1.ToolSpec{
+				Type:        "mcp",
+				Description: description,
+				InputSchema: &runtime.RawExtension{Raw: []byte(schema)},
+			},
+		}
+	}
+
+	It("should report no drift for unchanged tools", func() {
+		existing := []arkv1alpha1.Tool{newTool("docs-search", "Search docs", `{"type":"object","required":["query"]}`)}
+		desired := newTool("docs-search", "Search docs", `{"required":["query"],"type":"object"}`)
+
+		Expect(describeToolDrift(existing, []*arkv1alpha1.Tool{&desired})).To(BeEmpty())
+	})
+
+	It("should report changed, added and removed tools", func() {
+		existing := []arkv1alpha1.Tool{
+			newTool("docs-search", "Search docs", `{"type":"object"}`),
+			newTool("docs-read", "Read a page", `{"type":"object"}`),
+			newTool("docs-list", "List pages", `{"type":"object"}`),
+		}
+		search := newTool("docs-search", "Search docs", `{"type":"object","required":["query"]}`)
+		read := newTool("docs-read", "Read a page of the docs", `{"type":"object"}`)
+		summarize := newTool("docs-summarize", "Summarize a page", `{"type":"object"}`)
+
+		Expect(describeToolDrift(existing, []*arkv1alpha1.Tool{&search, &read, &summarize})).To(Equal([]string{
+			"Input schema of tool docs-search changed",
+			"Description of tool docs-read changed",
+			"Tool docs-summarize was added",
+			"Tool docs-list was removed",
+		}))
+	})
+})
+
+var _ = Describe("MCPServer Controller Tool Publishing", func() {
+	var (
+		reconciler *MCPServerReconciler
+		mcpServer  *arkv1alpha1.MCPServer
+	)
+
+	BeforeEach(func() {
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+		reconciler = &MCPServerReconciler{Scheme: scheme}
+		mcpServer = &arkv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "github", Namespace: "default"},
+			Spec: arkv1alpha1.MCPServerSpec{
+				Tools: &arkv1alpha1.MCPServerTools{
+					Include:    []string{"search_*", "get_*"},
+					Exclude:    []string{"*_admin"},
+					NamePrefix: "gh-",
+					Overrides: []arkv1alpha1.MCPToolOverride{{
+						Name:        "search_issues",
+						Description: "Search issues of the team repositories",
+						Annotations: &arkv1alpha1.ToolAnnotations{ReadOnlyHint: true},
+					}},
+				},
+			},
+		}
+	})
+
+	It("should publish the tools selected by the include and exclude patterns", func() {
+		mcpTools := []*mcp.Tool{{Name: "search_issues"}, {Name: "get_admin"}, {Name: "delete_repo"}, {Name: "get_file"}}
+
+		published := publishedMCPTools(mcpServer, mcpTools)
+		Expect(published).To(HaveLen(2))
+		Expect(published[0].Name).To(Equal("search_issues"))
+		Expect(published[1].Name).To(Equal("get_file"))
+
+		mcpServer.Spec.Tools = nil
+		Expect(publishedMCPTools(mcpServer, mcpTools)).To(HaveLen(4))
+	})
+
+	It("should name tools with the prefix", func() {
+		Expect(reconciler.generateToolName(mcpServer, "search_issues")).To(Equal("gh-search-issues"))
+
+		mcpServer.Spec.Tools.NamePrefix = ""
+		Expect(reconciler.generateToolName(mcpServer, "search_issues")).To(Equal("github-search-issues"))
+	})
+
+	It("should apply overrides to generated tools", func() {
+		readOnly := false
+		mcpTool := mcp.Tool{
+			Name:        "search_issues",
+			Description: "Search issues",
+			Annotations: &mcp.ToolAnnotations{DestructiveHint: &readOnly, Title: "Search"},
+		}
+
+		tool := reconciler.buildToolCRD(mcpServer, mcpTool, "gh-search-issues")
+		Expect(tool.Spec.Description).To(Equal("Search issues of the team repositories"))
+		Expect(tool.Spec.Annotations).To(Equal(&arkv1alpha1.ToolAnnotations{ReadOnlyHint: true}))
+		Expect(tool.Spec.MCP.ToolName).To(Equal("search_issues"))
+
+		mcpTool.Name = "get_file"
+		tool = reconciler.buildToolCRD(mcpServer, mcpTool, "gh-get-file")
+		Expect(tool.Spec.Description).To(Equal("Search issues"))
+		Expect(tool.Spec.Annotations).To(Equal(&arkv1alpha1.ToolAnnotations{OpenWorldHint: true, Title: "Search"}))
+	})
+})
+
+var _ = Describe("MCPServer Controller Process Status", func() {
+	var (
+		reconciler *MCPServerReconciler
+		mcpServer  *arkv1alpha1.MCPServer
+	)
+
+	BeforeEach(func() {
+		reconciler = &MCPServerReconciler{Eventing: eventnoop.NewProvider()}
+		mcpServer = &arkv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "filesystem", Namespace: "default"},
+			Spec:       arkv1alpha1.MCPServerSpec{Transport: "stdio"},
+		}
+	})
+
+	It("should report running processes", func() {
+		Expect(reconciler.reconcileProcessStatus(context.Background(), mcpServer, genai.MCPStdioProcessStatus{Running: true})).To(BeTrue())
+		condition := meta.FindStatusCondition(mcpServer.Status.Conditions, MCPServerProcessRunning)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+
+		Expect(reconciler.reconcileProcessStatus(context.Background(), mcpServer, genai.MCPStdioProcessStatus{Running: true})).To(BeFalse())
+	})
+
+	It("should report restarts and the backoff of exited processes", func() {
+		retryAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+		process := genai.MCPStdioProcessStatus{Restarts: 2, LastExit: "exited with code 2; stderr: fatal: out of memory", RetryAt: retryAt}
+
+		Expect(reconciler.reconcileProcessStatus(context.Background(), mcpServer, process)).To(BeTrue())
+		Expect(mcpServer.Status.ProcessRestarts).To(Equal(int32(2)))
+		condition := meta.FindStatusCondition(mcpServer.Status.Conditions, MCPServerProcessRunning)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("BackOff"))
+		Expect(condition.Message).To(Equal("Restarting at 2025-01-01T12:00:00Z after the process exited with code 2; stderr: fatal: out of memory"))
+	})
+})
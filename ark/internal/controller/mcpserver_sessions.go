@@ -0,0 +1,149 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"sync"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+// mcpServerSessionEvents is the number of pending reconciliations requested by sessions before further
+// requests are dropped. Dropped requests are made up for by the poll interval.
+const mcpServerSessionEvents = 1024
+
+// mcpServerSessions keeps a long-lived session open to every MCPServer, so that the tools of a server are
+// reconciled as soon as it sends a tools/list_changed notification, rather than on the next poll. Sessions
+// also request a reconciliation when they close, so that the server is reconnected or reported unreachable.
+type mcpServerSessions struct {
+	mu       sync.Mutex
+	sessions map[types.NamespacedName]*mcpServerSession
+	events   chan event.GenericEvent
+}
+
+type mcpServerSession struct {
+	client     *genai.MCPClient
+	generation int64
+	address    string
+	closing    bool
+}
+
+func newMCPServerSessions() *mcpServerSessions {
+	return &mcpServerSessions{
+		sessions: make(map[types.NamespacedName]*mcpServerSession),
+		events:   make(chan event.GenericEvent, mcpServerSessionEvents),
+	}
+}
+
+// connectFunc connects a session that calls onToolListChanged on tools/list_changed notifications.
+type connectFunc func(ctx context.Context, onToolListChanged func()) (*genai.MCPClient, error)
+
+// get returns the open session of the server, or connects a new one when there is none, or when the spec or
+// the address of the server changed since it was connected.
+func (s *mcpServerSessions) get(ctx context.Context, mcpServer *arkv1alpha1.MCPServer, connect connectFunc) (*genai.MCPClient, error) {
+	key := types.NamespacedName{Namespace: mcpServer.Namespace, Name: mcpServer.Name}
+
+	s.mu.Lock()
+	session, exists := s.sessions[key]
+	if exists && session.generation == mcpServer.Generation && session.address == mcpServer.Status.ResolvedAddress {
+		s.mu.Unlock()
+		return session.client, nil
+	}
+	s.mu.Unlock()
+	if exists {
+		s.close(ctx, key)
+	}
+
+	mcpClient, err := connect(ctx, func() {
+		logf.FromContext(ctx).Info("MCP server tools changed", "server", key.Name)
+		s.enqueue(ctx, key)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	session = &mcpServerSession{client: mcpClient, generation: mcpServer.Generation, address: mcpServer.Status.ResolvedAddress}
+	s.mu.Lock()
+	previous := s.sessions[key]
+	s.sessions[key] = session
+	s.mu.Unlock()
+	if previous != nil {
+		s.closeSession(ctx, previous)
+	}
+
+	go s.watch(ctx, key, session)
+	return mcpClient, nil
+}
+
+// watch removes the session once it is closed by the server, and requests a reconciliation of the server.
+func (s *mcpServerSessions) watch(ctx context.Context, key types.NamespacedName, session *mcpServerSession) {
+	err := session.client.Wait()
+
+	s.mu.Lock()
+	closing := session.closing
+	if s.sessions[key] == session {
+		delete(s.sessions, key)
+	}
+	s.mu.Unlock()
+
+	if !closing {
+		logf.FromContext(ctx).Info("MCP server session closed", "server", key.Name, "error", err)
+		s.enqueue(ctx, key)
+	}
+}
+
+func (s *mcpServerSessions) enqueue(ctx context.Context, key types.NamespacedName) {
+	mcpServer := &arkv1alpha1.MCPServer{ObjectMeta: metav1.ObjectMeta{Namespace: key.Namespace, Name: key.Name}}
+	select {
+	case s.events <- event.GenericEvent{Object: mcpServer}:
+	default:
+		logf.FromContext(ctx).Info("dropping MCP server reconciliation request, the server is synchronized on its poll interval", "server", key.Name)
+	}
+}
+
+// close closes the session of the server, if any.
+func (s *mcpServerSessions) close(ctx context.Context, key types.NamespacedName) {
+	s.mu.Lock()
+	session, exists := s.sessions[key]
+	delete(s.sessions, key)
+	s.mu.Unlock()
+
+	if exists {
+		s.closeSession(ctx, session)
+	}
+}
+
+func (s *mcpServerSessions) closeSession(ctx context.Context, session *mcpServerSession) {
+	s.mu.Lock()
+	session.closing = true
+	s.mu.Unlock()
+
+	if err := session.client.Close(); err != nil {
+		logf.FromContext(ctx).V(1).Info("failed to close MCP server session", "error", err.Error())
+	}
+}
+
+// Start closes all sessions once the context is done. It lets the sessions be closed with the controller
+// manager.
+func (s *mcpServerSessions) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	s.mu.Lock()
+	keys := make([]types.NamespacedName, 0, len(s.sessions))
+	for key := range s.sessions {
+		keys = append(keys, key)
+	}
+	s.mu.Unlock()
+
+	for _, key := range keys {
+		s.close(context.Background(), key)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("MCPServer Sessions", func() {
+	var (
+		ctx        context.Context
+		server     *mcp.Server
+		httpServer *httptest.Server
+		sessions   *mcpServerSessions
+		mcpServer  *arkv1alpha1.MCPServer
+		connect    connectFunc
+	)
+
+	addTool := func(name string) {
+		server.AddTool(&mcp.Tool{Name: name, InputSchema: &jsonschema.Schema{Type: "object"}},
+			func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return &mcp.CallToolResult{}, nil
+			})
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = mcp.NewServer(&mcp.Implementation{Name: "docs", Version: "v1"}, nil)
+		addTool("search")
+		httpServer = httptest.NewServer(mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil))
+		DeferCleanup(httpServer.Close)
+
+		sessions = newMCPServerSessions()
+		DeferCleanup(sessions.close, context.Background(), types.NamespacedName{Namespace: "default", Name: "docs"})
+		mcpServer = &arkv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "docs", Namespace: "default", Generation: 1},
+			Status:     arkv1alpha1.MCPServerStatus{ResolvedAddress: httpServer.URL},
+		}
+		connect = func(ctx context.Context, onToolListChanged func()) (*genai.MCPClient, error) {
+			return genai.NewMCPWatchClient(ctx, httpServer.URL, nil, nil, "http", 5*time.Second, onToolListChanged)
+		}
+	})
+
+	It("should reuse the session until the spec of the server changes", func() {
+		first, err := sessions.get(ctx, mcpServer, connect)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first.NotifiesToolListChanges()).To(BeTrue())
+
+		second, err := sessions.get(ctx, mcpServer, connect)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(second).To(BeIdenticalTo(first))
+
+		mcpServer.Generation = 2
+		third, err := sessions.get(ctx, mcpServer, connect)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(third).NotTo(BeIdenticalTo(first))
+		Expect(first.Wait()).To(Succeed())
+
+		// Sessions closed by the controller do not request reconciliations
+		Consistently(sessions.events, 200*time.Millisecond).ShouldNot(Receive())
+	})
+
+	It("should request a reconciliation when the tools of the server change", func() {
+		_, err := sessions.get(ctx, mcpServer, connect)
+		Expect(err).NotTo(HaveOccurred())
+
+		addTool("read")
+
+		var requested event.GenericEvent
+		Eventually(sessions.events, 5*time.Second).Should(Receive(&requested))
+		Expect(requested.Object.GetNamespace()).To(Equal("default"))
+		Expect(requested.Object.GetName()).To(Equal("docs"))
+	})
+})
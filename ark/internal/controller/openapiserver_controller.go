@@ -0,0 +1,406 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/eventing"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const (
+	// Condition types
+	OpenAPIServerAvailable = "Available"
+
+	openAPIDocumentTimeout = 30 * time.Second
+	openAPIDefaultPoll     = 5 * time.Minute
+)
+
+var invalidToolNameChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+type OpenAPIServerReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Eventing eventing.Provider
+	resolver *common.ValueSourceResolver
+}
+
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=openapiservers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=openapiservers/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=openapiservers/finalizers,verbs=update
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=tools,verbs=get;list;watch;create;update;patch;delete;deletecollection
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+
+func (r *OpenAPIServerReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	var server arkv1alpha1.OpenAPIServer
+	if err := r.Get(ctx, req.NamespacedName, &server); err != nil {
+		if errors.IsNotFound(err) {
+			// OpenAPIServer was deleted, tools will be garbage collected due to owner references
+			log.Info("OpenAPIServer deleted, associated tools will be garbage collected", "server", req.Name)
+			return ctrl.Result{}, nil
+		}
+		log.Error(err, "unable to fetch OpenAPIServer")
+		return ctrl.Result{}, err
+	}
+
+	requeue := ctrl.Result{RequeueAfter: pollInterval(server.Spec.PollInterval)}
+
+	data, err := r.loadDocument(ctx, &server)
+	if err != nil {
+		return requeue, r.reconcileConditionFailed(ctx, &server, "DocumentLoadFailed", err, r.Eventing.OpenAPIServerRecorder().DocumentLoadFailed)
+	}
+
+	doc, err := genai.ParseOpenAPIDocument(data)
+	if err != nil {
+		return requeue, r.reconcileConditionFailed(ctx, &server, "DocumentParseFailed", err, r.Eventing.OpenAPIServerRecorder().DocumentParseFailed)
+	}
+
+	tools, err := r.buildTools(&server, doc)
+	var skipped []string
+	if err == nil {
+		skipped, err = r.createTools(ctx, &server, tools)
+	}
+	if err != nil {
+		return requeue, r.reconcileConditionFailed(ctx, &server, "ToolCreationFailed", err, r.Eventing.OpenAPIServerRecorder().ToolCreationFailed)
+	}
+
+	server.Status.ToolCount = len(tools) - len(skipped)
+	message := fmt.Sprintf("Successfully generated %d tools", server.Status.ToolCount)
+	if len(skipped) > 0 {
+		message += fmt.Sprintf(", skipped tools owned by other resources: %s", strings.Join(skipped, ", "))
+	}
+	changed := meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               OpenAPIServerAvailable,
+		Status:             metav1.ConditionTrue,
+		Reason:             "ToolsGenerated",
+		Message:            message,
+		ObservedGeneration: server.Generation,
+	})
+	if changed {
+		if err := r.updateStatus(ctx, &server); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+	return requeue, nil
+}
+
+func pollInterval(interval *metav1.Duration) time.Duration {
+	if interval == nil || interval.Duration <= 0 {
+		return openAPIDefaultPoll
+	}
+	return interval.Duration
+}
+
+func (r *OpenAPIServerReconciler) getResolver() *common.ValueSourceResolver {
+	if r.resolver == nil {
+		r.resolver = common.NewValueSourceResolver(r.Client)
+	}
+	return r.resolver
+}
+
+// reconcileConditionFailed marks the server unavailable and emits a warning when the reason changes.
+// Existing tools are kept so that a temporarily unreachable document does not remove them.
+func (r *OpenAPIServerReconciler) reconcileConditionFailed(ctx context.Context, server *arkv1alpha1.OpenAPIServer, reason string, err error, emit func(context.Context, runtime.Object, string)) error {
+	changed := meta.SetStatusCondition(&server.Status.Conditions, metav1.Condition{
+		Type:               OpenAPIServerAvailable,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            err.Error(),
+		ObservedGeneration: server.Generation,
+	})
+	if !changed {
+		return nil
+	}
+	logf.FromContext(ctx).Error(err, "OpenAPIServer reconciliation failed", "server", server.Name, "reason", reason)
+	emit(ctx, server, err.Error())
+	return r.updateStatus(ctx, server)
+}
+
+func (r *OpenAPIServerReconciler) updateStatus(ctx context.Context, server *arkv1alpha1.OpenAPIServer) error {
+	if ctx.Err() != nil {
+		return nil
+	}
+	err := r.Status().Update(ctx, server)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "failed to update OpenAPIServer status")
+	}
+	return err
+}
+
+func (r *OpenAPIServerReconciler) loadDocument(ctx context.Context, server *arkv1alpha1.OpenAPIServer) ([]byte, error) {
+	source := server.Spec.Document
+	switch {
+	case source.Inline != "":
+		return []byte(source.Inline), nil
+	case source.ConfigMapKeyRef != nil:
+		value, err := r.getResolver().ResolveValueSource(ctx, arkv1alpha1.ValueSource{
+			ValueFrom: &arkv1alpha1.ValueFromSource{ConfigMapKeyRef: source.ConfigMapKeyRef},
+		}, server.Namespace)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(value), nil
+	case source.URL != "":
+		return r.fetchDocument(ctx, server)
+	default:
+		return nil, fmt.Errorf("document requires one of url, configMapKeyRef or inline")
+	}
+}
+
+func (r *OpenAPIServerReconciler) fetchDocument(ctx context.Context, server *arkv1alpha1.OpenAPIServer) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.Spec.Document.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// The document is often served behind the same authentication as the API
+	headers, err := genai.ResolveHeaders(ctx, r.Client, server.Spec.Headers, server.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	httpClient := &http.Client{Timeout: openAPIDocumentTimeout}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch document: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode >= 400 {
+		return nil, fmt.Errorf("failed to fetch document: HTTP %d", resp.StatusCode)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// buildTools generates one http Tool per selected operation of the document.
+func (r *OpenAPIServerReconciler) buildTools(server *arkv1alpha1.OpenAPIServer, doc *genai.OpenAPIDocument) ([]*arkv1alpha1.Tool, error) {
+	baseURL := server.Spec.BaseURL
+	if baseURL == "" && len(doc.Servers) > 0 {
+		baseURL = doc.Servers[0]
+	}
+	if !strings.HasPrefix(baseURL, "http://") && !strings.HasPrefix(baseURL, "https://") {
+		return nil, fmt.Errorf("no absolute base URL: set baseURL or an absolute server URL in the document")
+	}
+
+	var tools []*arkv1alpha1.Tool
+	names := make(map[string]bool)
+	for _, op := range doc.Operations {
+		if !operationSelected(server.Spec.Operations, op.OperationID) {
+			continue
+		}
+
+		toolName := r.generateToolName(server.Name, op.OperationID)
+		for i := 2; names[toolName]; i++ {
+			toolName = fmt.Sprintf("%s-%d", r.generateToolName(server.Name, op.OperationID), i)
+		}
+		names[toolName] = true
+
+		tool, err := r.buildToolCRD(server, op, baseURL, toolName)
+		if err != nil {
+			return nil, fmt.Errorf("operation %s: %w", op.OperationID, err)
+		}
+		tools = append(tools, tool)
+	}
+	return tools, nil
+}
+
+// operationSelected applies the include and exclude filters to an operationId.
+func operationSelected(filter *arkv1alpha1.OpenAPIOperationFilter, operationID string) bool {
+	if filter == nil {
+		return true
+	}
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, operationID); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(filter.Include) > 0 && !matches(filter.Include) {
+		return false
+	}
+	return !matches(filter.Exclude)
+}
+
+func (r *OpenAPIServerReconciler) buildToolCRD(server *arkv1alpha1.OpenAPIServer, op genai.OpenAPIOperation, baseURL, toolName string) (*arkv1alpha1.Tool, error) {
+	toolAnnotations := make(map[string]string)
+	for key, value := range server.Annotations {
+		if strings.HasPrefix(key, annotations.ARKPrefix) {
+			toolAnnotations[key] = value
+		}
+	}
+
+	// Path parameters are already {name} placeholders; query parameters are appended as placeholders
+	// that the http executor leaves out when they are not provided
+	toolURL := strings.TrimSuffix(baseURL, "/") + op.Path
+	if len(op.QueryParameters) > 0 {
+		query := make([]string, 0, len(op.QueryParameters))
+		for _, name := range op.QueryParameters {
+			query = append(query, fmt.Sprintf("%s={%s}", name, name))
+		}
+		toolURL += "?" + strings.Join(query, "&")
+	}
+
+	httpSpec := &arkv1alpha1.HTTPSpec{
+		URL:     toolURL,
+		Method:  op.Method,
+		Headers: append([]arkv1alpha1.Header{}, server.Spec.Headers...),
+		Timeout: server.Spec.Timeout,
+	}
+	if op.HasBody {
+		// The body is optional, so calls without it send no body rather than failing the template
+		httpSpec.Body = "{{ with .input.body }}{{ toJson . }}{{ end }}"
+		httpSpec.Headers = append(httpSpec.Headers, arkv1alpha1.Header{
+			Name:  "Content-Type",
+			Value: arkv1alpha1.HeaderValue{Value: "application/json"},
+		})
+	}
+
+	inputSchema, err := json.Marshal(op.InputSchema)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal input schema: %w", err)
+	}
+
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      toolName,
+			Namespace: server.Namespace,
+			Labels: map[string]string{
+				labels.OpenAPIServerLabel: server.Name,
+			},
+			Annotations: toolAnnotations,
+		},
+		Spec: arkv1alpha1.ToolSpec{
+			Type:        genai.ToolTypeHTTP,
+			Description: op.Description,
+			InputSchema: &runtime.RawExtension{Raw: inputSchema},
+			HTTP:        httpSpec,
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(server, tool, r.Scheme); err != nil {
+		return nil, err
+	}
+	return tool, nil
+}
+
+func (r *OpenAPIServerReconciler) generateToolName(serverName, operationID string) string {
+	// Tool names must be RFC 1123 subdomains: lowercase alphanumerics and '-'
+	sanitized := strings.Trim(invalidToolNameChars.ReplaceAllString(strings.ToLower(operationID), "-"), "-")
+	return fmt.Sprintf("%s-%s", serverName, sanitized)
+}
+
+// createTools creates or updates the generated tools and deletes tools of operations that are gone. It returns the
+// names of the tools it skipped because another resource owns them.
+func (r *OpenAPIServerReconciler) createTools(ctx context.Context, server *arkv1alpha1.OpenAPIServer, tools []*arkv1alpha1.Tool) ([]string, error) {
+	log := logf.FromContext(ctx)
+
+	var existing arkv1alpha1.ToolList
+	if err := r.List(ctx, &existing, client.InNamespace(server.Namespace), client.MatchingLabels{labels.OpenAPIServerLabel: server.Name}); err != nil {
+		return nil, fmt.Errorf("failed to list tools for OpenAPIServer %s: %w", server.Name, err)
+	}
+
+	var skipped []string
+	wanted := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		wanted[tool.Name] = true
+		owned, err := r.createOrUpdateTool(ctx, tool, server)
+		if err != nil {
+			return nil, err
+		}
+		if !owned {
+			skipped = append(skipped, tool.Name)
+			r.Eventing.OpenAPIServerRecorder().ToolCreationFailed(ctx, server,
+				fmt.Sprintf("tool %s already exists and is not owned by OpenAPIServer %s", tool.Name, server.Name))
+		}
+	}
+
+	// delete zombie tools
+	for i := range existing.Items {
+		tool := &existing.Items[i]
+		if wanted[tool.Name] {
+			continue
+		}
+		if err := r.Delete(ctx, tool); err != nil && !errors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to delete tool %s: %w", tool.Name, err)
+		}
+		log.Info("tool crd deleted", "tool", tool.Name, "openAPIServer", server.Name, "namespace", server.Namespace)
+	}
+	return skipped, nil
+}
+
+// createOrUpdateTool creates the tool, or updates it when the server owns it. It returns false when the tool exists
+// and is owned by another resource, which it leaves untouched.
+func (r *OpenAPIServerReconciler) createOrUpdateTool(ctx context.Context, tool *arkv1alpha1.Tool, server *arkv1alpha1.OpenAPIServer) (bool, error) {
+	log := logf.FromContext(ctx)
+	existingTool := &arkv1alpha1.Tool{}
+	err := r.Get(ctx, client.ObjectKey{Name: tool.Name, Namespace: tool.Namespace}, existingTool)
+
+	if errors.IsNotFound(err) {
+		if err := r.Create(ctx, tool); err != nil {
+			return false, fmt.Errorf("failed to create tool %s: %w", tool.Name, err)
+		}
+		log.Info("tool crd created", "tool", tool.Name, "openAPIServer", server.Name, "namespace", tool.Namespace)
+		return true, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get tool %s: %w", tool.Name, err)
+	}
+	if !metav1.IsControlledBy(existingTool, server) {
+		log.Info("tool crd owned by another resource, skipping", "tool", tool.Name, "openAPIServer", server.Name, "namespace", tool.Namespace)
+		return false, nil
+	}
+
+	// Check if spec actually changed
+	toolSpecJSON, _ := json.Marshal(tool.Spec)
+	existingSpecJSON, _ := json.Marshal(existingTool.Spec)
+	if string(toolSpecJSON) == string(existingSpecJSON) {
+		return true, nil
+	}
+
+	existingTool.Spec = tool.Spec
+	if err := r.Update(ctx, existingTool); err != nil {
+		return false, fmt.Errorf("failed to update tool %s: %w", tool.Name, err)
+	}
+	log.Info("tool crd updated", "tool", tool.Name, "openAPIServer", server.Name, "namespace", existingTool.Namespace)
+	return true, nil
+}
+
+func (r *OpenAPIServerReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&arkv1alpha1.OpenAPIServer{}).
+		Named("openapiserver").
+		Complete(r)
+}
@@ -0,0 +1,191 @@
+/* Copyright 2025. McKinsey & Company */
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/genai"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const petsDocument = `
+openapi: 3.0.3
+servers:
+  - url: https://pets.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      description: List the pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        required: true
+        schema:
+          type: string
+    patch:
+      operationId: updatePet
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+    delete:
+      operationId: deletePet
+`
+
+var _ = Describe("OpenAPIServer Controller", func() {
+	var (
+		ctx        context.Context
+		k8sClient  client.Client
+		reconciler *OpenAPIServerReconciler
+		server     *arkv1alpha1.OpenAPIServer
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(arkv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		server = &arkv1alpha1.OpenAPIServer{
+			ObjectMeta: metav1.ObjectMeta{Name: "pets", Namespace: "default"},
+			Spec: arkv1alpha1.OpenAPIServerSpec{
+				Document:   arkv1alpha1.OpenAPIDocumentSource{Inline: petsDocument},
+				Operations: &arkv1alpha1.OpenAPIOperationFilter{Exclude: []string{"delete*"}},
+			},
+		}
+		stale := &arkv1alpha1.Tool{ObjectMeta: metav1.ObjectMeta{
+			Name:      "pets-removedoperation",
+			Namespace: "default",
+			Labels:    map[string]string{labels.OpenAPIServerLabel: "pets"},
+		}}
+		k8sClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(server, stale).
+			WithStatusSubresource(&arkv1alpha1.OpenAPIServer{}).
+			Build()
+		reconciler = &OpenAPIServerReconciler{Client: k8sClient, Scheme: scheme, Eventing: eventnoop.NewProvider()}
+	})
+
+	reconcile := func() {
+		_, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "pets", Namespace: "default"}})
+		Expect(err).NotTo(HaveOccurred())
+	}
+
+	getTool := func(name string) *arkv1alpha1.Tool {
+		tool := &arkv1alpha1.Tool{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, tool)).To(Succeed())
+		return tool
+	}
+
+	It("should generate a tool for every selected operation and remove stale tools", func() {
+		reconcile()
+
+		var tools arkv1alpha1.ToolList
+		Expect(k8sClient.List(ctx, &tools, client.MatchingLabels{labels.OpenAPIServerLabel: "pets"})).To(Succeed())
+		var names []string
+		for _, tool := range tools.Items {
+			names = append(names, tool.Name)
+		}
+		Expect(names).To(ConsistOf("pets-listpets", "pets-updatepet"))
+
+		list := getTool("pets-listpets")
+		Expect(list.Spec.Type).To(Equal(genai.ToolTypeHTTP))
+		Expect(list.Spec.Description).To(Equal("List the pets"))
+		Expect(list.Spec.HTTP.URL).To(Equal("https://pets.example.com/v1/pets?limit={limit}"))
+		Expect(list.Spec.HTTP.Body).To(BeEmpty())
+		Expect(list.OwnerReferences).To(HaveLen(1))
+
+		updated := &arkv1alpha1.OpenAPIServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "pets", Namespace: "default"}, updated)).To(Succeed())
+		Expect(updated.Status.ToolCount).To(Equal(2))
+		condition := meta.FindStatusCondition(updated.Status.Conditions, OpenAPIServerAvailable)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+	})
+
+	It("should generate body templates that resolve without a body argument", func() {
+		reconcile()
+
+		update := getTool("pets-updatepet")
+		Expect(update.Spec.HTTP.Method).To(Equal("PATCH"))
+		Expect(update.Spec.HTTP.Headers).To(ContainElement(arkv1alpha1.Header{
+			Name:  "Content-Type",
+			Value: arkv1alpha1.HeaderValue{Value: "application/json"},
+		}))
+
+		body, err := genai.ResolveBodyTemplate(ctx, k8sClient, "default", update.Spec.HTTP.Body, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(BeEmpty())
+
+		body, err = genai.ResolveBodyTemplate(ctx, k8sClient, "default", update.Spec.HTTP.Body, nil, map[string]any{"petId": "rex"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(BeEmpty())
+
+		body, err = genai.ResolveBodyTemplate(ctx, k8sClient, "default", update.Spec.HTTP.Body, nil, map[string]any{
+			"petId": "rex",
+			"body":  map[string]any{"name": "Rex"},
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(body).To(MatchJSON(`{"name":"Rex"}`))
+	})
+
+	It("should not take over tools owned by other resources", func() {
+		handwritten := &arkv1alpha1.Tool{
+			ObjectMeta: metav1.ObjectMeta{Name: "pets-listpets", Namespace: "default"},
+			Spec:       arkv1alpha1.ToolSpec{Type: genai.ToolTypeHTTP, Description: "Written by hand"},
+		}
+		Expect(k8sClient.Create(ctx, handwritten)).To(Succeed())
+		reconcile()
+
+		list := getTool("pets-listpets")
+		Expect(list.Spec.Description).To(Equal("Written by hand"))
+		Expect(list.OwnerReferences).To(BeEmpty())
+		Expect(getTool("pets-updatepet").OwnerReferences).To(HaveLen(1))
+
+		updated := &arkv1alpha1.OpenAPIServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "pets", Namespace: "default"}, updated)).To(Succeed())
+		Expect(updated.Status.ToolCount).To(Equal(1))
+		condition := meta.FindStatusCondition(updated.Status.Conditions, OpenAPIServerAvailable)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		Expect(condition.Message).To(ContainSubstring("skipped tools owned by other resources: pets-listpets"))
+	})
+
+	It("should keep existing tools and report documents that fail to parse", func() {
+		reconcile()
+
+		current := &arkv1alpha1.OpenAPIServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "pets", Namespace: "default"}, current)).To(Succeed())
+		current.Spec.Document.Inline = "openapi: ["
+		Expect(k8sClient.Update(ctx, current)).To(Succeed())
+		reconcile()
+
+		getTool("pets-listpets")
+		updated := &arkv1alpha1.OpenAPIServer{}
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "pets", Namespace: "default"}, updated)).To(Succeed())
+		condition := meta.FindStatusCondition(updated.Status.Conditions, OpenAPIServerAvailable)
+		Expect(condition).NotTo(BeNil())
+		Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+		Expect(condition.Reason).To(Equal("DocumentParseFailed"))
+	})
+})
@@ -50,7 +50,9 @@ type targetResult struct {
 // - Never import OTEL packages directly - use the abstraction layer
 type QueryReconciler struct {
 	client.Client
-	Scheme     *runtime.Scheme
+	Scheme *runtime.Scheme
+	// Config is the identity of the controller, used to read pod logs for queries without a service account
+	Config     *rest.Config
 	Telemetry  *telemetryconfig.Provider
 	Eventing   *eventingconfig.Provider
 	operations sync.Map
@@ -62,6 +64,7 @@ type QueryReconciler struct {
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams,verbs=get;list
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=models,verbs=get;list
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=toolpolicies,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;list;watch;patch
 // +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=impersonate
 
@@ -180,6 +183,10 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 		}
 	}()
 
+	// MCP sessions leased by the agents of the query are returned to the shared pool once it completes
+	opCtx, releaseMCPSessions := genai.WithMCPSessionScope(opCtx)
+	defer releaseMCPSessions()
+
 	sessionId := obj.Spec.SessionId
 	if sessionId == "" {
 		sessionId = string(obj.UID)
@@ -197,6 +204,14 @@ func (r *QueryReconciler) executeQueryAsync(opCtx context.Context, obj arkv1alph
 		return
 	}
 
+	// Pod logs cannot be read through the client, so the k8s_logs tool gets a reader with the same identity.
+	// The Kubernetes tools never read with the identity of the controller.
+	if obj.Spec.ServiceAccount != "" {
+		if cfg, err := r.getRESTConfigForQuery(obj); err == nil && cfg != nil {
+			opCtx = genai.WithPodLogReader(opCtx, genai.NewPodLogReader(cfg))
+		}
+	}
+
 	// Get conversation ID from memory if attached
 	if memory != nil {
 		if httpMemory, ok := memory.(*genai.HTTPMemory); ok {
@@ -466,7 +481,7 @@ func (r *QueryReconciler) processTargetResults(resultChan chan targetResult) []a
 		case result.executionResult == nil || result.executionResult.Messages == nil:
 			// Skip targets that were delegated to external execution engines (executionResult == nil or messages == nil)
 		default:
-			response := r.createSuccessResponse(result.target, result.executionResult.Messages)
+			response := r.createSuccessResponse(result.target, result.executionResult)
 			if result.executionResult.A2AResponse != nil {
 				response.A2A = &arkv1alpha1.A2AMetadata{
 					ContextID: result.executionResult.A2AResponse.ContextID,
@@ -480,18 +495,28 @@ func (r *QueryReconciler) processTargetResults(resultChan chan targetResult) []a
 	return allResponses
 }
 
-func (r *QueryReconciler) createSuccessResponse(target arkv1alpha1.QueryTarget, messages []genai.Message) arkv1alpha1.Response {
+func (r *QueryReconciler) createSuccessResponse(target arkv1alpha1.QueryTarget, result *genai.ExecutionResult) arkv1alpha1.Response {
+	messages := result.Messages
 	rawJSON, err := serializeMessages(messages)
+	var rawTeamState, rawVote *runtime.RawExtension
+	if err == nil && result.TeamState != nil {
+		rawTeamState, err = serializeRawExtension("team state", result.TeamState)
+	}
+	if err == nil && result.Vote != nil {
+		rawVote, err = serializeRawExtension("vote", result.Vote)
+	}
 	if err != nil {
 		serializationErr := fmt.Errorf("failed to serialize messages for target %v: %w", target, err)
 		return r.createErrorResponse(target, serializationErr)
 	}
 
 	return arkv1alpha1.Response{
-		Target:  target,
-		Content: messageToText(messages[len(messages)-1]),
-		Raw:     rawJSON,
-		Phase:   statusDone,
+		Target:    target,
+		Content:   messageToText(messages[len(messages)-1]),
+		Raw:       rawJSON,
+		Phase:     statusDone,
+		TeamState: rawTeamState,
+		Vote:      rawVote,
 	}
 }
 
@@ -540,6 +565,15 @@ func serializeMessages(messages []genai.Message) (string, error) {
 	return string(rawBytes), nil
 }
 
+// serializeRawExtension converts a value, such as the final team state, for a JSON field of the response.
+func serializeRawExtension(name string, value any) (*runtime.RawExtension, error) {
+	rawBytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", name, err)
+	}
+	return &runtime.RawExtension{Raw: rawBytes}, nil
+}
+
 func (r *QueryReconciler) setConditionCompleted(query *arkv1alpha1.Query, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&query.Status.Conditions, metav1.Condition{
 		Type:               string(arkv1alpha1.QueryCompleted),
@@ -692,7 +726,7 @@ func (r *QueryReconciler) executeTarget(ctx context.Context, query arkv1alpha1.Q
 		result = &genai.ExecutionResult{Messages: messages}
 	case targetTypeTool:
 		var messages []genai.Message
-		messages, err = r.executeTool(execCtx, query, inputMessages, target.Name, impersonatedClient)
+		messages, err = r.executeTool(execCtx, query, inputMessages, target.Name, impersonatedClient, eventStream)
 		result = &genai.ExecutionResult{Messages: messages}
 	default:
 		panic(fmt.Errorf("unknown query target type:%s", target.Type))
@@ -849,7 +883,7 @@ func (r *QueryReconciler) executeModel(ctx context.Context, query arkv1alpha1.Qu
 	return responseMessages, nil
 }
 
-func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query, inputMessages []genai.Message, toolName string, impersonatedClient client.Client) ([]genai.Message, error) {
+func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query, inputMessages []genai.Message, toolName string, impersonatedClient client.Client, eventStream genai.EventStreamInterface) ([]genai.Message, error) {
 	log := logf.FromContext(ctx)
 
 	query, err := genai.MakeQuery(&crd)
@@ -912,12 +946,19 @@ func (r *QueryReconciler) executeTool(ctx context.Context, crd arkv1alpha1.Query
 		return nil, fmt.Errorf("failed to create tool executor: %w", err)
 	}
 	toolRegistry.RegisterTool(toolDefinition, executor)
+	if err := toolRegistry.ApplyToolPolicies(ctx, impersonatedClient, &toolCRD, toolDefinition.Name, genai.ToolCaller{ServiceAccount: crd.Spec.ServiceAccount}); err != nil {
+		return nil, err
+	}
 
 	// Execute the tool using the same ExecuteTool method agents use
-	result, err := toolRegistry.ExecuteTool(ctx, toolCall)
+	result, err := toolRegistry.ExecuteTool(genai.WithToolProgressStream(ctx, eventStream, toolName, toolCall.ID), toolCall)
 	if err != nil {
 		return nil, fmt.Errorf("tool execution failed: %w", err)
 	}
+	// Without a model to recover from them, errors returned by the tool fail the query
+	if result.Error != "" {
+		return nil, fmt.Errorf("tool execution failed: %s", result.Error)
+	}
 
 	// Create response message with tool result
 	assistantMessage := genai.NewAssistantMessage(result.Content)
@@ -952,16 +993,9 @@ func (r *QueryReconciler) getClientForQuery(query arkv1alpha1.Query) (client.Cli
 		return r.Client, nil
 	}
 
-	// Impersonate the specified service account.
-	// Note: This requires rbac.impersonation.enabled=true in the Helm chart.
-	// Future architecture will move this to per-namespace query executor pods.
-	cfg, err := rest.InClusterConfig()
+	cfg, err := r.getRESTConfigForQuery(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get in-cluster config: %w", err)
-	}
-
-	cfg.Impersonate = rest.ImpersonationConfig{
-		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", query.Namespace, serviceAccount),
+		return nil, err
 	}
 
 	impersonatedClient, err := client.New(cfg, client.Options{
@@ -975,6 +1009,28 @@ func (r *QueryReconciler) getClientForQuery(query arkv1alpha1.Query) (client.Cli
 	return impersonatedClient, nil
 }
 
+// getRESTConfigForQuery returns the configuration of the identity the query runs with. It is nil when the query
+// has no service account and the controller configuration is not set.
+func (r *QueryReconciler) getRESTConfigForQuery(query arkv1alpha1.Query) (*rest.Config, error) {
+	serviceAccount := query.Spec.ServiceAccount
+	if serviceAccount == "" {
+		return r.Config, nil
+	}
+
+	// Impersonate the specified service account.
+	// Note: This requires rbac.impersonation.enabled=true in the Helm chart.
+	// Future architecture will move this to per-namespace query executor pods.
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get in-cluster config: %w", err)
+	}
+
+	cfg.Impersonate = rest.ImpersonationConfig{
+		UserName: fmt.Sprintf("system:serviceaccount:%s:%s", query.Namespace, serviceAccount),
+	}
+	return cfg, nil
+}
+
 func (r *QueryReconciler) cleanupExistingOperation(namespacedName types.NamespacedName) {
 	if existingOp, exists := r.operations.Load(namespacedName); exists {
 		logf.Log.Info("Found existing operation, clearing due to cancel", "query", namespacedName.String())
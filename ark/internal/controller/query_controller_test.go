@@ -4,6 +4,7 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -239,5 +240,44 @@ var _ = Describe("Query Controller Message Serialization", func() {
 			Expect(err).To(HaveOccurred())
 			Expect(err.Error()).To(Equal("unknown message type encountered during serialization"))
 		})
+
+		It("should keep the raw messages an array and store the team state separately", func() {
+			reconciler := &QueryReconciler{}
+			messages := []genai.Message{genai.Message(openai.AssistantMessage("done"))}
+
+			response := reconciler.createSuccessResponse(arkv1alpha1.QueryTarget{Type: "team", Name: "review"}, &genai.ExecutionResult{
+				Messages:  messages,
+				TeamState: map[string]any{"approved": true},
+			})
+
+			var raw []map[string]any
+			Expect(json.Unmarshal([]byte(response.Raw), &raw)).To(Succeed())
+			Expect(raw).To(HaveLen(1))
+			Expect(response.TeamState).NotTo(BeNil())
+			Expect(string(response.TeamState.Raw)).To(Equal(`{"approved":true}`))
+		})
+
+		It("should store the outcome and member answers of a vote", func() {
+			reconciler := &QueryReconciler{}
+			vote := &genai.VoteResult{
+				VoteOutcome: genai.VoteOutcome{
+					Method:    genai.VoteMethodJudge,
+					Winner:    "b",
+					Answer:    "ham",
+					Rationale: "b explained its answer",
+				},
+				Answers: []genai.VoteAnswer{{Member: "a", Answer: "spam"}, {Member: "b", Answer: "ham"}},
+			}
+
+			response := reconciler.createSuccessResponse(arkv1alpha1.QueryTarget{Type: "team", Name: "panel"}, &genai.ExecutionResult{
+				Messages: []genai.Message{genai.Message(openai.AssistantMessage("ham"))},
+				Vote:     vote,
+			})
+
+			Expect(response.Vote).NotTo(BeNil())
+			var stored genai.VoteResult
+			Expect(json.Unmarshal(response.Vote.Raw, &stored)).To(Succeed())
+			Expect(stored).To(Equal(*vote))
+		})
 	})
 })
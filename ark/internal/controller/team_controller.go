@@ -36,6 +36,7 @@ type TeamReconciler struct {
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=teams/finalizers,verbs=update
 // +kubebuilder:rbac:groups=ark.mckinsey.com,resources=agents,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ark.mckinsey.com,resources=models,verbs=get;list;watch
 
 //nolint:dupl
 func (r *TeamReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -106,11 +107,40 @@ func (r *TeamReconciler) checkMembers(ctx context.Context, team *arkv1alpha1.Tea
 		if agentCondition == nil || agentCondition.Status != metav1.ConditionTrue {
 			return false, "MemberNotAvailable", fmt.Sprintf("Agent member %s is not available", member.Name)
 		}
+
+		if member.ModelRef != nil {
+			if ok, msg := r.checkMemberModel(ctx, team, member); !ok {
+				return false, "ModelNotFound", msg
+			}
+		}
 	}
 
 	return true, "Available", "All team members are available"
 }
 
+// checkMemberModel validates the model override of a team member
+func (r *TeamReconciler) checkMemberModel(ctx context.Context, team *arkv1alpha1.Team, member arkv1alpha1.TeamMember) (bool, string) {
+	modelNamespace := team.Namespace
+	if member.ModelRef.Namespace != "" {
+		modelNamespace = member.ModelRef.Namespace
+	}
+
+	var model arkv1alpha1.Model
+	if err := r.Get(ctx, types.NamespacedName{Name: member.ModelRef.Name, Namespace: modelNamespace}, &model); err != nil {
+		if errors.IsNotFound(err) {
+			return false, fmt.Sprintf("Model '%s' for member %s not found in namespace '%s'", member.ModelRef.Name, member.Name, modelNamespace)
+		}
+		return false, fmt.Sprintf("Failed to check model '%s' for member %s: %v", member.ModelRef.Name, member.Name, err)
+	}
+
+	modelCondition := meta.FindStatusCondition(model.Status.Conditions, "ModelAvailable")
+	if modelCondition == nil || modelCondition.Status != metav1.ConditionTrue {
+		return false, fmt.Sprintf("Model '%s' for member %s is not available", member.ModelRef.Name, member.Name)
+	}
+
+	return true, ""
+}
+
 func (r *TeamReconciler) setCondition(team *arkv1alpha1.Team, conditionType string, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&team.Status.Conditions, metav1.Condition{
 		Type:               conditionType,
@@ -137,6 +167,7 @@ func (r *TeamReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&arkv1alpha1.Team{}).
 		Watches(&arkv1alpha1.Agent{}, handler.EnqueueRequestsFromMapFunc(r.findTeamsForAgent)).
+		Watches(&arkv1alpha1.Model{}, handler.EnqueueRequestsFromMapFunc(r.findTeamsForModel)).
 		Named("team").
 		Complete(r)
 }
@@ -166,3 +197,36 @@ func (r *TeamReconciler) findTeamsForAgent(ctx context.Context, obj client.Objec
 
 	return requests
 }
+
+func (r *TeamReconciler) findTeamsForModel(ctx context.Context, obj client.Object) []reconcile.Request {
+	model := obj.(*arkv1alpha1.Model)
+
+	var teams arkv1alpha1.TeamList
+	if err := r.List(ctx, &teams); err != nil {
+		return []reconcile.Request{}
+	}
+
+	var requests []reconcile.Request
+	for _, team := range teams.Items {
+		for _, member := range team.Spec.Members {
+			if member.ModelRef == nil || member.ModelRef.Name != model.Name {
+				continue
+			}
+			modelNamespace := team.Namespace
+			if member.ModelRef.Namespace != "" {
+				modelNamespace = member.ModelRef.Namespace
+			}
+			if modelNamespace == model.Namespace {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Name:      team.Name,
+						Namespace: team.Namespace,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	return requests
+}
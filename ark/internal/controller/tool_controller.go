@@ -28,7 +28,8 @@ func (r *ToolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
-	if tool.Status.State == arkv1alpha1.ToolStateReady {
+	// States set by other controllers, such as Unavailable for tools of unreachable MCP servers, are kept
+	if tool.Status.State != "" {
 		return ctrl.Result{}, nil
 	}
 
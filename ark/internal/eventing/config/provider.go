@@ -23,6 +23,7 @@ type Provider struct {
 	teamRecorder            eventing.TeamRecorder
 	executionEngineRecorder eventing.ExecutionEngineRecorder
 	mcpServerRecorder       eventing.MCPServerRecorder
+	openAPIServerRecorder   eventing.OpenAPIServerRecorder
 	queryRecorder           eventing.QueryRecorder
 	toolRecorder            eventing.ToolRecorder
 	memoryRecorder          eventing.MemoryRecorder
@@ -62,6 +63,7 @@ func NewProvider(mgr ctrl.Manager, k8sClient client.Client) *Provider {
 		teamRecorder:            recorders.NewTeamRecorder(k8sEmitter, operationEmitter),
 		executionEngineRecorder: recorders.NewExecutionEngineRecorder(k8sEmitter, operationEmitter),
 		mcpServerRecorder:       recorders.NewMCPServerRecorder(k8sEmitter),
+		openAPIServerRecorder:   recorders.NewOpenAPIServerRecorder(k8sEmitter),
 		queryRecorder:           recorders.NewQueryRecorder(k8sEmitter, operationEmitter),
 		toolRecorder:            recorders.NewToolRecorder(k8sEmitter, operationEmitter),
 		memoryRecorder:          recorders.NewMemoryRecorder(k8sEmitter, operationEmitter),
@@ -92,6 +94,10 @@ func (p *Provider) MCPServerRecorder() eventing.MCPServerRecorder {
 	return p.mcpServerRecorder
 }
 
+func (p *Provider) OpenAPIServerRecorder() eventing.OpenAPIServerRecorder {
+	return p.openAPIServerRecorder
+}
+
 func (p *Provider) QueryRecorder() eventing.QueryRecorder {
 	return p.queryRecorder
 }
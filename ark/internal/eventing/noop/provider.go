@@ -11,6 +11,9 @@ type noopProvider struct {
 	agentRecorder eventing.AgentRecorder
 	teamRecorder  eventing.TeamRecorder
 	toolRecorder  eventing.ToolRecorder
+
+	mcpServerRecorder     eventing.MCPServerRecorder
+	openAPIServerRecorder eventing.OpenAPIServerRecorder
 }
 
 func NewProvider() eventing.Provider {
@@ -21,6 +24,9 @@ func NewProvider() eventing.Provider {
 		agentRecorder: recorder.NewAgentRecorder(emitter, emitter),
 		teamRecorder:  recorder.NewTeamRecorder(emitter, emitter),
 		toolRecorder:  recorder.NewToolRecorder(emitter, emitter),
+
+		mcpServerRecorder:     recorder.NewMCPServerRecorder(emitter),
+		openAPIServerRecorder: recorder.NewOpenAPIServerRecorder(emitter),
 	}
 }
 
@@ -45,7 +51,11 @@ func (p *noopProvider) ExecutionEngineRecorder() eventing.ExecutionEngineRecorde
 }
 
 func (p *noopProvider) MCPServerRecorder() eventing.MCPServerRecorder {
-	return nil
+	return p.mcpServerRecorder
+}
+
+func (p *noopProvider) OpenAPIServerRecorder() eventing.OpenAPIServerRecorder {
+	return p.openAPIServerRecorder
 }
 
 func (p *noopProvider) QueryRecorder() eventing.QueryRecorder {
@@ -33,3 +33,15 @@ func (t *mcpServerRecorder) ToolListingFailed(ctx context.Context, obj runtime.O
 func (t *mcpServerRecorder) ToolCreationFailed(ctx context.Context, obj runtime.Object, reason string) {
 	t.emitter.EmitWarning(ctx, obj, "ToolCreationFailed", reason)
 }
+
+func (t *mcpServerRecorder) StaleToolsDeleted(ctx context.Context, obj runtime.Object, reason string) {
+	t.emitter.EmitWarning(ctx, obj, "StaleToolsDeleted", reason)
+}
+
+func (t *mcpServerRecorder) ToolSchemaDrifted(ctx context.Context, obj runtime.Object, reason string) {
+	t.emitter.EmitWarning(ctx, obj, "ToolSchemaDrifted", reason)
+}
+
+func (t *mcpServerRecorder) ProcessExited(ctx context.Context, obj runtime.Object, reason string) {
+	t.emitter.EmitWarning(ctx, obj, "ProcessExited", reason)
+}
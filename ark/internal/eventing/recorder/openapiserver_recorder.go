@@ -0,0 +1,31 @@
+package recorder
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"mckinsey.com/ark/internal/eventing"
+)
+
+type openAPIServerRecorder struct {
+	emitter eventing.EventEmitter
+}
+
+func NewOpenAPIServerRecorder(emitter eventing.EventEmitter) eventing.OpenAPIServerRecorder {
+	return &openAPIServerRecorder{
+		emitter: emitter,
+	}
+}
+
+func (t *openAPIServerRecorder) DocumentLoadFailed(ctx context.Context, obj runtime.Object, reason string) {
+	t.emitter.EmitWarning(ctx, obj, "DocumentLoadFailed", reason)
+}
+
+func (t *openAPIServerRecorder) DocumentParseFailed(ctx context.Context, obj runtime.Object, reason string) {
+	t.emitter.EmitWarning(ctx, obj, "DocumentParseFailed", reason)
+}
+
+func (t *openAPIServerRecorder) ToolCreationFailed(ctx context.Context, obj runtime.Object, reason string) {
+	t.emitter.EmitWarning(ctx, obj, "ToolCreationFailed", reason)
+}
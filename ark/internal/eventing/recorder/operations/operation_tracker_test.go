@@ -179,6 +179,36 @@ func TestOperationTracker_Complete_NoQueryContext(t *testing.T) {
 	assert.Equal(t, 0, emitter.EventCount())
 }
 
+func TestOperationTracker_Progress(t *testing.T) {
+	emitter := mock.NewMockEventEmitter()
+	ot := NewOperationTracker(emitter)
+
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-query",
+			Namespace: "test-ns",
+			UID:       types.UID("test-uid"),
+		},
+	}
+
+	ctx := ot.InitializeQueryContext(context.Background(), query)
+	ctx = ot.Start(ctx, "TestOperation", "Starting test operation", map[string]string{"toolName": "indexer"})
+
+	ot.Progress(ctx, "TestOperation", "indexing 40/100", map[string]string{"progress": "40"})
+
+	events := emitter.GetEvents()
+	assert.Equal(t, 2, len(events))
+	event := events[1]
+	assert.Equal(t, "TestOperationProgress", event.Reason)
+	assert.Contains(t, event.Message, "indexing 40/100")
+
+	data, ok := (*event.Data).(map[string]string)
+	assert.True(t, ok)
+	assert.Equal(t, "indexer", data["toolName"])
+	assert.Equal(t, "40", data["progress"])
+	assert.Contains(t, data, "durationMs")
+}
+
 func TestOperationTracker_Fail(t *testing.T) {
 	emitter := mock.NewMockEventEmitter()
 	ot := NewOperationTracker(emitter)
@@ -56,6 +56,15 @@ type MCPServerRecorder interface {
 	ClientCreationFailed(ctx context.Context, obj runtime.Object, reason string)
 	ToolListingFailed(ctx context.Context, obj runtime.Object, reason string)
 	ToolCreationFailed(ctx context.Context, obj runtime.Object, reason string)
+	StaleToolsDeleted(ctx context.Context, obj runtime.Object, reason string)
+	ToolSchemaDrifted(ctx context.Context, obj runtime.Object, reason string)
+	ProcessExited(ctx context.Context, obj runtime.Object, reason string)
+}
+
+type OpenAPIServerRecorder interface {
+	DocumentLoadFailed(ctx context.Context, obj runtime.Object, reason string)
+	DocumentParseFailed(ctx context.Context, obj runtime.Object, reason string)
+	ToolCreationFailed(ctx context.Context, obj runtime.Object, reason string)
 }
 
 type TeamRecorder interface {
@@ -72,6 +81,8 @@ type QueryRecorder interface {
 
 type ToolRecorder interface {
 	OperationTracker
+	// Progress records a progress update reported while the operation runs
+	Progress(ctx context.Context, operation, message string, data map[string]string)
 }
 
 type MemoryRecorder interface {
@@ -85,6 +96,7 @@ type Provider interface {
 	TeamRecorder() TeamRecorder
 	ExecutionEngineRecorder() ExecutionEngineRecorder
 	MCPServerRecorder() MCPServerRecorder
+	OpenAPIServerRecorder() OpenAPIServerRecorder
 	QueryRecorder() QueryRecorder
 	ToolRecorder() ToolRecorder
 	MemoryRecorder() MemoryRecorder
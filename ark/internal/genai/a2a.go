@@ -10,6 +10,7 @@ import (
 	"strings"
 	"time"
 
+	"golang.org/x/oauth2"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -36,20 +37,25 @@ type A2AResponse struct {
 }
 
 // DiscoverA2AAgents discovers agents from an A2A server using simplified HTTP approach
-func DiscoverA2AAgents(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace string) (*A2AAgentCard, error) {
-	return DiscoverA2AAgentsWithRecorder(ctx, k8sClient, address, headers, namespace, nil, nil)
+func DiscoverA2AAgents(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, auth *arkv1alpha1.Auth, namespace string) (*A2AAgentCard, error) {
+	return DiscoverA2AAgentsWithRecorder(ctx, k8sClient, address, headers, auth, namespace, nil, nil)
 }
 
 // DiscoverA2AAgentsWithRecorder discovers agents with optional K8s event recording
 // Tries both A2A protocol versions: 0.3.x (agent-card.json) and 0.2.x (agent.json)
 // Note: protocol.AgentCardPath is version 0.2.x (agent.json) at time of writing
-func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace string, a2aRecorder eventing.A2aRecorder, obj client.Object) (*A2AAgentCard, error) {
+func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, auth *arkv1alpha1.Auth, namespace string, a2aRecorder eventing.A2aRecorder, obj client.Object) (*A2AAgentCard, error) {
 	baseURL := strings.TrimSuffix(address, "/")
 
 	if err := validateA2AClient(address, headers, ctx, k8sClient, namespace); err != nil {
 		return nil, err
 	}
 
+	tokenSource, err := ResolveAuthTokenSource(ctx, k8sClient, auth, namespace)
+	if err != nil {
+		return nil, err
+	}
+
 	endpoints := []struct {
 		url     string
 		version string
@@ -60,7 +66,7 @@ func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client,
 
 	var lastErr error
 	for _, endpoint := range endpoints {
-		req, err := createA2ARequest(ctx, endpoint.url, headers, k8sClient, namespace)
+		req, err := createA2ARequest(ctx, endpoint.url, headers, tokenSource, k8sClient, namespace)
 		if err != nil {
 			lastErr = err
 			continue
@@ -79,11 +85,11 @@ func DiscoverA2AAgentsWithRecorder(ctx context.Context, k8sClient client.Client,
 }
 
 // ExecuteA2AAgent executes a task on an A2A agent with optional K8s event recording and query context
-func ExecuteA2AAgent(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, namespace, input, agentName, queryName, contextID string, a2aRecorder eventing.A2aRecorder, obj client.Object) (*A2AResponse, error) {
+func ExecuteA2AAgent(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, auth *arkv1alpha1.Auth, namespace, input, agentName, queryName, contextID string, a2aRecorder eventing.A2aRecorder, obj client.Object) (*A2AResponse, error) {
 	rpcURL := strings.TrimSuffix(address, "/")
 
 	// Create and configure A2A client
-	a2aClient, err := CreateA2AClient(ctx, k8sClient, rpcURL, headers, namespace, agentName, a2aRecorder)
+	a2aClient, err := CreateA2AClient(ctx, k8sClient, rpcURL, headers, auth, namespace, agentName, a2aRecorder)
 	if err != nil {
 		return nil, err
 	}
@@ -92,16 +98,37 @@ func ExecuteA2AAgent(ctx context.Context, k8sClient client.Client, address strin
 	return executeA2AAgentMessage(ctx, k8sClient, a2aClient, input, agentName, namespace, queryName, contextID, obj, a2aRecorder)
 }
 
+// StreamA2AAgent executes a task on an A2A agent that supports streaming. Updates of the task are relayed to the
+// event stream, if any, as they arrive.
+func StreamA2AAgent(ctx context.Context, k8sClient client.Client, address string, headers []arkv1prealpha1.Header, auth *arkv1alpha1.Auth, namespace, input, agentName, queryName, contextID string, a2aRecorder eventing.A2aRecorder, obj client.Object, eventStream EventStreamInterface) (*A2AResponse, error) {
+	rpcURL := strings.TrimSuffix(address, "/")
+
+	a2aClient, err := CreateA2AClient(ctx, k8sClient, rpcURL, headers, auth, namespace, agentName, a2aRecorder)
+	if err != nil {
+		return nil, err
+	}
+
+	return streamA2AAgentMessage(ctx, k8sClient, a2aClient, input, agentName, namespace, queryName, contextID, obj, a2aRecorder, eventStream)
+}
+
 // CreateA2AClient creates and configures A2A client with header resolution and injection
-func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string, headers []arkv1prealpha1.Header, namespace, agentName string, a2aRecorder eventing.A2aRecorder) (*a2aclient.A2AClient, error) {
+func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string, headers []arkv1prealpha1.Header, auth *arkv1alpha1.Auth, namespace, agentName string, a2aRecorder eventing.A2aRecorder) (*a2aclient.A2AClient, error) {
 	// Use context deadline if available, otherwise default
 	timeout := 5 * time.Minute
 	if deadline, ok := ctx.Deadline(); ok {
 		timeout = time.Until(deadline)
 	}
 
+	tokenSource, err := ResolveAuthTokenSource(ctx, k8sClient, auth, namespace)
+	if err != nil {
+		if a2aRecorder != nil {
+			a2aRecorder.A2AHeaderResolutionFailed(ctx, fmt.Sprintf("failed to resolve A2A auth: %v", err))
+		}
+		return nil, err
+	}
+
 	var clientOptions []a2aclient.Option
-	if len(headers) > 0 {
+	if len(headers) > 0 || tokenSource != nil {
 		resolvedHeaders, err := resolveA2AHeaders(ctx, k8sClient, headers, namespace)
 		if err != nil {
 			if a2aRecorder != nil {
@@ -113,7 +140,8 @@ func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string
 		httpClient := &http.Client{Timeout: timeout}
 		clientOptions = append(clientOptions, a2aclient.WithHTTPClient(httpClient))
 		clientOptions = append(clientOptions, a2aclient.WithHTTPReqHandler(&customA2ARequestHandler{
-			headers: resolvedHeaders,
+			headers:     resolvedHeaders,
+			tokenSource: tokenSource,
 		}))
 	} else {
 		// No headers, but still need to set timeout via client options
@@ -129,21 +157,10 @@ func CreateA2AClient(ctx context.Context, k8sClient client.Client, rpcURL string
 
 // executeA2AAgentMessage sends message to A2A agent and processes response
 func executeA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aClient *a2aclient.A2AClient, input, agentName, namespace, queryName, contextID string, obj client.Object, a2aRecorder eventing.A2aRecorder) (*A2AResponse, error) {
-	var message protocol.Message
-	if contextID != "" {
-		message = protocol.NewMessageWithContext(protocol.MessageRoleUser, []protocol.Part{
-			protocol.NewTextPart(input),
-		}, nil, &contextID)
-	} else {
-		message = protocol.NewMessage(protocol.MessageRoleUser, []protocol.Part{
-			protocol.NewTextPart(input),
-		})
-	}
-
 	blocking := true
 	params := protocol.SendMessageParams{
 		RPCID:   protocol.GenerateRPCID(),
-		Message: message,
+		Message: newA2AUserMessage(input, contextID),
 		// Blocking: true causes the A2A server to wait for task completion before responding.
 		// When false, the server returns immediately with a Task in "submitted" state, requiring
 		// the client to poll for updates. Ark currently only supports blocking mode, expecting
@@ -172,9 +189,19 @@ func executeA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aCli
 	return response, nil
 }
 
+// newA2AUserMessage creates the message sent to A2A agents, continuing the context if any
+func newA2AUserMessage(input, contextID string) protocol.Message {
+	parts := []protocol.Part{protocol.NewTextPart(input)}
+	if contextID != "" {
+		return protocol.NewMessageWithContext(protocol.MessageRoleUser, parts, nil, &contextID)
+	}
+	return protocol.NewMessage(protocol.MessageRoleUser, parts)
+}
+
 // customA2ARequestHandler handles adding custom headers and OTEL tracing to A2A requests
 type customA2ARequestHandler struct {
-	headers map[string]string
+	headers     map[string]string
+	tokenSource oauth2.TokenSource
 }
 
 // Handle implements the HTTPReqHandler interface
@@ -183,6 +210,9 @@ func (h *customA2ARequestHandler) Handle(ctx context.Context, httpClient *http.C
 	for name, value := range h.headers {
 		req.Header.Set(name, value)
 	}
+	if err := setBearerToken(req, h.tokenSource); err != nil {
+		return nil, err
+	}
 
 	// Inject OTEL trace context and session headers
 	headerMap := make(map[string]string)
@@ -310,7 +340,7 @@ func validateA2AClient(address string, headers []arkv1prealpha1.Header, ctx cont
 }
 
 // createA2ARequest creates and configures HTTP request for A2A discovery
-func createA2ARequest(ctx context.Context, agentCardURL string, headers []arkv1prealpha1.Header, k8sClient client.Client, namespace string) (*http.Request, error) {
+func createA2ARequest(ctx context.Context, agentCardURL string, headers []arkv1prealpha1.Header, tokenSource oauth2.TokenSource, k8sClient client.Client, namespace string) (*http.Request, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, agentCardURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
@@ -326,6 +356,9 @@ func createA2ARequest(ctx context.Context, agentCardURL string, headers []arkv1p
 			req.Header.Set(name, value)
 		}
 	}
+	if err := setBearerToken(req, tokenSource); err != nil {
+		return nil, err
+	}
 
 	// Inject OTEL headers
 	headerMap := make(map[string]string)
@@ -383,8 +416,24 @@ func resolveA2AHeaders(ctx context.Context, k8sClient client.Client, headers []a
 func handleA2ATaskResponse(ctx context.Context, k8sClient client.Client, task *protocol.Task, agentName, namespace, queryName string, obj client.Object) error {
 	log := logf.FromContext(ctx)
 
+	a2aTask, err := newA2ATask(task, agentName, namespace, queryName, obj)
+	if err != nil {
+		return err
+	}
+
+	// Create the resource
+	if err := k8sClient.Create(ctx, a2aTask); err != nil {
+		log.Error(err, "failed to create A2ATask resource", "taskId", task.ID)
+		return fmt.Errorf("failed to create A2ATask resource: %w", err)
+	}
+
+	return nil
+}
+
+// newA2ATask builds the A2ATask resource tracking a task of an A2A agent
+func newA2ATask(task *protocol.Task, agentName, namespace, queryName string, obj client.Object) (*arkv1alpha1.A2ATask, error) {
 	if queryName == "" {
-		return fmt.Errorf("unable to determine A2A Task originating query")
+		return nil, fmt.Errorf("unable to determine A2A Task originating query")
 	}
 
 	var a2aServerName string
@@ -425,11 +474,5 @@ func handleA2ATaskResponse(ctx context.Context, k8sClient client.Client, task *p
 	now := metav1.NewTime(time.Now())
 	a2aTask.Status.StartTime = &now
 
-	// Create the resource
-	if err := k8sClient.Create(ctx, a2aTask); err != nil {
-		log.Error(err, "failed to create A2ATask resource", "taskId", task.ID)
-		return fmt.Errorf("failed to create A2ATask resource: %w", err)
-	}
-
-	return nil
+	return a2aTask, nil
 }
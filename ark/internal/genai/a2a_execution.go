@@ -79,9 +79,15 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 		content = userInput.OfUser.Content.OfString.Value
 	}
 
-	// Execute A2A agent
+	// Execute A2A agent, streaming the updates of its task if its agent card advertises streaming
 	queryName := getQueryName(ctx)
-	a2aResponse, err := ExecuteA2AAgent(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, namespace, content, agentName, queryName, contextID, e.eventingRecorder, &a2aServer)
+	var a2aResponse *A2AResponse
+	var err error
+	if agentAnnotations[arkann.A2AServerStreaming] == "true" {
+		a2aResponse, err = StreamA2AAgent(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, a2aServer.Spec.Auth, namespace, content, agentName, queryName, contextID, e.eventingRecorder, &a2aServer, eventStream)
+	} else {
+		a2aResponse, err = ExecuteA2AAgent(ctx, e.client, a2aAddress, a2aServer.Spec.Headers, a2aServer.Spec.Auth, namespace, content, agentName, queryName, contextID, e.eventingRecorder, &a2aServer)
+	}
 	if err != nil {
 		modelID := fmt.Sprintf("agent/%s", agentName)
 		StreamError(ctx, eventStream, err, "a2a_execution_failed", modelID)
@@ -92,8 +98,8 @@ func (e *A2AExecutionEngine) Execute(ctx context.Context, agentName, namespace s
 	// Convert response to genai.Message format
 	responseMessage := NewAssistantMessage(a2aResponse.Content)
 
-	// The A2A execution engine does not yet support streaming responses - if streaming
-	// was requested then the final response must be sent as a single chunk, as per the spec.
+	// Updates of streamed tasks are relayed as metadata while they run - the response itself
+	// is sent as a single chunk once the task completes, as per the spec.
 	if eventStream != nil {
 		// Use query ID as completion ID (all chunks for a query share the same ID)
 		completionID := getQueryID(ctx)
@@ -0,0 +1,371 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/openai/openai-go"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	a2aclient "trpc.group/trpc-go/trpc-a2a-go/client"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/eventing"
+)
+
+// a2aTaskRecordInterval limits how often the A2ATask of a streamed task is updated while its state does not change,
+// as agents may stream an artifact in many small chunks.
+const a2aTaskRecordInterval = 5 * time.Second
+
+// A2ATaskUpdateMetadata describes an update of an A2A task in the metadata of streaming chunks.
+type A2ATaskUpdateMetadata struct {
+	TaskID    string `json:"taskId"`
+	ContextID string `json:"contextId,omitempty"`
+	// State and Message are set by status updates
+	State   string `json:"state,omitempty"`
+	Message string `json:"message,omitempty"`
+	// Final is set on the last status update of the task
+	Final bool `json:"final,omitempty"`
+	// Artifact is set by artifact updates
+	Artifact *A2AArtifactUpdateMetadata `json:"artifact,omitempty"`
+}
+
+// A2AArtifactUpdateMetadata describes an artifact, or a chunk of it, produced by an A2A task.
+type A2AArtifactUpdateMetadata struct {
+	ArtifactID string `json:"artifactId"`
+	Name       string `json:"name,omitempty"`
+	Text       string `json:"text,omitempty"`
+	// Append is set when the text continues the artifact rather than replacing it
+	Append    bool `json:"append,omitempty"`
+	LastChunk bool `json:"lastChunk,omitempty"`
+}
+
+// streamA2AAgentMessage sends the message to the A2A agent with message/stream, and returns the response once the
+// task it started completes.
+func streamA2AAgentMessage(ctx context.Context, k8sClient client.Client, a2aClient *a2aclient.A2AClient, input, agentName, namespace, queryName, contextID string, obj client.Object, a2aRecorder eventing.A2aRecorder, eventStream EventStreamInterface) (*A2AResponse, error) {
+	// Stop reading the stream when returning early
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := a2aClient.StreamMessage(streamCtx, protocol.SendMessageParams{
+		RPCID:   protocol.GenerateRPCID(),
+		Message: newA2AUserMessage(input, contextID),
+	})
+	if err != nil {
+		if a2aRecorder != nil {
+			a2aRecorder.A2AMessageFailed(ctx, fmt.Sprintf("A2A StreamMessage failed: %v", err))
+		}
+		return nil, fmt.Errorf("A2A server call failed: %w", err)
+	}
+
+	stream := &a2aTaskStream{
+		k8sClient:   k8sClient,
+		agentName:   agentName,
+		namespace:   namespace,
+		queryName:   queryName,
+		obj:         obj,
+		eventStream: eventStream,
+	}
+	for event := range events {
+		if err := stream.apply(ctx, event.Result); err != nil {
+			return nil, err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil, fmt.Errorf("A2A stream interrupted: %w", ctx.Err())
+	}
+	stream.flush(ctx)
+
+	response, err := stream.response()
+	if err != nil {
+		if a2aRecorder != nil {
+			a2aRecorder.A2AResponseParseError(ctx, fmt.Sprintf("Failed to parse A2A response: %v", err))
+		}
+		return nil, err
+	}
+	return response, nil
+}
+
+// a2aTaskStream accumulates the events of a message/stream call into the task they update. Updates are relayed to
+// the event stream as they arrive. The task is recorded in its A2ATask when its state changes, and otherwise at most
+// every a2aTaskRecordInterval.
+type a2aTaskStream struct {
+	k8sClient   client.Client
+	agentName   string
+	namespace   string
+	queryName   string
+	obj         client.Object
+	eventStream EventStreamInterface
+
+	task    *protocol.Task
+	message *protocol.Message
+	a2aTask *arkv1alpha1.A2ATask
+
+	// recordedState and recordedAt are the state of the task and the time of the last update of the A2ATask
+	recordedState protocol.TaskState
+	recordedAt    time.Time
+	// pending is set when the task has updates that are not recorded yet
+	pending bool
+}
+
+// apply applies an event of the stream. Only failing to create the A2ATask of the task is an error.
+func (s *a2aTaskStream) apply(ctx context.Context, result protocol.StreamingMessageResult) error {
+	switch r := result.(type) {
+	case *protocol.Message:
+		// Agents that answer with a message do not start a task
+		s.message = r
+		return nil
+	case *protocol.Task:
+		s.task = r
+		s.streamUpdate(ctx, &A2ATaskUpdateMetadata{
+			TaskID:    r.ID,
+			ContextID: r.ContextID,
+			State:     string(r.Status.State),
+			Message:   statusMessageText(r.Status),
+		})
+	case *protocol.TaskStatusUpdateEvent:
+		task := s.taskFor(r.TaskID, r.ContextID)
+		// Status messages report the progress of the agent, so previous ones are kept in the history
+		if task.Status.Message != nil {
+			task.History = appendA2AMessage(task.History, *task.Status.Message)
+		}
+		task.Status = r.Status
+		s.streamUpdate(ctx, &A2ATaskUpdateMetadata{
+			TaskID:    r.TaskID,
+			ContextID: r.ContextID,
+			State:     string(r.Status.State),
+			Message:   statusMessageText(r.Status),
+			Final:     r.Final,
+		})
+	case *protocol.TaskArtifactUpdateEvent:
+		task := s.taskFor(r.TaskID, r.ContextID)
+		appendChunk := r.Append != nil && *r.Append
+		task.Artifacts = mergeA2AArtifact(task.Artifacts, r.Artifact, appendChunk)
+		update := &A2AArtifactUpdateMetadata{
+			ArtifactID: r.Artifact.ArtifactID,
+			Text:       extractTextFromParts(r.Artifact.Parts),
+			Append:     appendChunk,
+			LastChunk:  r.LastChunk != nil && *r.LastChunk,
+		}
+		if r.Artifact.Name != nil {
+			update.Name = *r.Artifact.Name
+		}
+		s.streamUpdate(ctx, &A2ATaskUpdateMetadata{TaskID: r.TaskID, ContextID: r.ContextID, Artifact: update})
+	default:
+		logf.FromContext(ctx).Info("ignoring unexpected A2A stream event", "type", fmt.Sprintf("%T", result), "agent", s.agentName)
+		return nil
+	}
+	return s.recordTask(ctx)
+}
+
+// taskFor returns the task updated by an event, starting it if the stream did not announce it
+func (s *a2aTaskStream) taskFor(taskID, contextID string) *protocol.Task {
+	if s.task == nil || s.task.ID != taskID {
+		s.task = &protocol.Task{ID: taskID, ContextID: contextID, Kind: protocol.KindTask}
+	}
+	return s.task
+}
+
+// flush records the updates of the task that were held back when the stream ends.
+func (s *a2aTaskStream) flush(ctx context.Context) {
+	if s.pending && s.a2aTask != nil {
+		s.updateTaskStatus(ctx)
+	}
+}
+
+// recordTask creates the A2ATask of the task on its first event, and updates its status on the following ones that
+// change the state of the task or come a2aTaskRecordInterval after the last update.
+func (s *a2aTaskStream) recordTask(ctx context.Context) error {
+	log := logf.FromContext(ctx)
+
+	if s.a2aTask == nil {
+		a2aTask, err := newA2ATask(s.task, s.agentName, s.namespace, s.queryName, s.obj)
+		if err != nil {
+			return err
+		}
+		status := a2aTask.Status
+		if err := s.k8sClient.Create(ctx, a2aTask); err != nil {
+			log.Error(err, "failed to create A2ATask resource", "taskId", s.task.ID)
+			return fmt.Errorf("failed to create A2ATask resource: %w", err)
+		}
+		// The status is not written on creation
+		a2aTask.Status.StartTime = status.StartTime
+		s.a2aTask = a2aTask
+	}
+
+	if s.task.Status.State == s.recordedState && !s.recordedAt.IsZero() && time.Since(s.recordedAt) < a2aTaskRecordInterval {
+		s.pending = true
+		return nil
+	}
+	s.updateTaskStatus(ctx)
+	return nil
+}
+
+// updateTaskStatus writes the task to the status of its A2ATask.
+func (s *a2aTaskStream) updateTaskStatus(ctx context.Context) {
+	// The A2ATask controller also polls the task until the stream completes it
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		oldPhase := s.a2aTask.Status.Phase
+		startTime := s.a2aTask.Status.StartTime
+		PopulateA2ATaskStatusFromProtocol(&s.a2aTask.Status, s.task)
+		setTaskTimestamps(&s.a2aTask.Status, oldPhase, s.task)
+		if s.a2aTask.Status.StartTime == nil {
+			s.a2aTask.Status.StartTime = startTime
+		}
+
+		err := s.k8sClient.Status().Update(ctx, s.a2aTask)
+		if apierrors.IsConflict(err) {
+			if getErr := s.k8sClient.Get(ctx, client.ObjectKeyFromObject(s.a2aTask), s.a2aTask); getErr != nil {
+				return getErr
+			}
+		}
+		return err
+	})
+	if err != nil {
+		// The A2ATask catches up with the task when it is next polled
+		logf.FromContext(ctx).Error(err, "failed to update A2ATask status", "taskId", s.task.ID)
+	}
+	s.recordedState = s.task.Status.State
+	s.recordedAt = time.Now()
+	s.pending = false
+}
+
+// streamUpdate relays an update of the task as a chunk without choices, so that OpenAI clients ignore it, with the
+// update in its ARK metadata.
+func (s *a2aTaskStream) streamUpdate(ctx context.Context, update *A2ATaskUpdateMetadata) {
+	if s.eventStream == nil {
+		return
+	}
+	modelID := fmt.Sprintf("agent/%s", s.agentName)
+	chunk := &openai.ChatCompletionChunk{
+		ID:      getQueryID(ctx),
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   modelID,
+		Choices: []openai.ChatCompletionChunkChoice{},
+	}
+	metadata := buildMetadata(ctx, modelID)
+	metadata.A2ATaskUpdate = update
+	if err := s.eventStream.StreamChunk(ctx, ChunkWithMetadata{ChatCompletionChunk: chunk, Ark: metadata}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send A2A task update to event stream", "taskId", update.TaskID)
+	}
+}
+
+// response returns the response of the agent once the stream has ended
+func (s *a2aTaskStream) response() (*A2AResponse, error) {
+	if s.message != nil {
+		response := &A2AResponse{Content: extractTextFromParts(s.message.Parts)}
+		if s.message.ContextID != nil {
+			response.ContextID = *s.message.ContextID
+		}
+		return response, nil
+	}
+	if s.task == nil {
+		return nil, fmt.Errorf("A2A stream ended without a response")
+	}
+	if !IsTerminalPhase(ConvertA2AStateToPhase(string(s.task.Status.State))) {
+		return nil, fmt.Errorf("A2A stream ended with the task in state '%s'", s.task.Status.State)
+	}
+
+	task := *s.task
+	if task.Status.Message != nil {
+		task.History = appendA2AMessage(task.History, *task.Status.Message)
+	}
+	text, err := extractTextFromTask(&task)
+	if err != nil {
+		return nil, err
+	}
+	// Agents that stream their results as artifacts may not send messages
+	if text == "" {
+		text = extractTextFromArtifacts(task.Artifacts)
+	}
+
+	return &A2AResponse{
+		Content:   text,
+		ContextID: task.ContextID,
+		TaskID:    task.ID,
+	}, nil
+}
+
+// statusMessageText returns the text of the message of a task status, if any
+func statusMessageText(status protocol.TaskStatus) string {
+	if status.Message == nil {
+		return ""
+	}
+	return extractTextFromParts(status.Message.Parts)
+}
+
+// appendA2AMessage appends a message to the history, unless the history already has it
+func appendA2AMessage(history []protocol.Message, message protocol.Message) []protocol.Message {
+	if message.MessageID != "" {
+		for _, existing := range history {
+			if existing.MessageID == message.MessageID {
+				return history
+			}
+		}
+	}
+	return append(history, message)
+}
+
+// mergeA2AArtifact merges an artifact update into the artifacts of a task. Chunks are appended to the parts of the
+// artifact, other updates replace it.
+func mergeA2AArtifact(artifacts []protocol.Artifact, artifact protocol.Artifact, appendChunk bool) []protocol.Artifact {
+	for i := range artifacts {
+		if artifacts[i].ArtifactID != artifact.ArtifactID {
+			continue
+		}
+		if appendChunk {
+			artifacts[i].Parts = appendA2AParts(artifacts[i].Parts, artifact.Parts)
+		} else {
+			artifacts[i] = artifact
+		}
+		return artifacts
+	}
+	return append(artifacts, artifact)
+}
+
+// appendA2AParts appends the parts of a chunk. Text that follows text is merged into one part, so that streamed text
+// is kept as a single part rather than one part per chunk.
+func appendA2AParts(parts, chunk []protocol.Part) []protocol.Part {
+	for _, part := range chunk {
+		text, isText := a2aPartText(part)
+		if isText && len(parts) > 0 {
+			if previous, ok := a2aPartText(parts[len(parts)-1]); ok {
+				// Parts decoded from the stream are pointers
+				merged := protocol.NewTextPart(previous + text)
+				parts[len(parts)-1] = &merged
+				continue
+			}
+		}
+		parts = append(parts, part)
+	}
+	return parts
+}
+
+func a2aPartText(part protocol.Part) (string, bool) {
+	switch p := part.(type) {
+	case protocol.TextPart:
+		return p.Text, true
+	case *protocol.TextPart:
+		return p.Text, true
+	}
+	return "", false
+}
+
+// extractTextFromArtifacts extracts the text of artifacts, one artifact per line
+func extractTextFromArtifacts(artifacts []protocol.Artifact) string {
+	texts := make([]string, 0, len(artifacts))
+	for _, artifact := range artifacts {
+		if text := extractTextFromParts(artifact.Parts); text != "" {
+			texts = append(texts, text)
+		}
+	}
+	return strings.Join(texts, "\n")
+}
@@ -0,0 +1,231 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"trpc.group/trpc-go/trpc-a2a-go/protocol"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// newStreamingA2AServer serves message/stream requests with the events, as server-sent events.
+func newStreamingA2AServer(t *testing.T, events ...protocol.StreamingMessageResult) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		var request struct {
+			ID     string `json:"id"`
+			Method string `json:"method"`
+		}
+		if err := json.Unmarshal(body, &request); err != nil || request.Method != protocol.MethodMessageStream {
+			http.Error(w, fmt.Sprintf("unexpected request %s", body), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		for _, event := range events {
+			data, err := json.Marshal(map[string]any{"jsonrpc": "2.0", "id": request.ID, "result": event})
+			if err != nil {
+				return
+			}
+			_, _ = fmt.Fprintf(w, "data: %s\n\n", data)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func agentMessage(id, text string) *protocol.Message {
+	message := protocol.NewMessage(protocol.MessageRoleAgent, []protocol.Part{protocol.NewTextPart(text)})
+	message.MessageID = id
+	return &message
+}
+
+func statusUpdate(state protocol.TaskState, message *protocol.Message, final bool) *protocol.TaskStatusUpdateEvent {
+	return &protocol.TaskStatusUpdateEvent{
+		Kind:      protocol.KindTaskStatusUpdate,
+		TaskID:    "task-1",
+		ContextID: "context-1",
+		Status:    protocol.TaskStatus{State: state, Message: message},
+		Final:     final,
+	}
+}
+
+func artifactUpdate(text string, appendChunk bool) *protocol.TaskArtifactUpdateEvent {
+	name := "itinerary"
+	return &protocol.TaskArtifactUpdateEvent{
+		Kind:      protocol.KindTaskArtifactUpdate,
+		TaskID:    "task-1",
+		ContextID: "context-1",
+		Append:    &appendChunk,
+		Artifact: protocol.Artifact{
+			ArtifactID: "artifact-1",
+			Name:       &name,
+			Parts:      []protocol.Part{protocol.NewTextPart(text)},
+		},
+	}
+}
+
+func newA2AStreamTestClient(t *testing.T) client.Client {
+	t.Helper()
+	k8sClient, _ := newCountingA2AStreamTestClient(t)
+	return k8sClient
+}
+
+// newCountingA2AStreamTestClient also returns the number of status updates made with the client.
+func newCountingA2AStreamTestClient(t *testing.T) (client.Client, *atomic.Int32) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	var statusUpdates atomic.Int32
+	k8sClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithStatusSubresource(&arkv1alpha1.A2ATask{}).
+		WithInterceptorFuncs(interceptor.Funcs{
+			SubResourceUpdate: func(ctx context.Context, c client.Client, subResourceName string, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+				statusUpdates.Add(1)
+				return c.SubResource(subResourceName).Update(ctx, obj, opts...)
+			},
+		}).
+		Build()
+	return k8sClient, &statusUpdates
+}
+
+func TestStreamA2AAgentRelaysTaskUpdates(t *testing.T) {
+	server := newStreamingA2AServer(t,
+		statusUpdate(TaskStateWorking, agentMessage("m-1", "Looking up flights"), false),
+		artifactUpdate("Paris ", false),
+		artifactUpdate("AF123", true),
+		statusUpdate(TaskStateCompleted, agentMessage("m-2", "Booked"), true),
+	)
+	k8sClient, statusUpdates := newCountingA2AStreamTestClient(t)
+	eventStream := &recordingEventStream{streamed: make(chan struct{}, 10)}
+	ctx := WithQueryContext(context.Background(), "query-uid", "session", "book-trip")
+
+	response, err := StreamA2AAgent(ctx, k8sClient, server.URL, nil, nil, "default", "Book a trip to Paris", "travel", "book-trip", "", nil, nil, eventStream)
+	require.NoError(t, err)
+	assert.Equal(t, &A2AResponse{Content: "Looking up flights\nBooked", ContextID: "context-1", TaskID: "task-1"}, response)
+
+	var updates []*A2ATaskUpdateMetadata
+	for _, chunk := range eventStream.chunks {
+		withMetadata, ok := chunk.(ChunkWithMetadata)
+		require.True(t, ok)
+		assert.Empty(t, withMetadata.Choices, "updates are ignored by OpenAI clients")
+		assert.Equal(t, "query-uid", withMetadata.ID)
+		updates = append(updates, withMetadata.Ark.A2ATaskUpdate)
+	}
+	require.Len(t, updates, 4)
+	assert.Equal(t, &A2ATaskUpdateMetadata{TaskID: "task-1", ContextID: "context-1", State: TaskStateWorking, Message: "Looking up flights"}, updates[0])
+	assert.Equal(t, &A2AArtifactUpdateMetadata{ArtifactID: "artifact-1", Name: "itinerary", Text: "AF123", Append: true}, updates[2].Artifact)
+	assert.Equal(t, &A2ATaskUpdateMetadata{TaskID: "task-1", ContextID: "context-1", State: TaskStateCompleted, Message: "Booked", Final: true}, updates[3])
+
+	var a2aTask arkv1alpha1.A2ATask
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "a2a-task-task-1"}, &a2aTask))
+	assert.Equal(t, "book-trip", a2aTask.Spec.QueryRef.Name)
+	assert.Equal(t, PhaseCompleted, a2aTask.Status.Phase)
+	assert.NotNil(t, a2aTask.Status.StartTime)
+	require.Len(t, a2aTask.Status.History, 2)
+	assert.Equal(t, "Looking up flights", a2aTask.Status.History[0].Parts[0].Text)
+	assert.Equal(t, "Booked", a2aTask.Status.History[1].Parts[0].Text)
+	require.Len(t, a2aTask.Status.Artifacts, 1)
+	require.Len(t, a2aTask.Status.Artifacts[0].Parts, 1, "text chunks are merged")
+	assert.Equal(t, "Paris AF123", a2aTask.Status.Artifacts[0].Parts[0].Text)
+	assert.Equal(t, int32(2), statusUpdates.Load(), "the A2ATask is only updated when the state changes")
+}
+
+func TestStreamA2AAgentRecordsHeldBackUpdatesWhenTheStreamEnds(t *testing.T) {
+	server := newStreamingA2AServer(t,
+		statusUpdate(TaskStateWorking, agentMessage("m-1", "Looking up flights"), false),
+		artifactUpdate("Paris ", false),
+		artifactUpdate("AF123", true),
+	)
+	k8sClient, statusUpdates := newCountingA2AStreamTestClient(t)
+	ctx := WithQueryContext(context.Background(), "query-uid", "session", "book-trip")
+
+	_, err := StreamA2AAgent(ctx, k8sClient, server.URL, nil, nil, "default", "Book a trip to Paris", "travel", "book-trip", "", nil, nil, nil)
+	require.Error(t, err)
+
+	var a2aTask arkv1alpha1.A2ATask
+	require.NoError(t, k8sClient.Get(ctx, types.NamespacedName{Namespace: "default", Name: "a2a-task-task-1"}, &a2aTask))
+	require.Len(t, a2aTask.Status.Artifacts, 1)
+	require.Len(t, a2aTask.Status.Artifacts[0].Parts, 1)
+	assert.Equal(t, "Paris AF123", a2aTask.Status.Artifacts[0].Parts[0].Text)
+	assert.Equal(t, int32(2), statusUpdates.Load())
+}
+
+func TestAppendA2AParts(t *testing.T) {
+	file := protocol.NewFilePartWithURI("itinerary.pdf", "application/pdf", "https://example.com/itinerary.pdf")
+	parts := appendA2AParts([]protocol.Part{protocol.NewTextPart("Paris ")}, []protocol.Part{protocol.NewTextPart("AF123")})
+	parts = appendA2AParts(parts, []protocol.Part{file, protocol.NewTextPart("Booked")})
+
+	require.Len(t, parts, 3)
+	assert.Equal(t, "Paris AF123", extractTextFromParts(parts[:1]))
+	assert.Equal(t, file, parts[1])
+	assert.Equal(t, "Booked", extractTextFromParts(parts[2:]))
+}
+
+func TestStreamA2AAgentUsesArtifactsWithoutMessages(t *testing.T) {
+	server := newStreamingA2AServer(t,
+		artifactUpdate("Paris, AF123", false),
+		statusUpdate(TaskStateCompleted, nil, true),
+	)
+	ctx := WithQueryContext(context.Background(), "query-uid", "session", "book-trip")
+
+	response, err := StreamA2AAgent(ctx, newA2AStreamTestClient(t), server.URL, nil, nil, "default", "Book a trip", "travel", "book-trip", "", nil, nil, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "Paris, AF123", response.Content)
+}
+
+func TestStreamA2AAgentFailsIncompleteTasks(t *testing.T) {
+	tests := []struct {
+		name      string
+		events    []protocol.StreamingMessageResult
+		wantError string
+	}{
+		{
+			name:      "stream ends while working",
+			events:    []protocol.StreamingMessageResult{statusUpdate(TaskStateWorking, agentMessage("m-1", "Looking up flights"), false)},
+			wantError: "A2A stream ended with the task in state 'working'",
+		},
+		{
+			name:      "task fails",
+			events:    []protocol.StreamingMessageResult{statusUpdate(TaskStateFailed, agentMessage("m-1", "No flights to Paris"), true)},
+			wantError: "No flights to Paris",
+		},
+		{
+			name:      "empty stream",
+			wantError: "A2A stream ended without a response",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newStreamingA2AServer(t, tt.events...)
+			ctx := WithQueryContext(context.Background(), "query-uid", "session", "book-trip")
+
+			_, err := StreamA2AAgent(ctx, newA2AStreamTestClient(t), server.URL, nil, nil, "default", "Book a trip", "travel", "book-trip", "", nil, nil, nil)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
@@ -21,6 +21,9 @@ type Agent struct {
 	Name              string
 	Namespace         string
 	Prompt            string
+	PromptFrom        *arkv1alpha1.AgentPromptSource
+	Resources         []arkv1alpha1.AgentResource
+	Instructions      string
 	Description       string
 	Parameters        []arkv1alpha1.Parameter
 	Model             *Model
@@ -41,6 +44,11 @@ func (a *Agent) FullName() string {
 
 // Execute executes the agent with optional event emission for tool calls
 func (a *Agent) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) (*ExecutionResult, error) {
+	ctx, err := enterExecution(ctx, MemberTypeAgent, a.Namespace, a.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	ctx, span := a.telemetryRecorder.StartAgentExecution(ctx, a.Name, a.Namespace)
 	defer span.End()
 
@@ -153,34 +161,61 @@ func (a *Agent) processAssistantMessage(choice openai.ChatCompletionChoice) Mess
 	return assistantMessage
 }
 
-func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatCompletionMessageToolCall) (Message, error) {
+func (a *Agent) executeToolCall(ctx context.Context, toolCall openai.ChatCompletionMessageToolCall, eventStream EventStreamInterface) (Message, []ToolResultImage, error) {
+	ctx = WithToolProgressStream(ctx, eventStream, toolCall.Function.Name, toolCall.ID)
 	result, err := a.Tools.ExecuteTool(ctx, ToolCall(toolCall))
 	toolMessage := ToolMessage(result.Content, result.ID)
 
 	if err != nil {
-		return toolMessage, err
+		return toolMessage, nil, err
 	}
 
-	return toolMessage, nil
+	return toolMessage, result.Images, nil
 }
 
-func (a *Agent) executeToolCalls(ctx context.Context, toolCalls []openai.ChatCompletionMessageToolCall, agentMessages, newMessages *[]Message) error {
+func (a *Agent) executeToolCalls(ctx context.Context, toolCalls []openai.ChatCompletionMessageToolCall, agentMessages, newMessages *[]Message, eventStream EventStreamInterface) error {
+	var imageParts []openai.ChatCompletionContentPartUnionParam
 	for _, tc := range toolCalls {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
 
-		toolMessage, err := a.executeToolCall(ctx, tc)
+		toolMessage, images, err := a.executeToolCall(ctx, tc, eventStream)
 		*agentMessages = append(*agentMessages, toolMessage)
 		*newMessages = append(*newMessages, toolMessage)
 
 		if err != nil {
 			return err
 		}
+		if a.Model != nil && a.Model.Vision {
+			imageParts = append(imageParts, toolImageParts(tc.Function.Name, images)...)
+		}
+	}
+
+	// Tool messages only carry text, so images follow the tool messages of the turn as a user message. They are
+	// only sent to the model, and not kept in the messages of the query.
+	if len(imageParts) > 0 {
+		*agentMessages = append(*agentMessages, Message(openai.UserMessage(imageParts)))
 	}
 	return nil
 }
 
+// toolImageParts returns the images of a tool result as content parts, introduced by the name of the tool.
+func toolImageParts(toolName string, images []ToolResultImage) []openai.ChatCompletionContentPartUnionParam {
+	if len(images) == 0 {
+		return nil
+	}
+	parts := []openai.ChatCompletionContentPartUnionParam{
+		openai.TextContentPart(fmt.Sprintf("Images returned by tool %s:", toolName)),
+	}
+	for _, image := range images {
+		parts = append(parts, openai.ImageContentPart(openai.ChatCompletionContentPartImageImageURLParam{
+			URL: fmt.Sprintf("data:%s;base64,%s", image.MIMEType, image.Data),
+		}))
+	}
+	return parts
+}
+
 // executeLocally executes the agent using the built-in OpenAI-compatible engine
 func (a *Agent) executeLocally(ctx context.Context, userInput Message, history []Message, _ MemoryInterface, eventStream EventStreamInterface) ([]Message, error) {
 	var tools []openai.ChatCompletionToolParam
@@ -219,7 +254,7 @@ func (a *Agent) executeLocally(ctx context.Context, userInput Message, history [
 			return newMessages, nil
 		}
 
-		if err := a.executeToolCalls(ctx, choice.Message.ToolCalls, &agentMessages, &newMessages); err != nil {
+		if err := a.executeToolCalls(ctx, choice.Message.ToolCalls, &agentMessages, &newMessages, eventStream); err != nil {
 			logger := logf.FromContext(ctx)
 			if !IsTerminateTeam(err) {
 				logger.Error(err, "Tool execution failed", "agent", a.FullName())
@@ -383,6 +418,8 @@ func MakeAgent(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Ag
 		Name:              crd.Name,
 		Namespace:         crd.Namespace,
 		Prompt:            crd.Spec.Prompt,
+		PromptFrom:        crd.Spec.PromptFrom,
+		Resources:         crd.Spec.Resources,
 		Description:       crd.Spec.Description,
 		Parameters:        crd.Spec.Parameters,
 		Model:             resolvedModel,
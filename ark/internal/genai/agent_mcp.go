@@ -0,0 +1,41 @@
+package genai
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// renderMCPPrompt renders the MCP prompt of the agent, with the agent parameters as its arguments.
+func (a *Agent) renderMCPPrompt(ctx context.Context, promptRef *arkv1alpha1.MCPPromptRef, parameters map[string]string) (string, error) {
+	mcpPool, mcpSettings := a.Tools.GetMCPPool()
+	mcpClient, err := getMCPServerClient(ctx, a.client, promptRef.MCPServerRef, a.Namespace, mcpPool, mcpSettings)
+	if err != nil {
+		return "", err
+	}
+	return mcpClient.GetPrompt(ctx, promptRef.Name, parameters)
+}
+
+// readResources reads the MCP resources of the agent as context to attach to its prompt.
+func (a *Agent) readResources(ctx context.Context) (string, error) {
+	if len(a.Resources) == 0 {
+		return "", nil
+	}
+
+	mcpPool, mcpSettings := a.Tools.GetMCPPool()
+	var attachments strings.Builder
+	for _, resource := range a.Resources {
+		mcpClient, err := getMCPServerClient(ctx, a.client, resource.MCPServerRef, a.Namespace, mcpPool, mcpSettings)
+		if err != nil {
+			return "", err
+		}
+		text, err := mcpClient.ReadResource(ctx, resource.URI)
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&attachments, "\n\n<resource uri=%q>\n%s\n</resource>", resource.URI, text)
+	}
+	return attachments.String(), nil
+}
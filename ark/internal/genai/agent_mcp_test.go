@@ -0,0 +1,162 @@
+package genai
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/eventing/mock"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/eventing/recorder"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestAgentPromptFromMCP(t *testing.T) {
+	ctx := context.Background()
+	mcpServer := &arkv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "docs", Namespace: "default"},
+		Spec:       arkv1alpha1.MCPServerSpec{Address: arkv1alpha1.ValueSource{Value: "http://docs.default.svc"}},
+	}
+
+	tools := NewToolRegistry(nil, noop.NewProvider().ToolRecorder(), eventnoop.NewProvider().ToolRecorder())
+	mcpPool, _ := tools.GetMCPPool()
+	mcpPool.clients["default/docs"] = newInMemoryMCPClient(t, newDocsMCPServer())
+
+	serverRef := arkv1alpha1.MCPServerRef{Name: "docs"}
+	agent := &Agent{
+		Name:       "reviewer",
+		Namespace:  "default",
+		PromptFrom: &arkv1alpha1.AgentPromptSource{MCPPrompt: &arkv1alpha1.MCPPromptRef{MCPServerRef: serverRef, Name: "review"}},
+		Parameters: []arkv1alpha1.Parameter{{Name: "language", Value: "Go"}},
+		Resources:  []arkv1alpha1.AgentResource{{MCPServerRef: serverRef, URI: "docs://readme"}},
+		Tools:      tools,
+		client:     setupTestClient([]client.Object{mcpServer}),
+	}
+
+	prompt, err := agent.resolvePrompt(ctx)
+	require.NoError(t, err)
+	require.Equal(t, "Review Go code.\n\nArguments: 1\n\n<resource uri=\"docs://readme\">\n# Readme\n</resource>", prompt)
+}
+
+func TestAgentForwardsToolImagesToVisionModels(t *testing.T) {
+	ctx := context.Background()
+	mcpClient := newInMemoryMCPClient(t, newResultsMCPServer(map[string]*mcp.CallToolResult{
+		"chart": {Content: []mcp.Content{&mcp.ImageContent{MIMEType: "image/png", Data: []byte("png-bytes")}}},
+	}))
+	toolCalls := []openai.ChatCompletionMessageToolCall{
+		{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "chart", Arguments: "{}"}},
+		{ID: "call-2", Function: openai.ChatCompletionMessageToolCallFunction{Name: "chart", Arguments: "{}"}},
+	}
+
+	for _, vision := range []bool{true, false} {
+		tools := NewToolRegistry(nil, noop.NewProvider().ToolRecorder(), eventnoop.NewProvider().ToolRecorder())
+		tools.RegisterTool(ToolDefinition{Name: "chart"}, &MCPExecutor{MCPClient: mcpClient, ToolName: "chart"})
+		agent := &Agent{Name: "analyst", Namespace: "default", Tools: tools, Model: &Model{Vision: vision}}
+
+		var agentMessages, newMessages []Message
+		require.NoError(t, agent.executeToolCalls(ctx, toolCalls, &agentMessages, &newMessages, nil))
+		require.Len(t, newMessages, 2, "images are not kept in the messages of the query")
+		require.NotNil(t, newMessages[1].OfTool)
+
+		if !vision {
+			require.Len(t, agentMessages, 2)
+			continue
+		}
+		// Images follow all tool messages of the turn
+		require.Len(t, agentMessages, 3)
+		parts := agentMessages[2].OfUser.Content.OfArrayOfContentParts
+		require.Len(t, parts, 4)
+		require.Equal(t, "Images returned by tool chart:", parts[0].OfText.Text)
+		require.Equal(t, "data:image/png;base64,"+base64.StdEncoding.EncodeToString([]byte("png-bytes")), parts[1].OfImageURL.ImageURL.URL)
+	}
+}
+
+// recordingEventStream keeps the chunks streamed to it, and signals each of them on streamed.
+type recordingEventStream struct {
+	mu       sync.Mutex
+	chunks   []interface{}
+	streamed chan struct{}
+}
+
+func (s *recordingEventStream) StreamChunk(ctx context.Context, chunk interface{}) error {
+	s.mu.Lock()
+	s.chunks = append(s.chunks, chunk)
+	s.mu.Unlock()
+	s.streamed <- struct{}{}
+	return nil
+}
+
+func (s *recordingEventStream) NotifyCompletion(ctx context.Context) error { return nil }
+
+func (s *recordingEventStream) Close() error { return nil }
+
+func TestAgentStreamsMCPToolProgress(t *testing.T) {
+	eventStream := &recordingEventStream{streamed: make(chan struct{})}
+
+	// Notifications are delivered concurrently with results, so the tool waits for each update to be streamed
+	server := mcp.NewServer(&mcp.Implementation{Name: "indexer", Version: "v1"}, nil)
+	server.AddTool(&mcp.Tool{Name: "index", InputSchema: &jsonschema.Schema{Type: "object"}},
+		func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			for _, progress := range []float64{40, 70, 100} {
+				err := req.Session.NotifyProgress(ctx, &mcp.ProgressNotificationParams{
+					ProgressToken: req.Params.GetProgressToken(), Progress: progress, Total: 100, Message: "indexing",
+				})
+				if err != nil {
+					return nil, err
+				}
+				select {
+				case <-eventStream.streamed:
+				case <-time.After(5 * time.Second):
+					return nil, errors.New("progress was not streamed")
+				}
+			}
+			return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: "indexed"}}}, nil
+		})
+
+	emitter := mock.NewMockEventEmitter()
+	toolRecorder := recorder.NewToolRecorder(emitter, emitter)
+	query := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "index-docs", Namespace: "default", UID: "query-uid"}}
+	ctx := toolRecorder.InitializeQueryContext(context.Background(), query)
+
+	tools := NewToolRegistry(nil, noop.NewProvider().ToolRecorder(), toolRecorder)
+	tools.RegisterTool(ToolDefinition{Name: "index"}, &MCPExecutor{MCPClient: newInMemoryMCPClient(t, server), ToolName: "index"})
+	agent := &Agent{Name: "indexer", Namespace: "default", Tools: tools, Model: &Model{}}
+	toolCalls := []openai.ChatCompletionMessageToolCall{
+		{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "index", Arguments: "{}"}},
+	}
+
+	var agentMessages, newMessages []Message
+	require.NoError(t, agent.executeToolCalls(ctx, toolCalls, &agentMessages, &newMessages, eventStream))
+
+	require.Len(t, eventStream.chunks, 3)
+	chunk := eventStream.chunks[0].(ChunkWithMetadata)
+	require.Empty(t, chunk.Choices)
+	require.Equal(t, &ToolProgressMetadata{Tool: "index", ToolCallID: "call-1", Progress: 40, Total: 100, Message: "indexing"}, chunk.Ark.ToolProgress)
+
+	var progressEvents []string
+	for _, event := range emitter.GetEvents() {
+		if event.Reason == "ToolCallProgress" {
+			progressEvents = append(progressEvents, event.Message)
+		}
+	}
+	// Events are throttled to the first update and the last one held back
+	require.Len(t, progressEvents, 2)
+	require.Contains(t, progressEvents[0], "Tool index: indexing 40/100")
+	require.Contains(t, progressEvents[1], "Tool index: indexing 100/100")
+}
+
+func TestToolProgressString(t *testing.T) {
+	require.Equal(t, "indexing 40/100", ToolProgress{Progress: 40, Total: 100, Message: "indexing"}.String())
+	require.Equal(t, "2.5", ToolProgress{Progress: 2.5}.String())
+}
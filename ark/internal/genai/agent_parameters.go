@@ -13,20 +13,51 @@ import (
 )
 
 func (a *Agent) resolvePrompt(ctx context.Context) (string, error) {
-	templateData := make(map[string]any)
+	prompt, err := a.resolveAgentPrompt(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Team instructions are already resolved when the agent is loaded as a team member
+	if a.Instructions != "" {
+		return a.Instructions + "\n\n" + prompt, nil
+	}
+	return prompt, nil
+}
 
+func (a *Agent) resolveAgentPrompt(ctx context.Context) (string, error) {
 	agentParams, err := a.resolveParameters(ctx)
 	if err != nil {
 		return "", fmt.Errorf("failed to resolve parameters: %w", err)
 	}
-	for name, value := range agentParams {
-		templateData[name] = value
+
+	prompt, err := a.renderPrompt(ctx, agentParams)
+	if err != nil {
+		return "", err
 	}
 
-	if len(templateData) == 0 {
+	// Resources are attached after rendering, so that their content is never read as a template
+	resources, err := a.readResources(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to read resources: %w", err)
+	}
+	return prompt + resources, nil
+}
+
+func (a *Agent) renderPrompt(ctx context.Context, agentParams map[string]string) (string, error) {
+	if a.PromptFrom != nil && a.PromptFrom.MCPPrompt != nil {
+		return a.renderMCPPrompt(ctx, a.PromptFrom.MCPPrompt, agentParams)
+	}
+
+	if len(agentParams) == 0 {
 		return a.Prompt, nil
 	}
 
+	templateData := make(map[string]any)
+	for name, value := range agentParams {
+		templateData[name] = value
+	}
+
 	resolved, err := common.ResolveTemplate(a.Prompt, templateData)
 	if err != nil {
 		return "", fmt.Errorf("template resolution failed: %w", err)
@@ -127,6 +127,18 @@ func TestAgentParameterResolution(t *testing.T) {
 			},
 			wantPrompt: "Hello NestedUser",
 		},
+		{
+			name: "team instructions are prepended",
+			agent: &Agent{
+				Name:         "test-agent",
+				Prompt:       "Hello {{.name}}",
+				Instructions: "You are the reviewer of team support.",
+				Parameters: []arkv1alpha1.Parameter{
+					{Name: "name", Value: "World"},
+				},
+			},
+			wantPrompt: "You are the reviewer of team support.\n\nHello World",
+		},
 		{
 			name: "missing query context",
 			agent: &Agent{
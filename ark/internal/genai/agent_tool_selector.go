@@ -0,0 +1,88 @@
+package genai
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"sort"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/eventing"
+	"mckinsey.com/ark/internal/telemetry"
+)
+
+// ListSelectedTools returns the Tools of the namespace that an agent tool of the selector type pulls in,
+// sorted by name.
+func ListSelectedTools(ctx context.Context, k8sClient client.Client, namespace string, selector *arkv1alpha1.AgentToolSelector) ([]arkv1alpha1.Tool, error) {
+	if selector == nil || (len(selector.MatchLabels) == 0 && len(selector.MatchExpressions) == 0) {
+		return nil, fmt.Errorf("selector tools must specify a label selector")
+	}
+	labelSelector, err := metav1.LabelSelectorAsSelector(&selector.LabelSelector)
+	if err != nil {
+		return nil, fmt.Errorf("invalid tool selector: %w", err)
+	}
+
+	var toolList arkv1alpha1.ToolList
+	if err := k8sClient.List(ctx, &toolList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: labelSelector}); err != nil {
+		return nil, fmt.Errorf("failed to list tools for selector: %w", err)
+	}
+
+	var tools []arkv1alpha1.Tool
+	for _, tool := range toolList.Items {
+		if ToolNameSelected(selector, tool.Name) {
+			tools = append(tools, tool)
+		}
+	}
+	sort.Slice(tools, func(i, j int) bool { return tools[i].Name < tools[j].Name })
+	return tools, nil
+}
+
+// ToolNameSelected applies the include and exclude patterns of a selector to a tool name.
+func ToolNameSelected(selector *arkv1alpha1.AgentToolSelector, name string) bool {
+	return ToolNameMatches(selector.Include, selector.Exclude, name)
+}
+
+// ToolNameMatches reports whether a tool name matches one of the include glob patterns, or there are none, and
+// none of the exclude patterns.
+func ToolNameMatches(include, exclude []string, name string) bool {
+	matches := func(patterns []string) bool {
+		for _, pattern := range patterns {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+		return false
+	}
+	if len(include) > 0 && !matches(include) {
+		return false
+	}
+	return !matches(exclude)
+}
+
+// registerSelectedTools registers the Tools an agent tool of the selector type pulls in, named with the
+// prefix of the selector and with its functions. Selected tools never replace tools that are already registered.
+func (r *ToolRegistry) registerSelectedTools(ctx context.Context, k8sClient client.Client, agentTool arkv1alpha1.AgentTool, namespace string, telemetryProvider telemetry.Provider, eventingProvider eventing.Provider) error {
+	tools, err := ListSelectedTools(ctx, k8sClient, namespace, agentTool.Selector)
+	if err != nil {
+		return err
+	}
+
+	for i := range tools {
+		tool := &tools[i]
+		name := agentTool.Selector.NamePrefix + tool.Name
+		if _, exists := r.tools[name]; exists {
+			logf.FromContext(ctx).Info("skipping selected tool with the name of a registered tool", "tool", tool.Name, "name", name)
+			continue
+		}
+
+		selected := arkv1alpha1.AgentTool{Type: AgentToolTypeCustom, Name: name, Functions: agentTool.Functions}
+		if err := r.registerToolCRD(ctx, k8sClient, tool, selected, namespace, telemetryProvider, eventingProvider); err != nil {
+			return err
+		}
+	}
+	return nil
+}
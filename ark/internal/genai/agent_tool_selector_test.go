@@ -0,0 +1,74 @@
+package genai
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestToolNameSelected(t *testing.T) {
+	selector := &arkv1alpha1.AgentToolSelector{Include: []string{"github-*"}, Exclude: []string{"github-delete-*"}}
+
+	assert.True(t, ToolNameSelected(selector, "github-create-issue"))
+	assert.False(t, ToolNameSelected(selector, "github-delete-repo"))
+	assert.False(t, ToolNameSelected(selector, "slack-post"))
+	assert.True(t, ToolNameSelected(&arkv1alpha1.AgentToolSelector{}, "slack-post"))
+}
+
+func TestRegisterSelectedTools(t *testing.T) {
+	ctx := context.Background()
+	builtinTool := func(name string, labels map[string]string) *arkv1alpha1.Tool {
+		return &arkv1alpha1.Tool{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default", Labels: labels},
+			Spec:       arkv1alpha1.ToolSpec{Type: ToolTypeBuiltin, Description: name},
+		}
+	}
+	github := map[string]string{"mcp/server": "github"}
+	k8sClient := setupTestClientForTools([]client.Object{
+		builtinTool("noop", github),
+		builtinTool("terminate", github),
+		builtinTool("other", map[string]string{"mcp/server": "slack"}),
+	})
+
+	agent := &arkv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "researcher", Namespace: "default"},
+		Spec: arkv1alpha1.AgentSpec{Tools: []arkv1alpha1.AgentTool{
+			{Type: "built-in", Name: "noop"},
+			{
+				Type: AgentToolTypeSelector,
+				Selector: &arkv1alpha1.AgentToolSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: github},
+				},
+			},
+			{
+				Type: AgentToolTypeSelector,
+				Selector: &arkv1alpha1.AgentToolSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: github},
+					Exclude:       []string{"term*"},
+					NamePrefix:    "gh-",
+				},
+			},
+		}},
+	}
+
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	registry := NewToolRegistry(nil, telemetryProvider.ToolRecorder(), eventingProvider.ToolRecorder())
+	require.NoError(t, registry.registerTools(ctx, k8sClient, agent, telemetryProvider, eventingProvider))
+
+	var names []string
+	for _, definition := range registry.GetToolDefinitions() {
+		names = append(names, definition.Name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"gh-noop", "noop", "terminate"}, names)
+}
@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"sync"
 	"time"
 
+	"golang.org/x/oauth2"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -15,20 +17,32 @@ import (
 	"mckinsey.com/ark/internal/telemetry"
 )
 
-// Add MCP client pool to ToolRegistry
+// MCPClientPool holds the MCP sessions used by one tool registry. Sessions are leased from the controller-wide
+// session pool and returned to it when the registry is closed.
 type MCPClientPool struct {
-	clients map[string]*MCPClient // key: mcpServerName
+	mu       sync.Mutex
+	sessions *MCPSessionPool
+	stdio    *MCPStdioSupervisor
+	clients  map[string]*MCPClient // key: mcpServerNamespace/mcpServerName
+	releases map[string]func()
 }
 
 func NewMCPClientPool() *MCPClientPool {
 	return &MCPClientPool{
-		clients: make(map[string]*MCPClient),
+		sessions: DefaultMCPSessionPool,
+		stdio:    DefaultMCPStdioSupervisor,
+		clients:  make(map[string]*MCPClient),
+		releases: make(map[string]func()),
 	}
 }
 
-// GetOrCreateClient returns an existing MCP client or creates a new one for the given server
-func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serverNamespace, serverURL string, headers map[string]string, transport string, timeout time.Duration, mcpSettings map[string]MCPSettings) (*MCPClient, error) {
+// GetOrCreateClient returns the MCP client of the registry for the given server, leasing a session of the
+// shared pool the first time the server is used
+func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serverNamespace string, generation int64, serverURL string, headers map[string]string, tokenSource oauth2.TokenSource, transport string, timeout time.Duration, mcpSettings map[string]MCPSettings) (*MCPClient, error) {
 	key := fmt.Sprintf("%s/%s", serverNamespace, serverName)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
 	if mcpClient, exists := p.clients[key]; exists {
 		return mcpClient, nil
 	}
@@ -36,21 +50,51 @@ func (p *MCPClientPool) GetOrCreateClient(ctx context.Context, serverName, serve
 	// Get MCP settings for this server if available
 	mcpSetting := mcpSettings[key]
 
-	// Create new client for this MCP server
-	mcpClient, err := NewMCPClient(ctx, serverURL, headers, transport, timeout, mcpSetting)
+	sessionKey := MCPSessionKey(serverNamespace, serverName, generation, serverURL, transport, headers, mcpSetting)
+	mcpClient, release, err := p.sessions.Acquire(ctx, sessionKey, func(ctx context.Context) (*MCPClient, error) {
+		return NewMCPClient(ctx, serverURL, headers, tokenSource, transport, timeout, mcpSetting)
+	})
 	if err != nil {
 		return nil, err
 	}
 
 	p.clients[key] = mcpClient
+	p.releases[key] = release
+	addToMCPSessionScope(ctx, release)
+	return mcpClient, nil
+}
+
+// GetOrCreateStdioClient returns the session of the process of a stdio MCP server. The session belongs to the
+// stdio supervisor, so it is not closed with the registry.
+func (p *MCPClientPool) GetOrCreateStdioClient(ctx context.Context, server types.NamespacedName, command MCPStdioCommand, timeout time.Duration) (*MCPClient, error) {
+	key := server.String()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if mcpClient, exists := p.clients[key]; exists {
+		return mcpClient, nil
+	}
+
+	mcpClient, err := p.stdio.Client(ctx, server, command, timeout)
+	if err != nil {
+		return nil, err
+	}
+	p.clients[key] = mcpClient
+	p.releases[key] = func() {}
 	return mcpClient, nil
 }
 
-// Close closes all MCP client connections in the pool
+// Close returns the sessions of the registry to the shared pool. Clients that were not leased from it are closed.
 func (p *MCPClientPool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
 	var lastErr error
 	for key, mcpClient := range p.clients {
-		if mcpClient != nil && mcpClient.client != nil {
+		if release, leased := p.releases[key]; leased {
+			release()
+			delete(p.releases, key)
+		} else if mcpClient != nil && mcpClient.client != nil {
 			if err := mcpClient.client.Close(); err != nil {
 				lastErr = fmt.Errorf("failed to close MCP client %s: %w", key, err)
 			}
@@ -61,8 +105,22 @@ func (p *MCPClientPool) Close() error {
 }
 
 func (r *ToolRegistry) registerTools(ctx context.Context, k8sClient client.Client, agent *arkv1alpha1.Agent, telemetryProvider telemetry.Provider, eventingProvider eventing.Provider) error {
+	policies, err := listToolPolicies(ctx, k8sClient, agent.Namespace)
+	if err != nil {
+		return err
+	}
+	r.toolPolicies = policies
+	r.caller = ToolCaller{Agent: agent.Name, AgentLabels: agent.Labels}
+	if query, ok := ctx.Value(QueryContextKey).(*arkv1alpha1.Query); ok {
+		r.caller.ServiceAccount = query.Spec.ServiceAccount
+	}
+
 	for _, agentTool := range agent.Spec.Tools {
-		if err := r.registerTool(ctx, k8sClient, agentTool, agent.Namespace, telemetryProvider, eventingProvider); err != nil {
+		register := r.registerTool
+		if agentTool.Type == AgentToolTypeSelector {
+			register = r.registerSelectedTools
+		}
+		if err := register(ctx, k8sClient, agentTool, agent.Namespace, telemetryProvider, eventingProvider); err != nil {
 			return err
 		}
 	}
@@ -80,7 +138,11 @@ func CreateToolExecutor(ctx context.Context, k8sClient client.Client, tool *arkv
 	case ToolTypeTeam:
 		return createTeamExecutor(ctx, k8sClient, tool, namespace, telemetryProvider, eventingProvider)
 	case ToolTypeBuiltin:
-		return createBuiltinExecutor(tool)
+		return createBuiltinExecutor(k8sClient, tool, namespace)
+	case ToolTypeGraphQL:
+		return createGraphQLExecutor(k8sClient, tool, namespace)
+	case ToolTypeWasm:
+		return createWasmExecutor(k8sClient, tool, namespace)
 	default:
 		return nil, fmt.Errorf("unsupported tool type %s for tool %s", tool.Spec.Type, tool.Name)
 	}
@@ -128,17 +190,36 @@ func createTeamExecutor(ctx context.Context, k8sClient client.Client, tool *arkv
 	}, nil
 }
 
-func createBuiltinExecutor(tool *arkv1alpha1.Tool) (ToolExecutor, error) {
-	switch tool.Name {
+func createBuiltinExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string) (ToolExecutor, error) {
+	name := BuiltinToolName(tool)
+	switch name {
 	case BuiltinToolNoop:
 		return &NoopExecutor{}, nil
 	case BuiltinToolTerminate:
 		return &TerminateExecutor{}, nil
+	case BuiltinToolK8sGet, BuiltinToolK8sList, BuiltinToolK8sDescribeEvents, BuiltinToolK8sLogs:
+		if tool.Spec.Builtin == nil || tool.Spec.Builtin.Kubernetes == nil {
+			return nil, fmt.Errorf("builtin.kubernetes is required for tool %s", tool.Name)
+		}
+		return &KubernetesToolExecutor{
+			Operation: name,
+			K8sClient: k8sClient,
+			Access:    tool.Spec.Builtin.Kubernetes,
+			Namespace: namespace,
+		}, nil
 	default:
-		return nil, fmt.Errorf("unsupported builtin tool %s", tool.Name)
+		return nil, fmt.Errorf("unsupported builtin tool %s", name)
 	}
 }
 
+// BuiltinToolName returns the built-in a tool runs: spec.builtin.name, or the name of the tool when it is not set.
+func BuiltinToolName(tool *arkv1alpha1.Tool) string {
+	if tool.Spec.Builtin != nil && tool.Spec.Builtin.Name != "" {
+		return tool.Spec.Builtin.Name
+	}
+	return tool.Name
+}
+
 func createHTTPExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string) (ToolExecutor, error) {
 	if tool.Spec.HTTP == nil {
 		return nil, fmt.Errorf("http spec is required for tool %s", tool.Name)
@@ -150,25 +231,64 @@ func createHTTPExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespa
 	}, nil
 }
 
+func createGraphQLExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string) (ToolExecutor, error) {
+	if tool.Spec.GraphQL == nil {
+		return nil, fmt.Errorf("graphql spec is required for tool %s", tool.Name)
+	}
+	return &GraphQLExecutor{
+		K8sClient:     k8sClient,
+		ToolName:      tool.Name,
+		ToolNamespace: namespace,
+	}, nil
+}
+
+func createWasmExecutor(k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string) (ToolExecutor, error) {
+	if tool.Spec.Wasm == nil {
+		return nil, fmt.Errorf("wasm spec is required for tool %s", tool.Name)
+	}
+	return &WasmExecutor{
+		K8sClient:     k8sClient,
+		ToolName:      tool.Name,
+		ToolNamespace: namespace,
+	}, nil
+}
+
 func createMCPExecutor(ctx context.Context, k8sClient client.Client, tool *arkv1alpha1.Tool, namespace string, mcpPool *MCPClientPool, mcpSettings map[string]MCPSettings) (ToolExecutor, error) {
 	if tool.Spec.MCP == nil {
 		return nil, fmt.Errorf("mcp spec is required for tool %s", tool.Name)
 	}
 
-	mcpServerNamespace := tool.Spec.MCP.MCPServerRef.Namespace
+	mcpClient, err := getMCPServerClient(ctx, k8sClient, tool.Spec.MCP.MCPServerRef, namespace, mcpPool, mcpSettings)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get or create MCP client for tool %s: %w", tool.Name, err)
+	}
+
+	return &MCPExecutor{
+		ToolName:  tool.Spec.MCP.ToolName,
+		MCPClient: mcpClient,
+	}, nil
+}
+
+// getMCPServerClient returns the client of the pool for a referenced MCP server, connecting to it if needed.
+func getMCPServerClient(ctx context.Context, k8sClient client.Client, serverRef arkv1alpha1.MCPServerRef, namespace string, mcpPool *MCPClientPool, mcpSettings map[string]MCPSettings) (*MCPClient, error) {
+	mcpServerNamespace := serverRef.Namespace
 	if mcpServerNamespace == "" {
 		mcpServerNamespace = namespace
 	}
 
 	var mcpServerCRD arkv1alpha1.MCPServer
 	mcpServerKey := types.NamespacedName{
-		Name:      tool.Spec.MCP.MCPServerRef.Name,
+		Name:      serverRef.Name,
 		Namespace: mcpServerNamespace,
 	}
 	if err := k8sClient.Get(ctx, mcpServerKey, &mcpServerCRD); err != nil {
 		return nil, fmt.Errorf("failed to get MCP server %v: %w", mcpServerKey, err)
 	}
 
+	if mcpServerCRD.Spec.Transport == MCPStdioTransport {
+		return getMCPStdioServerClient(ctx, k8sClient, &mcpServerCRD, mcpPool)
+	}
+
 	mcpURL, err := BuildMCPServerURL(ctx, k8sClient, &mcpServerCRD)
 	if err != nil {
 		return nil, fmt.Errorf("failed to build MCP server URL: %w", err)
@@ -183,6 +303,11 @@ func createMCPExecutor(ctx context.Context, k8sClient client.Client, tool *arkv1
 		headers[header.Name] = value
 	}
 
+	tokenSource, err := ResolveAuthTokenSource(ctx, k8sClient, mcpServerCRD.Spec.Auth, mcpServerCRD.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve auth for MCP server %v: %w", mcpServerKey, err)
+	}
+
 	// Parse timeout from MCPServer spec (default to 30s if not specified)
 	timeout := 30 * time.Second
 	if mcpServerCRD.Spec.Timeout != "" {
@@ -194,24 +319,33 @@ func createMCPExecutor(ctx context.Context, k8sClient client.Client, tool *arkv1
 	}
 
 	// Use the MCP client pool to get or create the client
-	mcpClient, err := mcpPool.GetOrCreateClient(
+	return mcpPool.GetOrCreateClient(
 		ctx,
-		tool.Spec.MCP.MCPServerRef.Name,
+		serverRef.Name,
 		mcpServerNamespace,
+		mcpServerCRD.Generation,
 		mcpURL,
 		headers,
+		tokenSource,
 		mcpServerCRD.Spec.Transport,
 		timeout,
 		mcpSettings,
 	)
+}
+
+// getMCPStdioServerClient returns the session of the process of a stdio MCP server, which all queries share.
+func getMCPStdioServerClient(ctx context.Context, k8sClient client.Client, mcpServerCRD *arkv1alpha1.MCPServer, mcpPool *MCPClientPool) (*MCPClient, error) {
+	command, err := mcpPool.stdio.ResolveCommand(ctx, k8sClient, mcpServerCRD)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get or create MCP client for tool %s: %w", tool.Name, err)
+		return nil, err
 	}
-
-	return &MCPExecutor{
-		ToolName:  tool.Spec.MCP.ToolName,
-		MCPClient: mcpClient,
-	}, nil
+	timeout := 30 * time.Second
+	if mcpServerCRD.Spec.Timeout != "" {
+		if timeout, err = time.ParseDuration(mcpServerCRD.Spec.Timeout); err != nil {
+			return nil, fmt.Errorf("failed to parse timeout %s: %w", mcpServerCRD.Spec.Timeout, err)
+		}
+	}
+	return mcpPool.GetOrCreateStdioClient(ctx, types.NamespacedName{Namespace: mcpServerCRD.Namespace, Name: mcpServerCRD.Name}, command, timeout)
 }
 
 func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client, agentTool arkv1alpha1.AgentTool, namespace string, telemetryProvider telemetry.Provider, eventingProvider eventing.Provider) error {
@@ -225,6 +359,24 @@ func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client
 		return fmt.Errorf("failed to get tool %s: %w", toolName, err)
 	}
 
+	return r.registerToolCRD(ctx, k8sClient, tool, agentTool, namespace, telemetryProvider, eventingProvider)
+}
+
+// registerToolCRD registers a Tool under the name and with the overrides of the agent tool.
+func (r *ToolRegistry) registerToolCRD(ctx context.Context, k8sClient client.Client, tool *arkv1alpha1.Tool, agentTool arkv1alpha1.AgentTool, namespace string, telemetryProvider telemetry.Provider, eventingProvider eventing.Provider) error {
+	toolName := tool.Name
+
+	// Tools of unreachable MCP servers are kept during the grace period of the server, but not offered
+	if tool.Status.State == arkv1alpha1.ToolStateUnavailable {
+		logf.FromContext(ctx).Info("skipping unavailable tool", "tool", toolName, "reason", tool.Status.Message)
+		return nil
+	}
+
+	// Tools denied by a ToolPolicy are not offered to the agent
+	if decision := r.bindToolPolicies(ctx, tool, agentTool.Name); !decision.allowed {
+		return nil
+	}
+
 	toolDef := CreateToolFromCRD(tool)
 
 	// Set the exposed name (the name the agent will see)
@@ -236,6 +388,14 @@ func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client
 		return fmt.Errorf("failed to create executor for tool %s: %w", toolDef.Name, err)
 	}
 
+	// Results are cached by the validated arguments, after partial parameters are injected
+	executor = newCachingToolExecutor(tool, executor, r.mcpSettings)
+
+	// Arguments of external tools are validated after partial parameters are injected
+	if tool.Spec.Type == ToolTypeHTTP || tool.Spec.Type == ToolTypeMCP || tool.Spec.Type == ToolTypeGraphQL || tool.Spec.Type == ToolTypeWasm {
+		executor = newArgumentValidatingExecutor(ctx, tool, executor)
+	}
+
 	// Override description if provided at the agent tool level
 	if agentTool.Description != "" {
 		toolDef.Description = agentTool.Description
@@ -250,10 +410,11 @@ func (r *ToolRegistry) registerTool(ctx context.Context, k8sClient client.Client
 		}
 		// Wrap with PartialToolExecutor if partial is specified
 		executor = &PartialToolExecutor{
-			BaseExecutor: executor,
-			Partial:      agentTool.Partial,
-			K8sClient:    k8sClient,
-			Namespace:    namespace,
+			BaseExecutor:     executor,
+			Partial:          agentTool.Partial,
+			K8sClient:        k8sClient,
+			Namespace:        namespace,
+			LenientArguments: tool.Spec.LenientArguments,
 		}
 	}
 
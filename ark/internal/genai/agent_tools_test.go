@@ -101,6 +101,31 @@ func TestRegisterToolDescriptionOverride(t *testing.T) {
 	}
 }
 
+func TestRegisterToolSkipsUnavailableTools(t *testing.T) {
+	ctx := context.Background()
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "docs-search", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type: ToolTypeMCP,
+			MCP: &arkv1alpha1.MCPToolRef{
+				MCPServerRef: arkv1alpha1.MCPServerRef{Name: "docs"},
+				ToolName:     "search",
+			},
+		},
+		Status: arkv1alpha1.ToolStatus{State: arkv1alpha1.ToolStateUnavailable, Message: "MCP server docs is unreachable"},
+	}
+	k8sClient := setupTestClientForTools([]client.Object{tool})
+
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	registry := NewToolRegistry(nil, telemetryProvider.ToolRecorder(), eventingProvider.ToolRecorder())
+
+	// The MCP server does not exist, so registering the tool would fail if it was not skipped
+	err := registry.registerTool(ctx, k8sClient, arkv1alpha1.AgentTool{Type: "custom", Name: "docs-search"}, "default", telemetryProvider, eventingProvider)
+	require.NoError(t, err)
+	require.Empty(t, registry.GetToolDefinitions())
+}
+
 func TestRegisterToolDescriptionWithPartial(t *testing.T) {
 	ctx := context.Background()
 
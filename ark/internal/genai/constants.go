@@ -14,8 +14,9 @@ const (
 
 // Agent tool type constants
 const (
-	AgentToolTypeBuiltIn = "built-in"
-	AgentToolTypeCustom  = "custom"
+	AgentToolTypeBuiltIn  = "built-in"
+	AgentToolTypeCustom   = "custom"
+	AgentToolTypeSelector = "selector"
 )
 
 // Role constants for execution engine messages
@@ -33,15 +34,25 @@ const (
 	ToolTypeAgent   = "agent"
 	ToolTypeTeam    = "team"
 	ToolTypeBuiltin = "builtin"
+	ToolTypeGraphQL = "graphql"
+	ToolTypeWasm    = "wasm"
 )
 
 // Team member type constants
 const (
 	MemberTypeAgent = "agent"
+	MemberTypeTeam  = "team"
 )
 
 // Built-in tool name constants
 const (
-	BuiltinToolNoop      = "noop"
-	BuiltinToolTerminate = "terminate"
+	BuiltinToolNoop         = "noop"
+	BuiltinToolTerminate    = "terminate"
+	BuiltinToolTeamStateGet = "team_state_get"
+	BuiltinToolTeamStateSet = "team_state_set"
+
+	BuiltinToolK8sGet            = "k8s_get"
+	BuiltinToolK8sList           = "k8s_list"
+	BuiltinToolK8sDescribeEvents = "k8s_describe_events"
+	BuiltinToolK8sLogs           = "k8s_logs"
 )
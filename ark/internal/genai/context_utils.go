@@ -20,6 +20,10 @@ const (
 	teamKey   contextKey = "team"   // Current team name
 	agentKey  contextKey = "agent"  // Current agent name
 	modelKey  contextKey = "model"  // Current model name
+	// executionPathKey carries the agents and teams entered so far, to detect cycles
+	executionPathKey contextKey = "executionPath"
+	// podLogReaderKey carries the reader for pod logs with the identity of the query
+	podLogReaderKey contextKey = "podLogReader"
 )
 
 func WithQueryContext(ctx context.Context, queryID, sessionID, queryName string) context.Context {
@@ -0,0 +1,29 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// MaxExecutionDepth limits how deeply agents and teams may call each other through members and tools.
+const MaxExecutionDepth = 10
+
+// enterExecution records that an agent or team is being entered. It fails when the same resource is already
+// on the path, which means agents and teams call each other in a cycle, or when the path gets too deep.
+func enterExecution(ctx context.Context, kind, namespace, name string) (context.Context, error) {
+	path, _ := ctx.Value(executionPathKey).([]string)
+	node := fmt.Sprintf("%s/%s/%s", kind, namespace, name)
+
+	if slices.Contains(path, node) {
+		return ctx, fmt.Errorf("dependency cycle detected: %s", strings.Join(append(slices.Clone(path), node), " -> "))
+	}
+	if len(path) >= MaxExecutionDepth {
+		return ctx, fmt.Errorf("execution depth limit of %d exceeded: %s", MaxExecutionDepth, strings.Join(append(slices.Clone(path), node), " -> "))
+	}
+
+	return context.WithValue(ctx, executionPathKey, append(slices.Clone(path), node)), nil
+}
@@ -0,0 +1,64 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func TestEnterExecution(t *testing.T) {
+	ctx, err := enterExecution(context.Background(), MemberTypeTeam, "default", "a")
+	require.NoError(t, err)
+	ctx, err = enterExecution(ctx, MemberTypeAgent, "default", "x")
+	require.NoError(t, err)
+
+	// Sibling paths do not affect each other
+	_, err = enterExecution(ctx, MemberTypeTeam, "default", "b")
+	require.NoError(t, err)
+	_, err = enterExecution(ctx, MemberTypeAgent, "default", "y")
+	require.NoError(t, err)
+
+	_, err = enterExecution(ctx, MemberTypeTeam, "default", "a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency cycle detected: team/default/a -> agent/default/x -> team/default/a")
+
+	deep := context.Background()
+	for i := 0; i < MaxExecutionDepth; i++ {
+		deep, err = enterExecution(deep, MemberTypeTeam, "default", fmt.Sprintf("t%d", i))
+		require.NoError(t, err)
+	}
+	_, err = enterExecution(deep, MemberTypeTeam, "default", "one-more")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "execution depth limit")
+}
+
+func TestMakeTeamRejectsNestedTeamCycle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+
+	teamA := &arkv1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "a", Namespace: "default"},
+		Spec:       arkv1alpha1.TeamSpec{Strategy: "sequential", Members: []arkv1alpha1.TeamMember{{Name: "b", Type: "team"}}},
+	}
+	teamB := &arkv1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "b", Namespace: "default"},
+		Spec:       arkv1alpha1.TeamSpec{Strategy: "sequential", Members: []arkv1alpha1.TeamMember{{Name: "a", Type: "team"}}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(teamA, teamB).Build()
+
+	_, err := MakeTeam(context.Background(), fakeClient, teamA, noop.NewProvider(), eventnoop.NewProvider())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dependency cycle detected: team/default/a -> team/default/b -> team/default/a")
+}
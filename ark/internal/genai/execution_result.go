@@ -3,4 +3,8 @@ package genai
 type ExecutionResult struct {
 	Messages    []Message
 	A2AResponse *A2AResponse
+	// TeamState is the final shared state of a team run, set only for teams with state enabled
+	TeamState map[string]any
+	// Vote is the outcome of a team run with the vote strategy, with the final answer of every member
+	Vote *VoteResult
 }
@@ -0,0 +1,573 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// GraphQLExecutor executes GraphQL tools
+type GraphQLExecutor struct {
+	K8sClient     client.Client
+	ToolName      string
+	ToolNamespace string
+}
+
+type graphQLRequest struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+type graphQLResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []graphQLError  `json:"errors"`
+}
+
+type graphQLError struct {
+	Message string `json:"message"`
+	Path    []any  `json:"path,omitempty"`
+}
+
+// Execute implements ToolExecutor interface for GraphQL tools
+func (g *GraphQLExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	var arguments map[string]any
+	if call.Function.Arguments != "" {
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+			return ToolResult{
+				ID:    call.ID,
+				Name:  call.Function.Name,
+				Error: fmt.Sprintf("failed to parse arguments: %v", err),
+			}, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	tool := &arkv1alpha1.Tool{}
+	if err := g.K8sClient.Get(ctx, client.ObjectKey{Name: g.ToolName, Namespace: g.ToolNamespace}, tool); err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to get tool %s: %v", g.ToolName, err),
+		}, fmt.Errorf("failed to get tool %s: %w", g.ToolName, err)
+	}
+
+	spec := tool.Spec.GraphQL
+	if spec == nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: "GraphQL spec is required",
+		}, fmt.Errorf("GraphQL spec is required")
+	}
+
+	log := logf.FromContext(ctx).WithValues("tool", tool.Name, "toolID", call.ID)
+
+	body, err := json.Marshal(graphQLRequest{
+		Query:         spec.Query,
+		OperationName: spec.OperationName,
+		Variables:     graphQLVariables(spec, arguments),
+	})
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to marshal GraphQL request: %v", err),
+		}, fmt.Errorf("failed to marshal GraphQL request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, spec.Endpoint, bytes.NewReader(body))
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to create request: %v", err),
+		}, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/graphql-response+json, application/json")
+
+	headers, err := ResolveHeaders(ctx, g.K8sClient, spec.Headers, tool.Namespace)
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to resolve headers: %v", err),
+		}, fmt.Errorf("failed to resolve headers: %w", err)
+	}
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+
+	tokenSource, err := ResolveAuthTokenSource(ctx, g.K8sClient, spec.Auth, tool.Namespace)
+	if err == nil {
+		err = setBearerToken(req, tokenSource)
+	}
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to authenticate: %v", err),
+		}, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
+	log.Info("making GraphQL request", "endpoint", spec.Endpoint, "operationName", spec.OperationName)
+	httpClient := &http.Client{Timeout: toolTimeout(spec.Timeout)}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to call GraphQL endpoint: %v", err),
+		}, fmt.Errorf("failed to call GraphQL endpoint: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	responseBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to read response: %v", err),
+		}, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	// GraphQL servers report errors in the body, with status 200 or, for the graphql-response media type, 4xx
+	var response graphQLResponse
+	parseErr := json.Unmarshal(responseBody, &response)
+	if parseErr == nil && len(response.Errors) > 0 {
+		message := graphQLErrorMessage(response)
+		log.Info("GraphQL request returned errors", "status", resp.StatusCode, "errors", len(response.Errors))
+		return ToolResult{
+			ID:      call.ID,
+			Name:    call.Function.Name,
+			Content: message,
+			Error:   message,
+		}, nil
+	}
+
+	if resp.StatusCode >= 400 {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("HTTP error %d: %s (URL: %s)", resp.StatusCode, resp.Status, spec.Endpoint),
+		}, fmt.Errorf("HTTP error %d: %s", resp.StatusCode, resp.Status)
+	}
+
+	if parseErr != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("invalid GraphQL response: %v", parseErr),
+		}, fmt.Errorf("invalid GraphQL response: %w", parseErr)
+	}
+
+	log.Info("GraphQL request completed", "status", resp.StatusCode, "responseSize", len(responseBody))
+
+	return ToolResult{
+		ID:      call.ID,
+		Name:    call.Function.Name,
+		Content: string(response.Data),
+	}, nil
+}
+
+// graphQLErrorMessage describes the errors of a response for the model, including any partial data.
+func graphQLErrorMessage(response graphQLResponse) string {
+	messages := make([]string, 0, len(response.Errors))
+	for _, graphQLErr := range response.Errors {
+		message := graphQLErr.Message
+		if len(graphQLErr.Path) > 0 {
+			path := make([]string, len(graphQLErr.Path))
+			for i, segment := range graphQLErr.Path {
+				path[i] = fmt.Sprint(segment)
+			}
+			message = fmt.Sprintf("%s (path: %s)", message, strings.Join(path, "."))
+		}
+		messages = append(messages, message)
+	}
+
+	result := "GraphQL errors: " + strings.Join(messages, "; ")
+	if data := string(response.Data); data != "" && data != "null" {
+		result += "\nPartial data: " + data
+	}
+	return result
+}
+
+// graphQLVariables builds the variables of the request from the tool arguments.
+func graphQLVariables(spec *arkv1alpha1.GraphQLSpec, arguments map[string]any) map[string]any {
+	if len(spec.Variables) == 0 {
+		return arguments
+	}
+
+	variables := make(map[string]any, len(spec.Variables))
+	for _, variable := range spec.Variables {
+		if value, exists := arguments[variable.Argument]; exists {
+			variables[variable.Name] = value
+		}
+	}
+	return variables
+}
+
+// GraphQLType is the type of a variable, such as [String!]!
+type GraphQLType struct {
+	// Name of a named type, empty for lists
+	Name string
+	// OfType is the element type of a list
+	OfType  *GraphQLType
+	NonNull bool
+}
+
+// GraphQLVariableDefinition is a variable defined by a GraphQL operation.
+type GraphQLVariableDefinition struct {
+	Name       string
+	Type       GraphQLType
+	HasDefault bool
+}
+
+// ParseGraphQLVariableDefinitions returns the variables defined by the operation of a GraphQL document. The
+// operation is selected by name, or must be the only operation in the document when operationName is empty.
+func ParseGraphQLVariableDefinitions(document, operationName string) ([]GraphQLVariableDefinition, error) {
+	tokens, err := lexGraphQL(document)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &graphQLParser{tokens: tokens}
+	var operations []string
+	var selected []GraphQLVariableDefinition
+	found := false
+	for !p.done() {
+		token := p.next()
+		switch {
+		case token == "{":
+			// Anonymous query shorthand without variables
+			if err := p.skipBalanced("{", "}"); err != nil {
+				return nil, err
+			}
+			operations = append(operations, "")
+			if operationName == "" {
+				found = true
+				selected = nil
+			}
+		case token == "query" || token == "mutation" || token == "subscription":
+			name := ""
+			if !p.done() && isGraphQLName(p.peek()) {
+				name = p.next()
+			}
+			var definitions []GraphQLVariableDefinition
+			if p.peek() == "(" {
+				p.next()
+				if definitions, err = p.variableDefinitions(); err != nil {
+					return nil, fmt.Errorf("operation %q: %w", name, err)
+				}
+			}
+			if err := p.skipUntilSelectionSet(); err != nil {
+				return nil, fmt.Errorf("operation %q: %w", name, err)
+			}
+			operations = append(operations, name)
+			if operationName == "" || operationName == name {
+				found = true
+				selected = definitions
+			}
+		case token == "fragment":
+			if err := p.skipUntilSelectionSet(); err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("unexpected %q at the top level of the document", token)
+		}
+	}
+
+	switch {
+	case len(operations) == 0:
+		return nil, fmt.Errorf("document does not define an operation")
+	case operationName == "" && len(operations) > 1:
+		return nil, fmt.Errorf("document defines %d operations, operationName must select one", len(operations))
+	case !found:
+		return nil, fmt.Errorf("document does not define operation %q", operationName)
+	}
+	return selected, nil
+}
+
+// lexGraphQL splits a GraphQL document into tokens. Whitespace, commas and comments are dropped.
+func lexGraphQL(document string) ([]string, error) {
+	var tokens []string
+	for i := 0; i < len(document); {
+		c := document[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == ',':
+			i++
+		case strings.HasPrefix(document[i:], "\uFEFF"):
+			i += len("\uFEFF")
+		case c == '#':
+			for i < len(document) && document[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(document[i:], `"""`):
+			end := strings.Index(document[i+3:], `"""`)
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated block string")
+			}
+			tokens = append(tokens, document[i:i+end+6])
+			i += end + 6
+		case c == '"':
+			j := i + 1
+			for j < len(document) && document[j] != '"' && document[j] != '\n' {
+				if document[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			if j >= len(document) || document[j] != '"' {
+				return nil, fmt.Errorf("unterminated string")
+			}
+			tokens = append(tokens, document[i:j+1])
+			i = j + 1
+		case strings.HasPrefix(document[i:], "..."):
+			tokens = append(tokens, "...")
+			i += 3
+		case strings.IndexByte("!$&():=@[]{|}", c) >= 0:
+			tokens = append(tokens, string(c))
+			i++
+		case isGraphQLNameChar(c) || c == '-':
+			// Names and numbers
+			j := i + 1
+			for j < len(document) && (isGraphQLNameChar(document[j]) || strings.IndexByte(".+-", document[j]) >= 0) {
+				j++
+			}
+			tokens = append(tokens, document[i:j])
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected character %q", c)
+		}
+	}
+	return tokens, nil
+}
+
+func isGraphQLNameChar(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+func isGraphQLName(token string) bool {
+	if token == "" || (token[0] >= '0' && token[0] <= '9') {
+		return false
+	}
+	for i := 0; i < len(token); i++ {
+		if !isGraphQLNameChar(token[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+type graphQLParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *graphQLParser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+func (p *graphQLParser) peek() string {
+	if p.done() {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *graphQLParser) next() string {
+	token := p.peek()
+	p.pos++
+	return token
+}
+
+func (p *graphQLParser) expect(expected string) error {
+	if token := p.next(); token != expected {
+		return fmt.Errorf("expected %q but found %q", expected, token)
+	}
+	return nil
+}
+
+// skipBalanced skips tokens up to the closer matching an opener that was already read.
+func (p *graphQLParser) skipBalanced(opener, closer string) error {
+	depth := 1
+	for depth > 0 {
+		if p.done() {
+			return fmt.Errorf("missing %q", closer)
+		}
+		switch p.next() {
+		case opener:
+			depth++
+		case closer:
+			depth--
+		}
+	}
+	return nil
+}
+
+// skipUntilSelectionSet skips directives and type conditions up to and including the selection set.
+func (p *graphQLParser) skipUntilSelectionSet() error {
+	for !p.done() {
+		switch p.next() {
+		case "(":
+			if err := p.skipBalanced("(", ")"); err != nil {
+				return err
+			}
+		case "{":
+			return p.skipBalanced("{", "}")
+		}
+	}
+	return fmt.Errorf("missing selection set")
+}
+
+func (p *graphQLParser) variableDefinitions() ([]GraphQLVariableDefinition, error) {
+	var definitions []GraphQLVariableDefinition
+	for p.peek() != ")" {
+		if err := p.expect("$"); err != nil {
+			return nil, err
+		}
+		name := p.next()
+		if !isGraphQLName(name) {
+			return nil, fmt.Errorf("invalid variable name %q", name)
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, fmt.Errorf("variable $%s: %w", name, err)
+		}
+		variableType, err := p.variableType()
+		if err != nil {
+			return nil, fmt.Errorf("variable $%s: %w", name, err)
+		}
+		definition := GraphQLVariableDefinition{Name: name, Type: variableType}
+
+		if p.peek() == "=" {
+			p.next()
+			definition.HasDefault = true
+			if err := p.skipValue(); err != nil {
+				return nil, fmt.Errorf("variable $%s: %w", name, err)
+			}
+		}
+		for p.peek() == "@" {
+			p.next()
+			p.next()
+			if p.peek() == "(" {
+				p.next()
+				if err := p.skipBalanced("(", ")"); err != nil {
+					return nil, err
+				}
+			}
+		}
+		definitions = append(definitions, definition)
+	}
+	p.next()
+	return definitions, nil
+}
+
+func (p *graphQLParser) variableType() (GraphQLType, error) {
+	var variableType GraphQLType
+	token := p.next()
+	switch {
+	case token == "[":
+		ofType, err := p.variableType()
+		if err != nil {
+			return variableType, err
+		}
+		if err := p.expect("]"); err != nil {
+			return variableType, err
+		}
+		variableType.OfType = &ofType
+	case isGraphQLName(token):
+		variableType.Name = token
+	default:
+		return variableType, fmt.Errorf("invalid type %q", token)
+	}
+	if p.peek() == "!" {
+		p.next()
+		variableType.NonNull = true
+	}
+	return variableType, nil
+}
+
+func (p *graphQLParser) skipValue() error {
+	switch token := p.next(); token {
+	case "[":
+		return p.skipBalanced("[", "]")
+	case "{":
+		return p.skipBalanced("{", "}")
+	case "", ")":
+		return fmt.Errorf("missing default value")
+	default:
+		return nil
+	}
+}
+
+// graphQLInputSchema generates the input schema of a GraphQL tool from the variables of its operation.
+func graphQLInputSchema(spec *arkv1alpha1.GraphQLSpec) (map[string]any, error) {
+	definitions, err := ParseGraphQLVariableDefinitions(spec.Query, spec.OperationName)
+	if err != nil {
+		return nil, err
+	}
+
+	// Variables are exposed under the name of the argument they are mapped to
+	arguments := map[string]string{}
+	for _, variable := range spec.Variables {
+		arguments[variable.Name] = variable.Argument
+	}
+
+	properties := map[string]any{}
+	required := []string{}
+	for _, definition := range definitions {
+		argument := definition.Name
+		if len(spec.Variables) > 0 {
+			mapped, exists := arguments[definition.Name]
+			if !exists {
+				continue
+			}
+			argument = mapped
+		}
+		properties[argument] = graphQLTypeSchema(definition.Type)
+		if definition.Type.NonNull && !definition.HasDefault {
+			required = append(required, argument)
+		}
+	}
+
+	schema := map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return schema, nil
+}
+
+func graphQLTypeSchema(graphQLType GraphQLType) map[string]any {
+	if graphQLType.OfType != nil {
+		return map[string]any{"type": "array", "items": graphQLTypeSchema(*graphQLType.OfType)}
+	}
+	switch graphQLType.Name {
+	case "String", "ID":
+		return map[string]any{"type": "string"}
+	case "Int":
+		return map[string]any{"type": "integer"}
+	case "Float":
+		return map[string]any{"type": "number"}
+	case "Boolean":
+		return map[string]any{"type": "boolean"}
+	default:
+		// Enums, input objects and custom scalars need the server schema to be described precisely
+		return map[string]any{"description": fmt.Sprintf("GraphQL type %s", graphQLType.Name)}
+	}
+}
@@ -0,0 +1,172 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const repositoryQuery = `
+# Look up a repository
+query Repository($owner: String!, $name: String!, $first: Int = 10, $labels: [String!], $order: IssueOrder @deprecated(reason: "unused")) {
+  repository(owner: $owner, name: $name) {
+    description
+    issues(first: $first, labels: $labels, orderBy: $order) { nodes { title body } }
+  }
+}
+
+fragment Stars on Repository { stargazerCount }
+`
+
+func TestParseGraphQLVariableDefinitions(t *testing.T) {
+	definitions, err := ParseGraphQLVariableDefinitions(repositoryQuery, "")
+	require.NoError(t, err)
+	require.Len(t, definitions, 5)
+
+	assert.Equal(t, GraphQLVariableDefinition{Name: "owner", Type: GraphQLType{Name: "String", NonNull: true}}, definitions[0])
+	assert.Equal(t, GraphQLVariableDefinition{Name: "first", Type: GraphQLType{Name: "Int"}, HasDefault: true}, definitions[2])
+	assert.Equal(t, GraphQLType{OfType: &GraphQLType{Name: "String", NonNull: true}}, definitions[3].Type)
+	assert.Equal(t, "IssueOrder", definitions[4].Type.Name)
+
+	t.Run("selects the operation by name", func(t *testing.T) {
+		document := `query A($a: Int) { a(v: $a) } mutation B($b: ID!) { b(v: $b) { id } }`
+		definitions, err := ParseGraphQLVariableDefinitions(document, "B")
+		require.NoError(t, err)
+		require.Len(t, definitions, 1)
+		assert.Equal(t, "b", definitions[0].Name)
+
+		_, err = ParseGraphQLVariableDefinitions(document, "")
+		assert.ErrorContains(t, err, "operationName must select one")
+		_, err = ParseGraphQLVariableDefinitions(document, "C")
+		assert.ErrorContains(t, err, `does not define operation "C"`)
+	})
+
+	t.Run("rejects invalid documents", func(t *testing.T) {
+		for _, document := range []string{
+			``,
+			`query ($a Int) { a }`,
+			`query ($a: Int) { a`,
+			`query { a(text: "unterminated) }`,
+		} {
+			_, err := ParseGraphQLVariableDefinitions(document, "")
+			assert.Error(t, err, document)
+		}
+	})
+}
+
+func TestGraphQLInputSchema(t *testing.T) {
+	spec := &arkv1alpha1.GraphQLSpec{Query: repositoryQuery}
+	schema, err := graphQLInputSchema(spec)
+	require.NoError(t, err)
+
+	actual, err := json.Marshal(schema)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"properties": {
+			"owner": {"type": "string"},
+			"name": {"type": "string"},
+			"first": {"type": "integer"},
+			"labels": {"type": "array", "items": {"type": "string"}},
+			"order": {"description": "GraphQL type IssueOrder"}
+		},
+		"required": ["owner", "name"]
+	}`, string(actual))
+
+	t.Run("exposes mapped variables under the argument name", func(t *testing.T) {
+		spec := &arkv1alpha1.GraphQLSpec{
+			Query:     repositoryQuery,
+			Variables: []arkv1alpha1.GraphQLVariable{{Name: "owner", Argument: "organization"}},
+		}
+		schema, err := graphQLInputSchema(spec)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{"organization": map[string]any{"type": "string"}}, schema["properties"])
+		assert.Equal(t, []string{"organization"}, schema["required"])
+	})
+}
+
+func newGraphQLTestTool(endpoint string, variables ...arkv1alpha1.GraphQLVariable) *arkv1alpha1.Tool {
+	return &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "repository", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type: ToolTypeGraphQL,
+			GraphQL: &arkv1alpha1.GraphQLSpec{
+				Endpoint:      endpoint,
+				Query:         repositoryQuery,
+				OperationName: "Repository",
+				Variables:     variables,
+				Headers:       []arkv1alpha1.Header{{Name: "X-Api-Key", Value: arkv1alpha1.HeaderValue{Value: "key"}}},
+			},
+		},
+	}
+}
+
+func TestGraphQLExecutor(t *testing.T) {
+	ctx := context.Background()
+	call := ToolCall{
+		ID:       "call-1",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: "repository", Arguments: `{"organization":"acme","name":"ark"}`},
+	}
+
+	t.Run("posts the operation and returns the data", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "key", r.Header.Get("X-Api-Key"))
+
+			var request graphQLRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			assert.Equal(t, "Repository", request.OperationName)
+			assert.Equal(t, map[string]any{"owner": "acme"}, request.Variables)
+
+			_, _ = w.Write([]byte(`{"data":{"repository":{"description":"Agents"}}}`))
+		}))
+		t.Cleanup(server.Close)
+
+		tool := newGraphQLTestTool(server.URL, arkv1alpha1.GraphQLVariable{Name: "owner", Argument: "organization"})
+		executor := &GraphQLExecutor{K8sClient: setupTestClient([]client.Object{tool}), ToolName: tool.Name, ToolNamespace: "default"}
+
+		result, err := executor.Execute(ctx, call)
+		require.NoError(t, err)
+		assert.Empty(t, result.Error)
+		assert.JSONEq(t, `{"repository":{"description":"Agents"}}`, result.Content)
+	})
+
+	t.Run("returns errors of a successful response to the model", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write([]byte(`{"data":{"repository":null},"errors":[{"message":"Could not resolve to a Repository","path":["repository"]}]}`))
+		}))
+		t.Cleanup(server.Close)
+
+		tool := newGraphQLTestTool(server.URL)
+		executor := &GraphQLExecutor{K8sClient: setupTestClient([]client.Object{tool}), ToolName: tool.Name, ToolNamespace: "default"}
+
+		result, err := executor.Execute(ctx, call)
+		require.NoError(t, err)
+		assert.Equal(t, "GraphQL errors: Could not resolve to a Repository (path: repository)\nPartial data: {\"repository\":null}", result.Error)
+		assert.Equal(t, result.Error, result.Content)
+	})
+
+	t.Run("fails on HTTP errors without a GraphQL response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "bad gateway", http.StatusBadGateway)
+		}))
+		t.Cleanup(server.Close)
+
+		tool := newGraphQLTestTool(server.URL)
+		executor := &GraphQLExecutor{K8sClient: setupTestClient([]client.Object{tool}), ToolName: tool.Name, ToolNamespace: "default"}
+
+		_, err := executor.Execute(ctx, call)
+		assert.ErrorContains(t, err, "HTTP error 502")
+	})
+}
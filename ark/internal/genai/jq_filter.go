@@ -6,6 +6,8 @@ import (
 	"fmt"
 
 	"github.com/itchyny/gojq"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 )
 
@@ -19,15 +21,23 @@ func (f *FilteredToolExecutor) Execute(ctx context.Context, call ToolCall) (Tool
 	if err != nil {
 		return result, err
 	}
+	// Error messages are meant for the model as they are
+	if result.Error != "" {
+		return result, nil
+	}
 
-	for _, fn := range f.Functions {
+	for i, fn := range f.Functions {
 		filteredContent, err := f.applyFilter(result.Content, fn)
 		if err != nil {
+			// The model is told why the output is missing instead of failing the query
+			message := fmt.Sprintf("The output of tool %s could not be processed by function %s (functions[%d]): %v", call.Function.Name, fn.Name, i, err)
+			logf.FromContext(ctx).Info("tool output function failed", "tool", call.Function.Name, "function", fn.Name, "error", err.Error())
 			return ToolResult{
-				ID:    call.ID,
-				Name:  call.Function.Name,
-				Error: fmt.Sprintf("filter error: %v", err),
-			}, fmt.Errorf("filter error: %w", err)
+				ID:      call.ID,
+				Name:    call.Function.Name,
+				Content: message,
+				Error:   message,
+			}, nil
 		}
 		result.Content = filteredContent
 	}
@@ -37,10 +47,20 @@ func (f *FilteredToolExecutor) Execute(ctx context.Context, call ToolCall) (Tool
 
 func (f *FilteredToolExecutor) applyFilter(content string, fn arkv1alpha1.ToolFunction) (string, error) {
 	switch fn.Name {
-	case "jq":
+	case ToolFunctionJQ:
 		return f.applyJQFilter(content, fn.Value)
+	case ToolFunctionTruncate:
+		return applyTruncate(content, fn.Value)
+	case ToolFunctionTemplate:
+		return applyTemplate(content, fn.Value)
+	case ToolFunctionJSONPath:
+		return applyJSONPath(content, fn.Value)
+	case ToolFunctionHTMLToText:
+		return applyHTMLToText(content)
+	case ToolFunctionRedact:
+		return applyRedact(content, fn.Value)
 	default:
-		return content, nil
+		return "", ValidateToolFunction(fn)
 	}
 }
 
@@ -56,7 +76,7 @@ func (f *FilteredToolExecutor) applyJQFilter(content, jqExpr string) (string, er
 
 	var data interface{}
 	if err := json.Unmarshal([]byte(content), &data); err != nil {
-		return content, nil
+		return "", fmt.Errorf("jq requires JSON output, got: %s", contentPreview(content))
 	}
 
 	iter := query.Run(data)
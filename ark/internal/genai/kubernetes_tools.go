@@ -0,0 +1,436 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	defaultK8sListLimit = 50
+	maxK8sListLimit     = 200
+	defaultK8sLogLines  = 100
+	maxK8sLogLines      = 1000
+	maxK8sLogBytes      = 256 * 1024
+)
+
+// PodLogReader reads container logs, which are not available through the controller-runtime client.
+type PodLogReader interface {
+	ReadPodLogs(ctx context.Context, namespace, name string, options *corev1.PodLogOptions) (string, error)
+}
+
+type clientsetPodLogReader struct {
+	config *rest.Config
+}
+
+// NewPodLogReader returns a reader that reads logs with the identity of the configuration.
+func NewPodLogReader(config *rest.Config) PodLogReader {
+	return &clientsetPodLogReader{config: config}
+}
+
+func (r *clientsetPodLogReader) ReadPodLogs(ctx context.Context, namespace, name string, options *corev1.PodLogOptions) (string, error) {
+	clientset, err := kubernetes.NewForConfig(r.config)
+	if err != nil {
+		return "", err
+	}
+	logs, err := clientset.CoreV1().Pods(namespace).GetLogs(name, options).DoRaw(ctx)
+	return string(logs), err
+}
+
+// WithPodLogReader makes pod logs available to the k8s_logs tool.
+func WithPodLogReader(ctx context.Context, reader PodLogReader) context.Context {
+	return context.WithValue(ctx, podLogReaderKey, reader)
+}
+
+func getPodLogReader(ctx context.Context) PodLogReader {
+	reader, _ := ctx.Value(podLogReaderKey).(PodLogReader)
+	return reader
+}
+
+// IsKubernetesBuiltinTool reports whether the built-in tool reads Kubernetes resources.
+func IsKubernetesBuiltinTool(name string) bool {
+	switch name {
+	case BuiltinToolK8sGet, BuiltinToolK8sList, BuiltinToolK8sDescribeEvents, BuiltinToolK8sLogs:
+		return true
+	}
+	return false
+}
+
+// KubernetesToolExecutor reads Kubernetes resources for the Kubernetes built-in tools. The client is the one of
+// the query, so reads, including those of cluster-scoped kinds, are subject to the RBAC of the query's service
+// account. Queries without a service account would read with the identity of the controller and are refused.
+type KubernetesToolExecutor struct {
+	Operation string
+	K8sClient client.Client
+	Access    *arkv1alpha1.KubernetesToolAccess
+	// Namespace of the tool, used when the access does not list namespaces
+	Namespace string
+}
+
+func (k *KubernetesToolExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	arguments, _, err := parseToolArguments(call.Function.Arguments, false)
+	if err != nil {
+		return invalidArgumentsResult(call, fmt.Sprintf("arguments are not valid JSON: %v", err)), nil
+	}
+	if err := requireQueryServiceAccount(ctx); err != nil {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: err.Error(), Error: err.Error()}, nil
+	}
+
+	var content string
+	switch k.Operation {
+	case BuiltinToolK8sGet:
+		content, err = k.get(ctx, arguments)
+	case BuiltinToolK8sList:
+		content, err = k.list(ctx, arguments)
+	case BuiltinToolK8sDescribeEvents:
+		content, err = k.describeEvents(ctx, arguments)
+	case BuiltinToolK8sLogs:
+		content, err = k.logs(ctx, arguments)
+	default:
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("unsupported kubernetes tool %s", k.Operation),
+		}, fmt.Errorf("unsupported kubernetes tool %s", k.Operation)
+	}
+
+	// Denied, missing and forbidden resources are reported to the model, which can try something else
+	if err != nil {
+		return ToolResult{
+			ID:      call.ID,
+			Name:    call.Function.Name,
+			Content: err.Error(),
+			Error:   err.Error(),
+		}, nil
+	}
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: content}, nil
+}
+
+// requireQueryServiceAccount ensures the query impersonates a service account rather than the controller.
+func requireQueryServiceAccount(ctx context.Context) error {
+	query, ok := ctx.Value(QueryContextKey).(*arkv1alpha1.Query)
+	if !ok || query == nil || query.Spec.ServiceAccount == "" {
+		return fmt.Errorf("kubernetes tools require the query to set spec.serviceAccount, so that reads are subject to its RBAC")
+	}
+	return nil
+}
+
+func (k *KubernetesToolExecutor) get(ctx context.Context, arguments map[string]any) (string, error) {
+	gvk, err := k.allowedKind(stringArgument(arguments, "kind"))
+	if err != nil {
+		return "", err
+	}
+	name := stringArgument(arguments, "name")
+	if name == "" {
+		return "", fmt.Errorf("name is required")
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	key, err := k.objectKey(obj, name, stringArgument(arguments, "namespace"))
+	if err != nil {
+		return "", err
+	}
+	if err := k.K8sClient.Get(ctx, key, obj); err != nil {
+		return "", err
+	}
+
+	trimObject(obj.Object)
+	return marshalK8sOutput(obj.Object)
+}
+
+func (k *KubernetesToolExecutor) list(ctx context.Context, arguments map[string]any) (string, error) {
+	gvk, err := k.allowedKind(stringArgument(arguments, "kind"))
+	if err != nil {
+		return "", err
+	}
+
+	limit := defaultK8sListLimit
+	if value, ok := arguments["limit"].(float64); ok && value > 0 {
+		limit = min(int(value), maxK8sListLimit)
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk.GroupVersion().WithKind(gvk.Kind + "List"))
+	options := []client.ListOption{client.Limit(int64(limit))}
+
+	item := &unstructured.Unstructured{}
+	item.SetGroupVersionKind(gvk)
+	key, err := k.objectKey(item, "", stringArgument(arguments, "namespace"))
+	if err != nil {
+		return "", err
+	}
+	if key.Namespace != "" {
+		options = append(options, client.InNamespace(key.Namespace))
+	}
+
+	if selector := stringArgument(arguments, "labelSelector"); selector != "" {
+		parsed, err := labels.Parse(selector)
+		if err != nil {
+			return "", fmt.Errorf("invalid labelSelector: %w", err)
+		}
+		options = append(options, client.MatchingLabelsSelector{Selector: parsed})
+	}
+
+	if err := k.K8sClient.List(ctx, list, options...); err != nil {
+		return "", err
+	}
+
+	items := make([]any, 0, len(list.Items))
+	for i := range list.Items {
+		if i == limit {
+			break
+		}
+		trimObject(list.Items[i].Object)
+		items = append(items, list.Items[i].Object)
+	}
+	output := map[string]any{"items": items}
+	if list.GetContinue() != "" || len(list.Items) > limit {
+		output["truncated"] = fmt.Sprintf("only the first %d items are shown, use a labelSelector to narrow the list", limit)
+	}
+	return marshalK8sOutput(output)
+}
+
+func (k *KubernetesToolExecutor) describeEvents(ctx context.Context, arguments map[string]any) (string, error) {
+	gvk, err := k.allowedKind(stringArgument(arguments, "kind"))
+	if err != nil {
+		return "", err
+	}
+	name := stringArgument(arguments, "name")
+	if name == "" {
+		return "", fmt.Errorf("name is required")
+	}
+
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(gvk)
+	key, err := k.objectKey(obj, name, stringArgument(arguments, "namespace"))
+	if err != nil {
+		return "", err
+	}
+	// Events of cluster-scoped resources are recorded in the default namespace
+	eventNamespace := key.Namespace
+	if eventNamespace == "" {
+		eventNamespace = corev1.NamespaceDefault
+	}
+
+	// Unstructured lists are read from the API server, typed lists would start an informer in the manager cache
+	events := &unstructured.UnstructuredList{}
+	events.SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("EventList"))
+	if err := k.K8sClient.List(ctx, events, client.InNamespace(eventNamespace), client.MatchingFields{
+		"involvedObject.kind": gvk.Kind,
+		"involvedObject.name": name,
+	}); err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, len(events.Items))
+	for _, event := range events.Items {
+		var e corev1.Event
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(event.Object, &e); err != nil {
+			continue
+		}
+		timestamp := e.LastTimestamp.Time
+		if timestamp.IsZero() {
+			timestamp = e.EventTime.Time
+		}
+		line := fmt.Sprintf("%s %s %s", timestamp.UTC().Format("2006-01-02T15:04:05Z"), e.Type, e.Reason)
+		if e.Count > 1 {
+			line += fmt.Sprintf(" (x%d)", e.Count)
+		}
+		lines = append(lines, line+": "+e.Message)
+	}
+	if len(lines) == 0 {
+		return fmt.Sprintf("No events found for %s %s", gvk.Kind, name), nil
+	}
+	sort.Strings(lines)
+	return strings.Join(lines, "\n"), nil
+}
+
+func (k *KubernetesToolExecutor) logs(ctx context.Context, arguments map[string]any) (string, error) {
+	gvk, err := k.allowedKind("Pod")
+	if err != nil {
+		return "", err
+	}
+	name := stringArgument(arguments, "name")
+	if name == "" {
+		return "", fmt.Errorf("name is required")
+	}
+
+	pod := &unstructured.Unstructured{}
+	pod.SetGroupVersionKind(gvk)
+	key, err := k.objectKey(pod, name, stringArgument(arguments, "namespace"))
+	if err != nil {
+		return "", err
+	}
+
+	reader := getPodLogReader(ctx)
+	if reader == nil {
+		return "", fmt.Errorf("pod logs are not available outside of queries")
+	}
+
+	tailLines := int64(defaultK8sLogLines)
+	if value, ok := arguments["tailLines"].(float64); ok && value > 0 {
+		tailLines = min(int64(value), maxK8sLogLines)
+	}
+	limitBytes := int64(maxK8sLogBytes)
+	previous, _ := arguments["previous"].(bool)
+
+	logs, err := reader.ReadPodLogs(ctx, key.Namespace, key.Name, &corev1.PodLogOptions{
+		Container:  stringArgument(arguments, "container"),
+		TailLines:  &tailLines,
+		LimitBytes: &limitBytes,
+		Previous:   previous,
+	})
+	if err != nil {
+		return "", err
+	}
+	if logs == "" {
+		return fmt.Sprintf("No logs found for pod %s", name), nil
+	}
+	return logs, nil
+}
+
+// allowedKind returns the allow-listed resource kind with the given name.
+func (k *KubernetesToolExecutor) allowedKind(kind string) (schema.GroupVersionKind, error) {
+	if kind == "" {
+		return schema.GroupVersionKind{}, fmt.Errorf("kind is required")
+	}
+	allowed := make([]string, 0, len(k.Access.Resources))
+	for _, resource := range k.Access.Resources {
+		if strings.EqualFold(resource.Kind, kind) {
+			return schema.FromAPIVersionAndKind(kubernetesAPIVersion(resource), resource.Kind), nil
+		}
+		allowed = append(allowed, resource.Kind)
+	}
+	return schema.GroupVersionKind{}, fmt.Errorf("kind %s is not allowed, allowed kinds are: %s", kind, strings.Join(allowed, ", "))
+}
+
+// objectKey checks the namespace against the allow-list. Cluster-scoped resources have no namespace.
+func (k *KubernetesToolExecutor) objectKey(obj *unstructured.Unstructured, name, namespace string) (client.ObjectKey, error) {
+	namespaced, err := k.K8sClient.IsObjectNamespaced(obj)
+	if err != nil {
+		return client.ObjectKey{}, fmt.Errorf("unknown kind %s: %w", obj.GetKind(), err)
+	}
+	if !namespaced {
+		return client.ObjectKey{Name: name}, nil
+	}
+
+	allowed := k.allowedNamespaces()
+	if namespace == "" {
+		namespace = allowed[0]
+	}
+	for _, allowedNamespace := range allowed {
+		if namespace == allowedNamespace {
+			return client.ObjectKey{Namespace: namespace, Name: name}, nil
+		}
+	}
+	return client.ObjectKey{}, fmt.Errorf("namespace %s is not allowed, allowed namespaces are: %s", namespace, strings.Join(allowed, ", "))
+}
+
+func (k *KubernetesToolExecutor) allowedNamespaces() []string {
+	if len(k.Access.Namespaces) > 0 {
+		return k.Access.Namespaces
+	}
+	return []string{k.Namespace}
+}
+
+func kubernetesAPIVersion(resource arkv1alpha1.KubernetesResourceKind) string {
+	if resource.APIVersion == "" {
+		return "v1"
+	}
+	return resource.APIVersion
+}
+
+func stringArgument(arguments map[string]any, name string) string {
+	value, _ := arguments[name].(string)
+	return value
+}
+
+// trimObject removes fields that are noise to the model, and the values of secrets.
+func trimObject(obj map[string]any) {
+	unstructured.RemoveNestedField(obj, "metadata", "managedFields")
+	unstructured.RemoveNestedField(obj, "metadata", "annotations", corev1.LastAppliedConfigAnnotation)
+	if annotations, found, _ := unstructured.NestedMap(obj, "metadata", "annotations"); found && len(annotations) == 0 {
+		unstructured.RemoveNestedField(obj, "metadata", "annotations")
+	}
+
+	if obj["kind"] == "Secret" && obj["apiVersion"] == "v1" {
+		for _, field := range []string{"data", "stringData"} {
+			if values, ok := obj[field].(map[string]any); ok {
+				for key := range values {
+					values[key] = "<redacted>"
+				}
+			}
+		}
+	}
+}
+
+func marshalK8sOutput(output any) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(output); err != nil {
+		return "", fmt.Errorf("failed to marshal result: %w", err)
+	}
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// kubernetesToolInputSchema describes the arguments of a Kubernetes built-in tool, with the allowed kinds and
+// namespaces as enums.
+func kubernetesToolInputSchema(name string, access *arkv1alpha1.KubernetesToolAccess) map[string]any {
+	kinds := make([]string, 0, len(access.Resources))
+	for _, resource := range access.Resources {
+		kinds = append(kinds, resource.Kind)
+	}
+	properties := map[string]any{
+		"namespace": map[string]any{"type": "string", "description": "Namespace of the resource"},
+	}
+	if len(access.Namespaces) > 0 {
+		properties["namespace"].(map[string]any)["enum"] = access.Namespaces
+	}
+	kindProperty := map[string]any{"type": "string", "description": "Kind of the resource", "enum": kinds}
+
+	var required []string
+	switch name {
+	case BuiltinToolK8sGet, BuiltinToolK8sDescribeEvents:
+		properties["kind"] = kindProperty
+		properties["name"] = map[string]any{"type": "string", "description": "Name of the resource"}
+		required = []string{"kind", "name"}
+	case BuiltinToolK8sList:
+		properties["kind"] = kindProperty
+		properties["labelSelector"] = map[string]any{"type": "string", "description": "Label selector, for example app=web,tier!=cache"}
+		properties["limit"] = map[string]any{"type": "integer", "minimum": 1, "maximum": maxK8sListLimit, "description": fmt.Sprintf("Maximum number of items, defaults to %d", defaultK8sListLimit)}
+		required = []string{"kind"}
+	case BuiltinToolK8sLogs:
+		properties["name"] = map[string]any{"type": "string", "description": "Name of the pod"}
+		properties["container"] = map[string]any{"type": "string", "description": "Container of the pod, required when the pod has several"}
+		properties["tailLines"] = map[string]any{"type": "integer", "minimum": 1, "maximum": maxK8sLogLines, "description": fmt.Sprintf("Number of lines from the end of the log, defaults to %d", defaultK8sLogLines)}
+		properties["previous"] = map[string]any{"type": "boolean", "description": "Return the logs of the previous, terminated container"}
+		required = []string{"name"}
+	}
+	return map[string]any{"type": "object", "properties": properties, "required": required}
+}
+
+// kubernetesToolDescriptions are used when a Kubernetes built-in tool has no description.
+var kubernetesToolDescriptions = map[string]string{
+	BuiltinToolK8sGet:            "Get a Kubernetes resource by kind and name",
+	BuiltinToolK8sList:           "List Kubernetes resources of a kind, optionally filtered by labels",
+	BuiltinToolK8sDescribeEvents: "List the events of a Kubernetes resource, such as scheduling failures and restarts",
+	BuiltinToolK8sLogs:           "Read the most recent log lines of a pod container",
+}
@@ -0,0 +1,201 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// fakePodLogReader returns the options it was called with.
+type fakePodLogReader struct {
+	options *corev1.PodLogOptions
+}
+
+func (f *fakePodLogReader) ReadPodLogs(_ context.Context, namespace, name string, options *corev1.PodLogOptions) (string, error) {
+	f.options = options
+	return "log of " + namespace + "/" + name, nil
+}
+
+func newKubernetesTestClient(objects ...client.Object) client.Client {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	mapper := meta.NewDefaultRESTMapper([]schema.GroupVersion{corev1.SchemeGroupVersion})
+	for _, kind := range []string{"Pod", "Secret", "Event"} {
+		mapper.Add(corev1.SchemeGroupVersion.WithKind(kind), meta.RESTScopeNamespace)
+	}
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithRESTMapper(mapper).
+		WithObjects(objects...).
+		WithIndex(&corev1.Event{}, "involvedObject.name", involvedObjectIndex("name")).
+		WithIndex(&corev1.Event{}, "involvedObject.kind", involvedObjectIndex("kind")).
+		Build()
+}
+
+// involvedObjectIndex emulates the event field selectors of the API server.
+func involvedObjectIndex(field string) client.IndexerFunc {
+	return func(obj client.Object) []string {
+		value, _, _ := unstructured.NestedString(obj.(*unstructured.Unstructured).Object, "involvedObject", field)
+		return []string{value}
+	}
+}
+
+func executeKubernetesTool(t *testing.T, ctx context.Context, executor *KubernetesToolExecutor, arguments string) ToolResult {
+	t.Helper()
+	result, err := executor.Execute(ctx, ToolCall{
+		ID:       "call-1",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: executor.Operation, Arguments: arguments},
+	})
+	require.NoError(t, err)
+	return result
+}
+
+func TestKubernetesToolExecutor(t *testing.T) {
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{Name: "query", Namespace: "default"},
+		Spec:       arkv1alpha1.QuerySpec{ServiceAccount: "reader"},
+	}
+	ctx := context.WithValue(context.Background(), QueryContextKey, query)
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:          "web-1",
+			Namespace:     "apps",
+			Labels:        map[string]string{"app": "web"},
+			Annotations:   map[string]string{corev1.LastAppliedConfigAnnotation: "{}"},
+			ManagedFields: []metav1.ManagedFieldsEntry{{Manager: "kubectl", Operation: metav1.ManagedFieldsOperationUpdate, APIVersion: "v1", FieldsType: "FieldsV1", FieldsV1: &metav1.FieldsV1{Raw: []byte(`{}`)}}},
+		},
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db", Namespace: "apps"},
+		Data:       map[string][]byte{"password": []byte("s3cret")},
+	}
+	otherPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "worker-1", Namespace: "apps", Labels: map[string]string{"app": "worker"}}}
+	event := &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: "web-1.1", Namespace: "apps"},
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web-1", Namespace: "apps"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "Back-off restarting failed container",
+		Count:          3,
+	}
+	k8sClient := newKubernetesTestClient(pod, otherPod, secret, event)
+	access := &arkv1alpha1.KubernetesToolAccess{
+		Resources:  []arkv1alpha1.KubernetesResourceKind{{Kind: "Pod"}, {APIVersion: "v1", Kind: "Secret"}},
+		Namespaces: []string{"apps"},
+	}
+	newExecutor := func(operation string) *KubernetesToolExecutor {
+		return &KubernetesToolExecutor{Operation: operation, K8sClient: k8sClient, Access: access, Namespace: "default"}
+	}
+
+	t.Run("refuses queries without a service account", func(t *testing.T) {
+		expected := "kubernetes tools require the query to set spec.serviceAccount, so that reads are subject to its RBAC"
+		result := executeKubernetesTool(t, context.Background(), newExecutor(BuiltinToolK8sGet), `{"kind":"Pod","name":"web-1"}`)
+		assert.Equal(t, expected, result.Error)
+
+		anonymous := &arkv1alpha1.Query{ObjectMeta: metav1.ObjectMeta{Name: "query", Namespace: "default"}}
+		result = executeKubernetesTool(t, context.WithValue(context.Background(), QueryContextKey, anonymous), newExecutor(BuiltinToolK8sList), `{"kind":"Pod"}`)
+		assert.Equal(t, expected, result.Error)
+	})
+
+	t.Run("gets trimmed resources", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sGet), `{"kind":"pod","name":"web-1"}`)
+		require.Empty(t, result.Error)
+
+		var obj map[string]any
+		require.NoError(t, json.Unmarshal([]byte(result.Content), &obj))
+		metadata := obj["metadata"].(map[string]any)
+		assert.Equal(t, "web-1", metadata["name"])
+		assert.NotContains(t, metadata, "managedFields")
+		assert.NotContains(t, metadata, "annotations")
+	})
+
+	t.Run("redacts secret values", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sGet), `{"kind":"Secret","name":"db"}`)
+		assert.Contains(t, result.Content, `"password":"<redacted>"`)
+		assert.NotContains(t, result.Content, "czNjcmV0")
+	})
+
+	t.Run("lists resources by label", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sList), `{"kind":"Pod","labelSelector":"app=web"}`)
+		require.Empty(t, result.Error)
+
+		var output struct {
+			Items []map[string]any `json:"items"`
+		}
+		require.NoError(t, json.Unmarshal([]byte(result.Content), &output))
+		require.Len(t, output.Items, 1)
+		assert.Equal(t, "web-1", output.Items[0]["metadata"].(map[string]any)["name"])
+	})
+
+	t.Run("rejects kinds and namespaces that are not allowed", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sGet), `{"kind":"ConfigMap","name":"settings"}`)
+		assert.Equal(t, "kind ConfigMap is not allowed, allowed kinds are: Pod, Secret", result.Error)
+
+		result = executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sList), `{"kind":"Pod","namespace":"kube-system"}`)
+		assert.Equal(t, "namespace kube-system is not allowed, allowed namespaces are: apps", result.Error)
+	})
+
+	t.Run("reports missing resources to the model", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sGet), `{"kind":"Pod","name":"missing"}`)
+		assert.Contains(t, result.Content, "not found")
+	})
+
+	t.Run("describes events of a resource", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sDescribeEvents), `{"kind":"Pod","name":"web-1"}`)
+		require.Empty(t, result.Error)
+		assert.Contains(t, result.Content, "Warning BackOff (x3): Back-off restarting failed container")
+
+		result = executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sDescribeEvents), `{"kind":"Pod","name":"worker-1"}`)
+		assert.Equal(t, "No events found for Pod worker-1", result.Content)
+	})
+
+	t.Run("reads pod logs with the reader of the query", func(t *testing.T) {
+		result := executeKubernetesTool(t, ctx, newExecutor(BuiltinToolK8sLogs), `{"name":"web-1"}`)
+		assert.Equal(t, "pod logs are not available outside of queries", result.Error)
+
+		reader := &fakePodLogReader{}
+		result = executeKubernetesTool(t, WithPodLogReader(ctx, reader), newExecutor(BuiltinToolK8sLogs), `{"name":"web-1","tailLines":5000,"container":"app"}`)
+		assert.Equal(t, "log of apps/web-1", result.Content)
+		assert.Equal(t, "app", reader.options.Container)
+		assert.Equal(t, int64(maxK8sLogLines), *reader.options.TailLines)
+	})
+}
+
+func TestKubernetesToolInputSchema(t *testing.T) {
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "cluster-get", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type: ToolTypeBuiltin,
+			Builtin: &arkv1alpha1.BuiltinToolRef{
+				Name: BuiltinToolK8sGet,
+				Kubernetes: &arkv1alpha1.KubernetesToolAccess{
+					Resources: []arkv1alpha1.KubernetesResourceKind{{Kind: "Pod"}, {APIVersion: "apps/v1", Kind: "Deployment"}},
+				},
+			},
+		},
+	}
+
+	definition := CreateToolFromCRD(tool)
+	assert.Equal(t, "Get a Kubernetes resource by kind and name", definition.Description)
+	properties := definition.Parameters["properties"].(map[string]any)
+	assert.Equal(t, []any{"Pod", "Deployment"}, properties["kind"].(map[string]any)["enum"])
+	assert.Equal(t, []any{"kind", "name"}, definition.Parameters["required"])
+
+	executor, err := createBuiltinExecutor(nil, tool, "default")
+	require.NoError(t, err)
+	assert.Equal(t, BuiltinToolK8sGet, executor.(*KubernetesToolExecutor).Operation)
+}
@@ -2,18 +2,24 @@ package genai
 
 import (
 	"context"
+	"encoding/base64"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"maps"
 	"net"
 	"net/http"
 	"net/url"
 	"path"
+	"reflect"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"golang.org/x/oauth2"
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/common"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -29,6 +35,8 @@ type MCPClient struct {
 	baseURL string
 	headers map[string]string
 	client  *mcp.ClientSession
+	// lost is set once a call finds the connection closed, so that the session pool replaces the session
+	lost atomic.Bool
 }
 
 const (
@@ -46,12 +54,13 @@ var (
 	ErrUnsupportedTransport  = "unsupported transport type"
 )
 
-func NewMCPClient(ctx context.Context, baseURL string, headers map[string]string, transportType string, timeout time.Duration, mcpSetting MCPSettings) (*MCPClient, error) {
+// NewMCPClient connects to an MCP server. The tokenSource is optional; when set, every request carries its bearer token.
+func NewMCPClient(ctx context.Context, baseURL string, headers map[string]string, tokenSource oauth2.TokenSource, transportType string, timeout time.Duration, mcpSetting MCPSettings) (*MCPClient, error) {
 	mergedHeaders := make(map[string]string)
 	maps.Copy(mergedHeaders, headers)
 	maps.Copy(mergedHeaders, mcpSetting.Headers)
 
-	mcpClient, err := createMCPClientWithRetry(ctx, baseURL, mergedHeaders, transportType, timeout, connectMaxReties)
+	mcpClient, err := createMCPClientWithRetry(ctx, baseURL, mergedHeaders, tokenSource, transportType, timeout, connectMaxReties, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -67,16 +76,49 @@ func NewMCPClient(ctx context.Context, baseURL string, headers map[string]string
 	return mcpClient, nil
 }
 
-func createHTTPClient() *mcp.Client {
+// NewMCPWatchClient connects a long-lived session to an MCP server, calling onToolListChanged whenever the server
+// sends a tools/list_changed notification. The session is not bound to the cancellation of ctx, and its requests
+// are not bounded by the timeout, which only applies to connecting.
+func NewMCPWatchClient(ctx context.Context, baseURL string, headers map[string]string, tokenSource oauth2.TokenSource, transportType string, timeout time.Duration, onToolListChanged func()) (*MCPClient, error) {
+	options := &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) { onToolListChanged() },
+	}
+	return createMCPClientWithRetry(context.WithoutCancel(ctx), baseURL, headers, tokenSource, transportType, timeout, connectMaxReties, options)
+}
+
+func createHTTPClient(options *mcp.ClientOptions) *mcp.Client {
 	impl := &mcp.Implementation{
 		Name:    arkv1alpha1.GroupVersion.Group,
 		Version: arkv1alpha1.GroupVersion.Version,
 	}
 
-	mcpClient := mcp.NewClient(impl, nil)
+	clientOptions := mcp.ClientOptions{}
+	if options != nil {
+		clientOptions = *options
+	}
+	clientOptions.ProgressNotificationHandler = handleMCPProgress
+
+	mcpClient := mcp.NewClient(impl, &clientOptions)
 	return mcpClient
 }
 
+// mcpProgressListeners routes progress notifications to the tool calls that requested them, by progress token,
+// as sessions are shared between queries.
+var (
+	mcpProgressListeners sync.Map
+	mcpProgressTokens    atomic.Int64
+)
+
+func handleMCPProgress(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+	token, ok := req.Params.ProgressToken.(string)
+	if !ok {
+		return
+	}
+	if report, ok := mcpProgressListeners.Load(token); ok {
+		report.(func(ToolProgress))(ToolProgress{Progress: req.Params.Progress, Total: req.Params.Total, Message: req.Params.Message})
+	}
+}
+
 func performBackoff(ctx context.Context, attempt int, baseURL string) error {
 	log := logf.FromContext(ctx)
 	backoff := time.Duration(1<<uint(attempt)) * time.Second
@@ -90,12 +132,12 @@ func performBackoff(ctx context.Context, attempt int, baseURL string) error {
 	}
 }
 
-func createTransport(baseURL string, headers map[string]string, timeout time.Duration, transportType string) (mcp.Transport, error) {
+func createTransport(baseURL string, headers map[string]string, tokenSource oauth2.TokenSource, timeout time.Duration, transportType string, longLived bool) (mcp.Transport, error) {
 	// Create HTTP client with headers
 	var httpClient *http.Client
-	if transportType == sseTransport {
+	if transportType == sseTransport || longLived {
 		httpClient = &http.Client{
-			// No timeout for SSE: connections are long-lived
+			// No timeout for SSE and long-lived sessions: they keep a request open to receive notifications
 		}
 	} else {
 		httpClient = &http.Client{
@@ -103,11 +145,12 @@ func createTransport(baseURL string, headers map[string]string, timeout time.Dur
 		}
 	}
 
-	// If we have headers, wrap the transport
-	if len(headers) > 0 {
+	// If we have headers or a token, wrap the transport
+	if len(headers) > 0 || tokenSource != nil {
 		httpClient.Transport = &headerTransport{
-			headers: headers,
-			base:    http.DefaultTransport,
+			headers:     headers,
+			tokenSource: tokenSource,
+			base:        http.DefaultTransport,
 		}
 	}
 
@@ -137,8 +180,9 @@ func createTransport(baseURL string, headers map[string]string, timeout time.Dur
 }
 
 type headerTransport struct {
-	headers map[string]string
-	base    http.RoundTripper
+	headers     map[string]string
+	tokenSource oauth2.TokenSource
+	base        http.RoundTripper
 }
 
 func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -148,13 +192,18 @@ func (t *headerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 		req.Header.Set(k, v)
 	}
 
+	// The token is read per request so that long-lived sessions pick up refreshed tokens
+	if err := setBearerToken(req, t.tokenSource); err != nil {
+		return nil, err
+	}
+
 	return t.base.RoundTrip(req)
 }
 
-func attemptMCPConnection(ctx context.Context, mcpClient *mcp.Client, baseURL string, headers map[string]string, httpTimeout time.Duration, transportType string) (*mcp.ClientSession, error) {
+func attemptMCPConnection(ctx context.Context, mcpClient *mcp.Client, baseURL string, headers map[string]string, tokenSource oauth2.TokenSource, httpTimeout time.Duration, transportType string, longLived bool) (*mcp.ClientSession, error) {
 	log := logf.FromContext(ctx)
 
-	transport, err := createTransport(baseURL, headers, httpTimeout, transportType)
+	transport, err := createTransport(baseURL, headers, tokenSource, httpTimeout, transportType, longLived)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create MCP client transport for %s: %w", baseURL, err)
 	}
@@ -173,8 +222,10 @@ func attemptMCPConnection(ctx context.Context, mcpClient *mcp.Client, baseURL st
 	return session, nil
 }
 
-func createMCPClientWithRetry(ctx context.Context, baseURL string, headers map[string]string, transportType string, httpTimeout time.Duration, maxRetries int) (*MCPClient, error) {
-	mcpClient := createHTTPClient()
+func createMCPClientWithRetry(ctx context.Context, baseURL string, headers map[string]string, tokenSource oauth2.TokenSource, transportType string, httpTimeout time.Duration, maxRetries int, options *mcp.ClientOptions) (*MCPClient, error) {
+	mcpClient := createHTTPClient(options)
+	// Only long-lived sessions pass client options, to receive notifications
+	longLived := options != nil
 
 	// Create a context with timeout ONLY for the retry loop
 	// The caller's context (ctx) is used for the actual connection and should control its lifetime
@@ -193,7 +244,7 @@ func createMCPClientWithRetry(ctx context.Context, baseURL string, headers map[s
 		// Use the caller's context for the connection
 		// For SSE: This context controls the connection lifetime - when ctx is canceled, connection closes
 		// For HTTP: This context is used per-request
-		session, err := attemptMCPConnection(ctx, mcpClient, baseURL, headers, httpTimeout, transportType)
+		session, err := attemptMCPConnection(ctx, mcpClient, baseURL, headers, tokenSource, httpTimeout, transportType, longLived)
 		if err == nil {
 			return &MCPClient{
 				baseURL: baseURL,
@@ -254,6 +305,133 @@ func (c *MCPClient) ListTools(ctx context.Context) ([]*mcp.Tool, error) {
 	return response.Tools, nil
 }
 
+// serverCapabilities returns the capabilities the server announced when the session was initialized.
+func (c *MCPClient) serverCapabilities() *mcp.ServerCapabilities {
+	if result := c.client.InitializeResult(); result != nil && result.Capabilities != nil {
+		return result.Capabilities
+	}
+	return &mcp.ServerCapabilities{}
+}
+
+// NotifiesToolListChanges reports whether the server announced that it sends tools/list_changed notifications.
+func (c *MCPClient) NotifiesToolListChanges() bool {
+	tools := c.serverCapabilities().Tools
+	return tools != nil && tools.ListChanged
+}
+
+// Wait blocks until the session is closed, by either side.
+func (c *MCPClient) Wait() error {
+	return c.client.Wait()
+}
+
+// Close closes the session.
+func (c *MCPClient) Close() error {
+	return c.client.Close()
+}
+
+// ListResources lists the resources of the server. Servers without the resources capability have none.
+func (c *MCPClient) ListResources(ctx context.Context) ([]*mcp.Resource, error) {
+	if c.serverCapabilities().Resources == nil {
+		return nil, nil
+	}
+
+	var resources []*mcp.Resource
+	for resource, err := range c.client.Resources(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, resource)
+	}
+	return resources, nil
+}
+
+// ListPrompts lists the prompts of the server. Servers without the prompts capability have none.
+func (c *MCPClient) ListPrompts(ctx context.Context) ([]*mcp.Prompt, error) {
+	if c.serverCapabilities().Prompts == nil {
+		return nil, nil
+	}
+
+	var prompts []*mcp.Prompt
+	for prompt, err := range c.client.Prompts(ctx, nil) {
+		if err != nil {
+			return nil, err
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// ReadResource returns the text of a resource. Binary contents are described rather than included.
+func (c *MCPClient) ReadResource(ctx context.Context, uri string) (string, error) {
+	response, err := c.client.ReadResource(ctx, &mcp.ReadResourceParams{URI: uri})
+	if err != nil {
+		return "", fmt.Errorf("failed to read resource %s: %w", uri, err)
+	}
+
+	texts := make([]string, 0, len(response.Contents))
+	for _, contents := range response.Contents {
+		texts = append(texts, resourceContentsText(contents))
+	}
+	return strings.Join(texts, "\n"), nil
+}
+
+// GetPrompt renders a prompt of the server. Only the arguments the prompt declares are taken from values.
+func (c *MCPClient) GetPrompt(ctx context.Context, name string, values map[string]string) (string, error) {
+	prompts, err := c.ListPrompts(ctx)
+	if err != nil {
+		return "", fmt.Errorf("failed to list prompts: %w", err)
+	}
+
+	var prompt *mcp.Prompt
+	for _, candidate := range prompts {
+		if candidate.Name == name {
+			prompt = candidate
+			break
+		}
+	}
+	if prompt == nil {
+		return "", fmt.Errorf("prompt %s not found", name)
+	}
+
+	arguments := make(map[string]string)
+	for _, argument := range prompt.Arguments {
+		value, exists := values[argument.Name]
+		if !exists {
+			if argument.Required {
+				return "", fmt.Errorf("prompt %s requires argument %s", name, argument.Name)
+			}
+			continue
+		}
+		arguments[argument.Name] = value
+	}
+
+	response, err := c.client.GetPrompt(ctx, &mcp.GetPromptParams{Name: name, Arguments: arguments})
+	if err != nil {
+		return "", fmt.Errorf("failed to get prompt %s: %w", name, err)
+	}
+
+	var texts []string
+	for _, message := range response.Messages {
+		switch content := message.Content.(type) {
+		case *mcp.TextContent:
+			texts = append(texts, content.Text)
+		case *mcp.EmbeddedResource:
+			texts = append(texts, resourceContentsText(content.Resource))
+		}
+	}
+	return strings.Join(texts, "\n\n"), nil
+}
+
+func resourceContentsText(contents *mcp.ResourceContents) string {
+	if contents == nil {
+		return ""
+	}
+	if contents.Text != "" || len(contents.Blob) == 0 {
+		return contents.Text
+	}
+	return fmt.Sprintf("[binary content of type %s, %d bytes]", contents.MIMEType, len(contents.Blob))
+}
+
 // MCP Tool Executor
 type MCPExecutor struct {
 	MCPClient *MCPClient
@@ -281,26 +459,100 @@ func (m *MCPExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, e
 		arguments = make(map[string]any)
 	}
 
-	log.Info("calling mcp", "tool", m.ToolName, "server", m.MCPClient.baseURL)
-	response, err := m.MCPClient.client.CallTool(ctx, &mcp.CallToolParams{
+	params := &mcp.CallToolParams{
 		Name:      m.ToolName,
 		Arguments: arguments,
-	})
+	}
+	if hasToolProgressReporter(ctx) {
+		token := fmt.Sprintf("%s-%d", call.ID, mcpProgressTokens.Add(1))
+		mcpProgressListeners.Store(token, func(progress ToolProgress) { reportToolProgress(ctx, progress) })
+		defer mcpProgressListeners.Delete(token)
+		// SetProgressToken only sets the token in existing metadata
+		params.Meta = mcp.Meta{}
+		params.SetProgressToken(token)
+	}
+
+	log.Info("calling mcp", "tool", m.ToolName, "server", m.MCPClient.baseURL)
+	response, err := m.MCPClient.client.CallTool(ctx, params)
 	if err != nil {
+		if errors.Is(err, mcp.ErrConnectionClosed) {
+			m.MCPClient.lost.Store(true)
+		}
 		log.Info("tool call error", "tool", m.ToolName, "error", err, "errorType", fmt.Sprintf("%T", err))
 		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: ""}, err
 	}
 	log.V(2).Info("tool call response", "tool", m.ToolName, "response", response)
+	content, images := mcpToolResultContent(response)
+	result := ToolResult{ID: call.ID, Name: call.Function.Name, Content: content, Images: images}
+
+	// Tool errors are reported to the model, which may recover from them, rather than failing the query
+	if response.IsError {
+		result.Error = content
+		if result.Error == "" {
+			result.Error = fmt.Sprintf("MCP tool %s returned an error", m.ToolName)
+		}
+	}
+	return result, nil
+}
+
+// mcpToolResultContent renders the content of an MCP tool result as text for the model. Images are returned
+// separately, to be sent to models with the vision capability, and structured content is passed as JSON.
+func mcpToolResultContent(response *mcp.CallToolResult) (string, []ToolResultImage) {
 	var result strings.Builder
+	var images []ToolResultImage
+	previousText := false
+	write := func(text string, isText bool) {
+		// Consecutive text blocks are concatenated, other blocks are written on their own lines
+		if result.Len() > 0 && (!isText || !previousText) {
+			result.WriteString("\n")
+		}
+		result.WriteString(text)
+		previousText = isText
+	}
+
 	for _, content := range response.Content {
-		if textContent, ok := content.(*mcp.TextContent); ok {
-			result.WriteString(textContent.Text)
-		} else {
-			jsonBytes, _ := json.MarshalIndent(content, "", "  ")
-			result.WriteString(string(jsonBytes))
+		switch c := content.(type) {
+		case *mcp.TextContent:
+			// Servers repeat structured content as text for clients that do not support it
+			if response.StructuredContent != nil && jsonTextEqual(c.Text, response.StructuredContent) {
+				continue
+			}
+			write(c.Text, true)
+		case *mcp.ImageContent:
+			images = append(images, ToolResultImage{MIMEType: c.MIMEType, Data: base64.StdEncoding.EncodeToString(c.Data)})
+			write(fmt.Sprintf("[image %d of type %s]", len(images), c.MIMEType), false)
+		case *mcp.AudioContent:
+			write(fmt.Sprintf("[audio of type %s, %d bytes]", c.MIMEType, len(c.Data)), false)
+		case *mcp.EmbeddedResource:
+			if c.Resource != nil {
+				write(fmt.Sprintf("[resource %s of type %s, %d bytes]", c.Resource.URI, c.Resource.MIMEType, len(c.Resource.Text)+len(c.Resource.Blob)), false)
+			}
+		case *mcp.ResourceLink:
+			write(fmt.Sprintf("[resource link %s of type %s]", c.URI, c.MIMEType), false)
+		default:
+			jsonBytes, _ := json.Marshal(content)
+			write(string(jsonBytes), false)
 		}
 	}
-	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: result.String()}, nil
+
+	if response.StructuredContent != nil {
+		structured, _ := json.Marshal(response.StructuredContent)
+		write(string(structured), false)
+	}
+	return result.String(), images
+}
+
+// jsonTextEqual reports whether text is the JSON encoding of value, regardless of formatting and key order.
+func jsonTextEqual(text string, value any) bool {
+	var decodedText, decodedValue any
+	if err := json.Unmarshal([]byte(text), &decodedText); err != nil {
+		return false
+	}
+	encoded, err := json.Marshal(value)
+	if err != nil || json.Unmarshal(encoded, &decodedValue) != nil {
+		return false
+	}
+	return reflect.DeepEqual(decodedText, decodedValue)
 }
 
 // BuildMCPServerURL builds the URL for an MCP server with full ValueSource resolution
@@ -0,0 +1,305 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	// DefaultMCPMaxSessions is the number of MCP sessions kept by the controller-wide pool.
+	DefaultMCPMaxSessions = 100
+	// DefaultMCPSessionIdleTimeout is how long an unused MCP session is kept before it is closed.
+	DefaultMCPSessionIdleTimeout = 5 * time.Minute
+	// DefaultMCPSessionHealthCheckInterval is how long a session is trusted before it is pinged again.
+	DefaultMCPSessionHealthCheckInterval = 30 * time.Second
+)
+
+type MCPSessionPoolOptions struct {
+	MaxSessions         int
+	IdleTimeout         time.Duration
+	HealthCheckInterval time.Duration
+}
+
+// MCPSessionPool shares MCP sessions between queries, so that queries do not pay the initialize handshake of
+// servers they use. Sessions are keyed by server and by everything sent to it when connecting, including the
+// resolved headers and the settings of queries, so that sessions carrying the credentials of one query are
+// never handed to another. Sessions are pinged before reuse once the health check interval has passed, and
+// replaced when they fail. Unused sessions are closed after the idle timeout. When the pool is full and no
+// session is unused, sessions are created outside of the pool and closed when released.
+type MCPSessionPool struct {
+	mu       sync.Mutex
+	options  MCPSessionPoolOptions
+	sessions map[string]*mcpSession
+	now      func() time.Time
+}
+
+type mcpSession struct {
+	key         string
+	client      *MCPClient
+	refs        int
+	lastUsed    time.Time
+	lastChecked time.Time
+	// pooled is false for sessions created while the pool was full, and for sessions removed from it
+	pooled bool
+}
+
+func NewMCPSessionPool(options MCPSessionPoolOptions) *MCPSessionPool {
+	return &MCPSessionPool{
+		options:  options,
+		sessions: make(map[string]*mcpSession),
+		now:      time.Now,
+	}
+}
+
+// DefaultMCPSessionPool is shared by all queries of the controller.
+var DefaultMCPSessionPool = NewMCPSessionPool(MCPSessionPoolOptions{
+	MaxSessions:         DefaultMCPMaxSessions,
+	IdleTimeout:         DefaultMCPSessionIdleTimeout,
+	HealthCheckInterval: DefaultMCPSessionHealthCheckInterval,
+})
+
+// MCPSessionKey identifies the session of a server for the given connection parameters. Header values are hashed
+// rather than kept in the key.
+func MCPSessionKey(serverNamespace, serverName string, generation int64, serverURL, transport string, headers map[string]string, mcpSetting MCPSettings) string {
+	params, _ := json.Marshal(struct {
+		Generation int64             `json:"generation"`
+		URL        string            `json:"url"`
+		Transport  string            `json:"transport"`
+		Headers    map[string]string `json:"headers,omitempty"`
+		Settings   MCPSettings       `json:"settings"`
+	}{generation, serverURL, transport, headers, mcpSetting})
+	hash := sha256.Sum256(params)
+	return fmt.Sprintf("%s/%s/%s", serverNamespace, serverName, hex.EncodeToString(hash[:8]))
+}
+
+// Acquire returns the session for the key, connecting with connect if there is no healthy session yet. The
+// returned release function must be called once the session is no longer used.
+func (p *MCPSessionPool) Acquire(ctx context.Context, key string, connect func(ctx context.Context) (*MCPClient, error)) (*MCPClient, func(), error) {
+	if session := p.reuse(ctx, key); session != nil {
+		return session.client, p.releaseFunc(session), nil
+	}
+
+	// Sessions outlive the query that created them, so they must not be bound to its cancellation
+	mcpClient, err := connect(context.WithoutCancel(ctx))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	session := p.add(ctx, key, mcpClient)
+	return session.client, p.releaseFunc(session), nil
+}
+
+// reuse returns the pooled session for the key with a reference taken, or nil if there is no healthy one.
+func (p *MCPSessionPool) reuse(ctx context.Context, key string) *mcpSession {
+	p.mu.Lock()
+	session, exists := p.sessions[key]
+	if !exists {
+		p.mu.Unlock()
+		return nil
+	}
+	session.refs++
+	needsCheck := session.client.lost.Load() || p.now().Sub(session.lastChecked) >= p.options.HealthCheckInterval
+	p.mu.Unlock()
+
+	if !needsCheck {
+		return session
+	}
+
+	err := session.client.ping(ctx)
+	p.mu.Lock()
+	if err == nil {
+		session.lastChecked = p.now()
+		p.mu.Unlock()
+		return session
+	}
+	if p.sessions[key] == session {
+		delete(p.sessions, key)
+		session.pooled = false
+	}
+	p.mu.Unlock()
+
+	logf.FromContext(ctx).Info("reconnecting unhealthy MCP session", "server", session.client.baseURL, "error", err.Error())
+	p.release(session)
+	return nil
+}
+
+// add pools a new session, unless another query pooled one for the key in the meantime.
+func (p *MCPSessionPool) add(ctx context.Context, key string, mcpClient *MCPClient) *mcpSession {
+	now := p.now()
+	session := &mcpSession{key: key, client: mcpClient, refs: 1, lastUsed: now, lastChecked: now}
+
+	p.mu.Lock()
+	if existing, exists := p.sessions[key]; exists {
+		existing.refs++
+		p.mu.Unlock()
+		closeMCPClient(ctx, mcpClient)
+		return existing
+	}
+
+	var evicted []*mcpSession
+	if len(p.sessions) >= p.options.MaxSessions {
+		evicted = p.evictLocked(now, true)
+	}
+	if len(p.sessions) < p.options.MaxSessions {
+		session.pooled = true
+		p.sessions[key] = session
+	}
+	p.mu.Unlock()
+
+	for _, idle := range evicted {
+		closeMCPClient(ctx, idle.client)
+	}
+	return session
+}
+
+func (p *MCPSessionPool) releaseFunc(session *mcpSession) func() {
+	var once sync.Once
+	return func() { once.Do(func() { p.release(session) }) }
+}
+
+func (p *MCPSessionPool) release(session *mcpSession) {
+	p.mu.Lock()
+	session.refs--
+	session.lastUsed = p.now()
+	closeNow := session.refs == 0 && !session.pooled
+	p.mu.Unlock()
+
+	if closeNow {
+		closeMCPClient(context.Background(), session.client)
+	}
+}
+
+// evictLocked removes the unused sessions that have been idle for longer than the idle timeout, or the least
+// recently used unused session when force is set, and returns them to be closed.
+func (p *MCPSessionPool) evictLocked(now time.Time, force bool) []*mcpSession {
+	var evicted []*mcpSession
+	var oldest *mcpSession
+	for key, session := range p.sessions {
+		if session.refs > 0 {
+			continue
+		}
+		if now.Sub(session.lastUsed) >= p.options.IdleTimeout {
+			delete(p.sessions, key)
+			session.pooled = false
+			evicted = append(evicted, session)
+			continue
+		}
+		if oldest == nil || session.lastUsed.Before(oldest.lastUsed) {
+			oldest = session
+		}
+	}
+	if force && len(evicted) == 0 && oldest != nil {
+		delete(p.sessions, oldest.key)
+		oldest.pooled = false
+		evicted = append(evicted, oldest)
+	}
+	return evicted
+}
+
+// Len returns the number of pooled sessions.
+func (p *MCPSessionPool) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return len(p.sessions)
+}
+
+// Start closes idle sessions until the context is done, and then closes all unused sessions. It lets the pool
+// run as a runnable of the controller manager.
+func (p *MCPSessionPool) Start(ctx context.Context) error {
+	interval := p.options.IdleTimeout / 2
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			p.mu.Lock()
+			evicted := p.evictLocked(p.now(), false)
+			p.mu.Unlock()
+			for _, session := range evicted {
+				closeMCPClient(ctx, session.client)
+			}
+		case <-ctx.Done():
+			p.mu.Lock()
+			var unused []*mcpSession
+			for key, session := range p.sessions {
+				session.pooled = false
+				delete(p.sessions, key)
+				if session.refs == 0 {
+					unused = append(unused, session)
+				}
+			}
+			p.mu.Unlock()
+			for _, session := range unused {
+				closeMCPClient(context.Background(), session.client)
+			}
+			return nil
+		}
+	}
+}
+
+// NeedLeaderElection lets the pool close idle sessions on every replica, as every replica may lease sessions.
+func (p *MCPSessionPool) NeedLeaderElection() bool {
+	return false
+}
+
+func (c *MCPClient) ping(ctx context.Context) error {
+	if c.lost.Load() {
+		return mcp.ErrConnectionClosed
+	}
+	return c.client.Ping(ctx, nil)
+}
+
+func closeMCPClient(ctx context.Context, mcpClient *MCPClient) {
+	if mcpClient == nil || mcpClient.client == nil {
+		return
+	}
+	if err := mcpClient.client.Close(); err != nil {
+		logf.FromContext(ctx).V(1).Info("failed to close MCP session", "server", mcpClient.baseURL, "error", err.Error())
+	}
+}
+
+type mcpSessionScopeKey struct{}
+
+type mcpSessionScope struct {
+	mu       sync.Mutex
+	releases []func()
+}
+
+// WithMCPSessionScope returns a context in which leased MCP sessions are returned to the pool by the returned
+// function, even when the tool registries that leased them are not closed.
+func WithMCPSessionScope(ctx context.Context) (context.Context, func()) {
+	scope := &mcpSessionScope{}
+	return context.WithValue(ctx, mcpSessionScopeKey{}, scope), scope.release
+}
+
+func addToMCPSessionScope(ctx context.Context, release func()) {
+	if scope, ok := ctx.Value(mcpSessionScopeKey{}).(*mcpSessionScope); ok {
+		scope.mu.Lock()
+		scope.releases = append(scope.releases, release)
+		scope.mu.Unlock()
+	}
+}
+
+func (s *mcpSessionScope) release() {
+	s.mu.Lock()
+	releases := s.releases
+	s.releases = nil
+	s.mu.Unlock()
+	for _, release := range releases {
+		release()
+	}
+}
@@ -0,0 +1,189 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMCPSessionPool(maxSessions int) (*MCPSessionPool, *time.Time) {
+	now := time.Now()
+	pool := NewMCPSessionPool(MCPSessionPoolOptions{
+		MaxSessions:         maxSessions,
+		IdleTimeout:         time.Minute,
+		HealthCheckInterval: 10 * time.Second,
+	})
+	pool.now = func() time.Time { return now }
+	return pool, &now
+}
+
+// countingConnect connects in-memory clients to the docs server and counts the connections.
+func countingConnect(t *testing.T, connects *atomic.Int32) func(ctx context.Context) (*MCPClient, error) {
+	return func(ctx context.Context) (*MCPClient, error) {
+		connects.Add(1)
+		return newInMemoryMCPClient(t, newDocsMCPServer()), nil
+	}
+}
+
+func TestMCPSessionKeyIsolatesCredentials(t *testing.T) {
+	key := func(headers map[string]string, setting MCPSettings) string {
+		return MCPSessionKey("default", "docs", 1, "http://docs", "http", headers, setting)
+	}
+
+	assert.Equal(t, key(map[string]string{"Authorization": "a"}, MCPSettings{}), key(map[string]string{"Authorization": "a"}, MCPSettings{}))
+	assert.NotEqual(t, key(map[string]string{"Authorization": "a"}, MCPSettings{}), key(map[string]string{"Authorization": "b"}, MCPSettings{}))
+	assert.NotEqual(t, key(nil, MCPSettings{}), key(nil, MCPSettings{Headers: map[string]string{"X-Tenant": "acme"}}))
+	assert.NotEqual(t, key(nil, MCPSettings{}), MCPSessionKey("default", "docs", 2, "http://docs", "http", nil, MCPSettings{}))
+	assert.NotContains(t, key(map[string]string{"Authorization": "secret-token"}, MCPSettings{}), "secret-token")
+}
+
+func TestMCPSessionPoolReusesSessions(t *testing.T) {
+	ctx := context.Background()
+	pool, _ := newTestMCPSessionPool(10)
+	var connects atomic.Int32
+
+	first, releaseFirst, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	second, releaseSecond, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	other, releaseOther, err := pool.Acquire(ctx, "default/docs/b", countingConnect(t, &connects))
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.NotSame(t, first, other)
+	assert.Equal(t, int32(2), connects.Load())
+	assert.Equal(t, 2, pool.Len())
+
+	releaseFirst()
+	releaseFirst()
+	releaseSecond()
+	releaseOther()
+	assert.NoError(t, first.client.Ping(ctx, nil), "released sessions stay open in the pool")
+}
+
+func TestMCPSessionPoolReconnectsUnhealthySessions(t *testing.T) {
+	ctx := context.Background()
+	pool, now := newTestMCPSessionPool(10)
+	var connects atomic.Int32
+
+	first, release, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+
+	// Healthy sessions are reused after a health check
+	*now = now.Add(time.Minute / 2)
+	second, release, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+	assert.Same(t, first, second)
+
+	// Sessions whose connection was lost are replaced
+	first.lost.Store(true)
+	third, release, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+	assert.NotSame(t, first, third)
+	assert.Equal(t, int32(2), connects.Load())
+	assert.ErrorIs(t, first.client.Ping(ctx, nil), mcp.ErrConnectionClosed)
+}
+
+func TestMCPSessionPoolLimitsSessions(t *testing.T) {
+	ctx := context.Background()
+	pool, now := newTestMCPSessionPool(1)
+	var connects atomic.Int32
+
+	first, releaseFirst, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+
+	// Sessions in use are not evicted, so sessions beyond the limit are closed when released
+	overflow, releaseOverflow, err := pool.Acquire(ctx, "default/docs/b", countingConnect(t, &connects))
+	require.NoError(t, err)
+	assert.Equal(t, 1, pool.Len())
+	releaseOverflow()
+	assert.Error(t, overflow.client.Ping(ctx, nil))
+
+	// Unused sessions make room for new ones
+	releaseFirst()
+	*now = now.Add(time.Second)
+	_, releaseSecond, err := pool.Acquire(ctx, "default/docs/c", countingConnect(t, &connects))
+	require.NoError(t, err)
+	defer releaseSecond()
+	assert.Equal(t, 1, pool.Len())
+	assert.Error(t, first.client.Ping(ctx, nil))
+}
+
+func TestMCPSessionPoolClosesIdleSessions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	pool, _ := newTestMCPSessionPool(10)
+	pool.options.IdleTimeout = 20 * time.Millisecond
+	pool.now = time.Now
+	var connects atomic.Int32
+
+	idle, release, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	release()
+
+	done := make(chan error)
+	go func() { done <- pool.Start(ctx) }()
+	require.Eventually(t, func() bool { return pool.Len() == 0 }, time.Second, 5*time.Millisecond)
+	assert.Error(t, idle.client.Ping(ctx, nil))
+
+	cancel()
+	require.NoError(t, <-done)
+}
+
+func TestMCPSessionPoolConcurrentAcquire(t *testing.T) {
+	ctx := context.Background()
+	pool, _ := newTestMCPSessionPool(10)
+	var connects atomic.Int32
+
+	var wg sync.WaitGroup
+	clients := make([]*MCPClient, 8)
+	for i := range clients {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mcpClient, release, err := pool.Acquire(ctx, "default/docs/a", countingConnect(t, &connects))
+			assert.NoError(t, err)
+			defer release()
+			clients[i] = mcpClient
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, pool.Len())
+	for _, mcpClient := range clients {
+		assert.NoError(t, mcpClient.client.Ping(ctx, nil), "sessions connected concurrently are closed in favor of the pooled one")
+	}
+}
+
+func TestMCPSessionScopeReleasesLeases(t *testing.T) {
+	pool, _ := newTestMCPSessionPool(1)
+	var connects atomic.Int32
+	clientPool := &MCPClientPool{sessions: pool, clients: map[string]*MCPClient{}, releases: map[string]func(){}}
+
+	ctx, releaseScope := WithMCPSessionScope(context.Background())
+	key := MCPSessionKey("default", "docs", 1, "memory", "http", nil, MCPSettings{})
+	mcpClient, release, err := pool.Acquire(ctx, key, countingConnect(t, &connects))
+	require.NoError(t, err)
+	clientPool.clients["default/docs"] = mcpClient
+	clientPool.releases["default/docs"] = release
+	addToMCPSessionScope(ctx, release)
+
+	releaseScope()
+	require.NoError(t, clientPool.Close(), "closing after the scope released the lease is safe")
+
+	// The session is unused again, so it can be evicted for another server
+	_, releaseOther, err := pool.Acquire(context.Background(), "default/other/a", countingConnect(t, &connects))
+	require.NoError(t, err)
+	defer releaseOther()
+	assert.Error(t, mcpClient.client.Ping(context.Background(), nil))
+}
@@ -0,0 +1,502 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// MCPStdioTransport runs an MCP server as a process of the controller, and speaks to it over its stdin and stdout.
+const MCPStdioTransport = "stdio"
+
+const (
+	DefaultMCPStdioInitialBackoff = 10 * time.Second
+	DefaultMCPStdioMaxBackoff     = 5 * time.Minute
+	DefaultMCPStdioStableAfter    = 10 * time.Minute
+	DefaultMCPStdioStderrLines    = 20
+)
+
+// inheritedStdioEnv are the variables of the controller passed on to stdio servers, which need them to find and
+// cache their packages. Nothing else is inherited, so that servers do not see the configuration of the controller.
+var inheritedStdioEnv = []string{"PATH", "HOME"}
+
+// ErrMCPStdioProcessStopped is returned for servers whose process was stopped while it was starting.
+var ErrMCPStdioProcessStopped = errors.New("MCP server process stopped")
+
+// ErrMCPStdioDisabled is returned for stdio servers when no command is allowed.
+var ErrMCPStdioDisabled = errors.New("the stdio transport is disabled, as the controller allows no commands")
+
+// MCPStdioCommand is the resolved command of a stdio MCP server.
+type MCPStdioCommand struct {
+	Command string
+	Args    []string
+	Env     map[string]string
+}
+
+// identity changes whenever the process has to be restarted to apply the command.
+func (c MCPStdioCommand) identity(timeout time.Duration) string {
+	data, _ := json.Marshal(struct {
+		Command MCPStdioCommand
+		Timeout time.Duration
+	}{c, timeout})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func (c MCPStdioCommand) environment() []string {
+	env := make([]string, 0, len(inheritedStdioEnv)+len(c.Env))
+	for _, name := range inheritedStdioEnv {
+		if _, overridden := c.Env[name]; overridden {
+			continue
+		}
+		if value, ok := os.LookupEnv(name); ok {
+			env = append(env, name+"="+value)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(c.Env)) {
+		env = append(env, name+"="+c.Env[name])
+	}
+	return env
+}
+
+type MCPStdioSupervisorOptions struct {
+	// AllowedCommands are the commands stdio servers may run. Processes run in the controller pod, with access to
+	// its service account token, so stdio servers are disabled unless an administrator allows their commands.
+	AllowedCommands []string
+	// InitialBackoff is how long the supervisor waits before restarting a process that exited, doubled on
+	// every consecutive exit.
+	InitialBackoff time.Duration
+	// MaxBackoff bounds the wait before restarts.
+	MaxBackoff time.Duration
+	// StableAfter is how long a process has to run for its next exit to restart it after InitialBackoff again.
+	StableAfter time.Duration
+	// StderrLines is the number of lines at the end of the stderr of a process that are kept to explain its exit.
+	StderrLines int
+}
+
+// MCPStdioProcessStatus describes the process of a stdio MCP server.
+type MCPStdioProcessStatus struct {
+	Running bool
+	// Restarts is the number of times the process exited or failed to start.
+	Restarts int32
+	// LastExit describes the last exit or failed start of the process, with the end of its stderr.
+	LastExit string
+	// RetryAt is when the process is restarted, while it is not running.
+	RetryAt time.Time
+}
+
+// MCPStdioSupervisor runs the processes of stdio MCP servers. Every server has a single process, and its session
+// is shared by the controller and all queries, as a stdio server serves a single client. Processes that exit are
+// restarted with an exponential backoff.
+type MCPStdioSupervisor struct {
+	options   MCPStdioSupervisorOptions
+	mu        sync.Mutex
+	processes map[types.NamespacedName]*mcpStdioProcess
+	listener  func(types.NamespacedName)
+}
+
+type mcpStdioProcess struct {
+	// starting serializes the starts of the process
+	starting sync.Mutex
+
+	command  MCPStdioCommand
+	timeout  time.Duration
+	identity string
+
+	client    *MCPClient
+	startedAt time.Time
+	failures  int
+	restarts  int32
+	lastExit  string
+	retryAt   time.Time
+	restart   *time.Timer
+	stopped   bool
+}
+
+func NewMCPStdioSupervisor(options MCPStdioSupervisorOptions) *MCPStdioSupervisor {
+	if options.InitialBackoff <= 0 {
+		options.InitialBackoff = DefaultMCPStdioInitialBackoff
+	}
+	if options.MaxBackoff < options.InitialBackoff {
+		options.MaxBackoff = max(DefaultMCPStdioMaxBackoff, options.InitialBackoff)
+	}
+	if options.StableAfter <= 0 {
+		options.StableAfter = DefaultMCPStdioStableAfter
+	}
+	if options.StderrLines <= 0 {
+		options.StderrLines = DefaultMCPStdioStderrLines
+	}
+	return &MCPStdioSupervisor{
+		options:   options,
+		processes: make(map[types.NamespacedName]*mcpStdioProcess),
+	}
+}
+
+// DefaultMCPStdioSupervisor runs the stdio MCP servers of the controller.
+var DefaultMCPStdioSupervisor = NewMCPStdioSupervisor(MCPStdioSupervisorOptions{})
+
+// CheckCommand returns an error unless stdio servers may run the command.
+func (s *MCPStdioSupervisor) CheckCommand(command string) error {
+	if len(s.options.AllowedCommands) == 0 {
+		return ErrMCPStdioDisabled
+	}
+	if !slices.Contains(s.options.AllowedCommands, command) {
+		return fmt.Errorf("command %q is not allowed for stdio MCP servers, allowed commands are: %s",
+			command, strings.Join(s.options.AllowedCommands, ", "))
+	}
+	return nil
+}
+
+// ResolveCommand resolves the environment of the command of a stdio MCP server. Commands that are not allowed are
+// refused before their environment is resolved from secrets.
+func (s *MCPStdioSupervisor) ResolveCommand(ctx context.Context, k8sClient client.Client, mcpServer *arkv1alpha1.MCPServer) (MCPStdioCommand, error) {
+	if mcpServer.Spec.Command == nil {
+		return MCPStdioCommand{}, fmt.Errorf("MCP server %s uses the stdio transport but has no command", mcpServer.Name)
+	}
+	if err := s.CheckCommand(mcpServer.Spec.Command.Command); err != nil {
+		return MCPStdioCommand{}, err
+	}
+
+	command := MCPStdioCommand{
+		Command: mcpServer.Spec.Command.Command,
+		Args:    mcpServer.Spec.Command.Args,
+		Env:     make(map[string]string, len(mcpServer.Spec.Command.Env)),
+	}
+	resolver := common.NewValueSourceResolver(k8sClient)
+	for _, env := range mcpServer.Spec.Command.Env {
+		value, err := resolver.ResolveValueSource(ctx, env.ValueSource, mcpServer.Namespace)
+		if err != nil {
+			return MCPStdioCommand{}, fmt.Errorf("failed to resolve environment variable %s: %w", env.Name, err)
+		}
+		command.Env[env.Name] = value
+	}
+	return command, nil
+}
+
+// SetListener sets the function called when the process of a server exits or restarts, or when the server
+// notifies that its tools changed.
+func (s *MCPStdioSupervisor) SetListener(listener func(types.NamespacedName)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.listener = listener
+}
+
+func (s *MCPStdioSupervisor) notify(server types.NamespacedName) {
+	s.mu.Lock()
+	listener := s.listener
+	s.mu.Unlock()
+	if listener != nil {
+		listener(server)
+	}
+}
+
+// Client returns the session of the process of the server, starting the process if it is not running. A running
+// process is replaced when the command or the timeout changed. Callers must not close the session.
+func (s *MCPStdioSupervisor) Client(ctx context.Context, server types.NamespacedName, command MCPStdioCommand, timeout time.Duration) (*MCPClient, error) {
+	if err := s.CheckCommand(command.Command); err != nil {
+		return nil, err
+	}
+	identity := command.identity(timeout)
+
+	s.mu.Lock()
+	process, exists := s.processes[server]
+	var replaced *mcpStdioProcess
+	if exists && process.identity != identity {
+		replaced = process
+		s.stopLocked(process)
+		exists = false
+	}
+	if !exists {
+		process = &mcpStdioProcess{command: command, timeout: timeout, identity: identity}
+		s.processes[server] = process
+	}
+	s.mu.Unlock()
+
+	if replaced != nil {
+		logf.FromContext(ctx).Info("restarting MCP server process to apply its new command", "server", server.String())
+		s.closeStdioClient(ctx, replaced)
+	}
+	return s.ensureRunning(ctx, server, process)
+}
+
+// ensureRunning starts the process unless it is running or waiting to be restarted.
+func (s *MCPStdioSupervisor) ensureRunning(ctx context.Context, server types.NamespacedName, process *mcpStdioProcess) (*MCPClient, error) {
+	process.starting.Lock()
+	defer process.starting.Unlock()
+
+	s.mu.Lock()
+	switch {
+	case process.stopped:
+		s.mu.Unlock()
+		return nil, ErrMCPStdioProcessStopped
+	case process.client != nil:
+		mcpClient := process.client
+		s.mu.Unlock()
+		return mcpClient, nil
+	case time.Now().Before(process.retryAt):
+		err := fmt.Errorf("MCP server process is restarted in %s: %s", time.Until(process.retryAt).Round(time.Second), process.lastExit)
+		s.mu.Unlock()
+		return nil, err
+	}
+	s.mu.Unlock()
+
+	stderr := newStderrTail(s.options.StderrLines)
+	mcpClient, err := connectMCPStdio(ctx, process.command, process.timeout, stderr, func() { s.notify(server) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if process.stopped {
+		if mcpClient != nil {
+			go func() { _ = mcpClient.Close() }()
+		}
+		return nil, ErrMCPStdioProcessStopped
+	}
+	if err != nil {
+		s.failedLocked(server, process, describeStdioExit(fmt.Sprintf("failed to start: %v", err), stderr))
+		return nil, fmt.Errorf("failed to start MCP server process: %s", process.lastExit)
+	}
+
+	process.client = mcpClient
+	process.startedAt = time.Now()
+	go s.supervise(ctx, server, process, mcpClient, stderr)
+	logf.FromContext(ctx).Info("started MCP server process", "server", server.String(), "command", process.command.Command)
+	return mcpClient, nil
+}
+
+// supervise waits for the process to exit, and schedules its restart unless it was stopped.
+func (s *MCPStdioSupervisor) supervise(ctx context.Context, server types.NamespacedName, process *mcpStdioProcess, mcpClient *MCPClient, stderr *stderrTail) {
+	waitErr := mcpClient.Wait()
+	// Closing the session reaps the process
+	_ = mcpClient.Close()
+
+	s.mu.Lock()
+	if process.stopped || process.client != mcpClient {
+		s.mu.Unlock()
+		return
+	}
+	process.client = nil
+	if time.Since(process.startedAt) >= s.options.StableAfter {
+		process.failures = 0
+	}
+	reason := "exited"
+	if waitErr != nil {
+		reason = fmt.Sprintf("exited: %v", waitErr)
+	}
+	s.failedLocked(server, process, describeStdioExit(reason, stderr))
+	lastExit, retryAt := process.lastExit, process.retryAt
+	s.mu.Unlock()
+
+	logf.FromContext(ctx).Info("MCP server process exited", "server", server.String(), "exit", lastExit, "restartAt", retryAt)
+	s.notify(server)
+}
+
+// failedLocked records an exit or a failed start of the process, and schedules its restart.
+func (s *MCPStdioSupervisor) failedLocked(server types.NamespacedName, process *mcpStdioProcess, lastExit string) {
+	process.failures++
+	process.restarts++
+	process.lastExit = lastExit
+
+	backoff := s.options.InitialBackoff
+	for i := 1; i < process.failures && backoff < s.options.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	backoff = min(backoff, s.options.MaxBackoff)
+	process.retryAt = time.Now().Add(backoff)
+	process.restart = time.AfterFunc(backoff, func() { s.restart(server, process) })
+}
+
+func (s *MCPStdioSupervisor) restart(server types.NamespacedName, process *mcpStdioProcess) {
+	ctx := context.Background()
+	if _, err := s.ensureRunning(ctx, server, process); err != nil {
+		if !errors.Is(err, ErrMCPStdioProcessStopped) {
+			logf.FromContext(ctx).Info("failed to restart MCP server process", "server", server.String(), "error", err.Error())
+		}
+		return
+	}
+	s.notify(server)
+}
+
+// Status returns the status of the process of the server, which is not running if the server has none.
+func (s *MCPStdioSupervisor) Status(server types.NamespacedName) MCPStdioProcessStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	process, exists := s.processes[server]
+	if !exists {
+		return MCPStdioProcessStatus{}
+	}
+	status := MCPStdioProcessStatus{
+		Running:  process.client != nil,
+		Restarts: process.restarts,
+		LastExit: process.lastExit,
+	}
+	if !status.Running {
+		status.RetryAt = process.retryAt
+	}
+	return status
+}
+
+// Stop stops the process of the server, if any.
+func (s *MCPStdioSupervisor) Stop(ctx context.Context, server types.NamespacedName) {
+	s.mu.Lock()
+	process, exists := s.processes[server]
+	if exists {
+		s.stopLocked(process)
+		delete(s.processes, server)
+	}
+	s.mu.Unlock()
+
+	if exists {
+		s.closeStdioClient(ctx, process)
+	}
+}
+
+func (s *MCPStdioSupervisor) stopLocked(process *mcpStdioProcess) {
+	process.stopped = true
+	if process.restart != nil {
+		process.restart.Stop()
+	}
+}
+
+// closeStdioClient terminates the process once it was stopped, after it finished starting. Sessions are closed
+// outside the lock of the supervisor, as processes are given time to exit.
+func (s *MCPStdioSupervisor) closeStdioClient(ctx context.Context, process *mcpStdioProcess) {
+	process.starting.Lock()
+	s.mu.Lock()
+	mcpClient := process.client
+	s.mu.Unlock()
+	process.starting.Unlock()
+	if mcpClient == nil {
+		return
+	}
+	if err := mcpClient.Close(); err != nil {
+		logf.FromContext(ctx).V(1).Info("MCP server process exited with an error when stopped", "error", err.Error())
+	}
+}
+
+// Start stops all processes once the context is done, to stop them with the controller manager.
+func (s *MCPStdioSupervisor) Start(ctx context.Context) error {
+	<-ctx.Done()
+
+	s.mu.Lock()
+	servers := slices.Collect(maps.Keys(s.processes))
+	s.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, server := range servers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.Stop(context.Background(), server)
+		}()
+	}
+	wg.Wait()
+	return nil
+}
+
+// NeedLeaderElection lets every replica of the controller manager run the stdio servers used by its queries.
+func (s *MCPStdioSupervisor) NeedLeaderElection() bool {
+	return false
+}
+
+// connectMCPStdio starts the command and initializes a session with it. The session lives until it is closed or
+// the process exits; ctx and the timeout only bound the initialization.
+func connectMCPStdio(ctx context.Context, command MCPStdioCommand, timeout time.Duration, stderr *stderrTail, onToolListChanged func()) (*MCPClient, error) {
+	cmd := exec.Command(command.Command, command.Args...)
+	cmd.Env = command.environment()
+	cmd.Stderr = stderr
+
+	options := &mcp.ClientOptions{
+		ToolListChangedHandler: func(context.Context, *mcp.ToolListChangedRequest) { onToolListChanged() },
+	}
+	connectCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	session, err := createHTTPClient(options).Connect(connectCtx, &mcp.CommandTransport{Command: cmd}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &MCPClient{baseURL: MCPStdioTransport + ":" + command.Command, client: session}, nil
+}
+
+func describeStdioExit(reason string, stderr *stderrTail) string {
+	if output := stderr.String(); output != "" {
+		return fmt.Sprintf("%s; stderr: %s", reason, output)
+	}
+	return reason
+}
+
+// stderrTail keeps the last lines written to the stderr of a process.
+type stderrTail struct {
+	mu      sync.Mutex
+	lines   []string
+	partial []byte
+	max     int
+}
+
+func newStderrTail(maxLines int) *stderrTail {
+	return &stderrTail{max: maxLines}
+}
+
+func (t *stderrTail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.partial = append(t.partial, p...)
+	for {
+		i := bytes.IndexByte(t.partial, '\n')
+		if i < 0 {
+			break
+		}
+		t.addLocked(string(t.partial[:i]))
+		t.partial = t.partial[i+1:]
+	}
+	// Lines longer than a terminal are cut rather than buffered without bounds
+	if len(t.partial) > 4096 {
+		t.addLocked(string(t.partial))
+		t.partial = nil
+	}
+	return len(p), nil
+}
+
+func (t *stderrTail) addLocked(line string) {
+	line = strings.TrimRight(line, "\r")
+	if strings.TrimSpace(line) == "" {
+		return
+	}
+	t.lines = append(t.lines, line)
+	if len(t.lines) > t.max {
+		t.lines = t.lines[len(t.lines)-t.max:]
+	}
+}
+
+// String returns the kept lines, and the last line if it is not terminated.
+func (t *stderrTail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lines := t.lines
+	if partial := strings.TrimSpace(string(t.partial)); partial != "" {
+		lines = append(slices.Clip(lines), partial)
+	}
+	return strings.Join(lines, "\n")
+}
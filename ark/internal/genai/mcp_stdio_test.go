@@ -0,0 +1,243 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const stdioHelperEnv = "ARK_TEST_MCP_STDIO_SERVER"
+
+// TestMCPStdioHelperServer is not a test: it is the stdio MCP server launched by the tests of the supervisor,
+// which run the test binary as the command of the server.
+func TestMCPStdioHelperServer(t *testing.T) {
+	if os.Getenv(stdioHelperEnv) != "1" {
+		t.Skip("only runs as the command of stdio MCP servers")
+	}
+	if message := os.Getenv("FAIL_START"); message != "" {
+		fmt.Fprintln(os.Stderr, message)
+		os.Exit(1)
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "stdio-helper", Version: "v1"}, nil)
+	schema := &jsonschema.Schema{Type: "object", Properties: map[string]*jsonschema.Schema{"name": {Type: "string"}}}
+	server.AddTool(&mcp.Tool{Name: "getenv", InputSchema: schema}, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var args struct {
+			Name string `json:"name"`
+		}
+		if err := json.Unmarshal(req.Params.Arguments, &args); err != nil {
+			return nil, err
+		}
+		return &mcp.CallToolResult{Content: []mcp.Content{&mcp.TextContent{Text: os.Getenv(args.Name)}}}, nil
+	})
+	server.AddTool(&mcp.Tool{Name: "crash", InputSchema: &jsonschema.Schema{Type: "object"}}, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		fmt.Fprintln(os.Stderr, "fatal: out of memory")
+		os.Exit(2)
+		return nil, nil
+	})
+
+	_ = server.Run(context.Background(), &mcp.StdioTransport{})
+	os.Exit(0)
+}
+
+func stdioHelperCommand(env map[string]string) MCPStdioCommand {
+	command := MCPStdioCommand{
+		Command: os.Args[0],
+		Args:    []string{"-test.run=^TestMCPStdioHelperServer$"},
+		Env:     map[string]string{stdioHelperEnv: "1"},
+	}
+	for name, value := range env {
+		command.Env[name] = value
+	}
+	return command
+}
+
+func newTestStdioSupervisor(t *testing.T, options MCPStdioSupervisorOptions) *MCPStdioSupervisor {
+	t.Helper()
+	options.AllowedCommands = []string{os.Args[0]}
+	supervisor := NewMCPStdioSupervisor(options)
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		_ = supervisor.Start(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return supervisor
+}
+
+func callStdioTool(t *testing.T, mcpClient *MCPClient, name string, args map[string]any) string {
+	t.Helper()
+	result, err := mcpClient.client.CallTool(context.Background(), &mcp.CallToolParams{Name: name, Arguments: args})
+	require.NoError(t, err)
+	require.Len(t, result.Content, 1)
+	return result.Content[0].(*mcp.TextContent).Text
+}
+
+func TestMCPStdioSupervisorSharesTheSessionOfTheProcess(t *testing.T) {
+	t.Setenv("ARK_TEST_CONTROLLER_SECRET", "leaked")
+	supervisor := newTestStdioSupervisor(t, MCPStdioSupervisorOptions{})
+	server := types.NamespacedName{Namespace: "default", Name: "files"}
+	command := stdioHelperCommand(map[string]string{"API_KEY": "secret"})
+
+	first, err := supervisor.Client(context.Background(), server, command, 30*time.Second)
+	require.NoError(t, err)
+	second, err := supervisor.Client(context.Background(), server, command, 30*time.Second)
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	assert.True(t, supervisor.Status(server).Running)
+
+	tools, err := first.ListTools(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, tools, 2)
+
+	assert.Equal(t, "secret", callStdioTool(t, first, "getenv", map[string]any{"name": "API_KEY"}))
+	assert.Empty(t, callStdioTool(t, first, "getenv", map[string]any{"name": "ARK_TEST_CONTROLLER_SECRET"}),
+		"the environment of the controller is not inherited")
+	assert.Equal(t, os.Getenv("PATH"), callStdioTool(t, first, "getenv", map[string]any{"name": "PATH"}))
+}
+
+func TestMCPStdioSupervisorReplacesTheProcessWhenTheCommandChanges(t *testing.T) {
+	supervisor := newTestStdioSupervisor(t, MCPStdioSupervisorOptions{})
+	server := types.NamespacedName{Namespace: "default", Name: "files"}
+
+	first, err := supervisor.Client(context.Background(), server, stdioHelperCommand(map[string]string{"API_KEY": "old"}), 30*time.Second)
+	require.NoError(t, err)
+	second, err := supervisor.Client(context.Background(), server, stdioHelperCommand(map[string]string{"API_KEY": "new"}), 30*time.Second)
+	require.NoError(t, err)
+
+	assert.NotSame(t, first, second)
+	_ = first.Wait()
+	_, err = first.ListTools(context.Background())
+	assert.Error(t, err, "the previous process is stopped")
+	assert.Equal(t, "new", callStdioTool(t, second, "getenv", map[string]any{"name": "API_KEY"}))
+	assert.Zero(t, supervisor.Status(server).Restarts, "replacing a process is not a restart")
+}
+
+func TestMCPStdioSupervisorRestartsExitedProcesses(t *testing.T) {
+	supervisor := newTestStdioSupervisor(t, MCPStdioSupervisorOptions{InitialBackoff: 100 * time.Millisecond})
+	server := types.NamespacedName{Namespace: "default", Name: "files"}
+	var notifications atomic.Int32
+	supervisor.SetListener(func(notified types.NamespacedName) {
+		if notified == server {
+			notifications.Add(1)
+		}
+	})
+
+	first, err := supervisor.Client(context.Background(), server, stdioHelperCommand(nil), 30*time.Second)
+	require.NoError(t, err)
+	_, _ = first.client.CallTool(context.Background(), &mcp.CallToolParams{Name: "crash", Arguments: map[string]any{}})
+
+	require.Eventually(t, func() bool { return supervisor.Status(server).Restarts == 1 }, 10*time.Second, 10*time.Millisecond)
+	assert.Contains(t, supervisor.Status(server).LastExit, "fatal: out of memory")
+
+	// The process is restarted after the backoff, without waiting for a caller
+	require.Eventually(t, func() bool { return supervisor.Status(server).Running }, 10*time.Second, 10*time.Millisecond)
+	require.Eventually(t, func() bool { return notifications.Load() >= 2 }, 10*time.Second, 10*time.Millisecond,
+		"the listener is notified of the exit and of the restart")
+
+	second, err := supervisor.Client(context.Background(), server, stdioHelperCommand(nil), 30*time.Second)
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	_, err = second.ListTools(context.Background())
+	assert.NoError(t, err)
+}
+
+func TestMCPStdioSupervisorBacksOffProcessesThatFailToStart(t *testing.T) {
+	supervisor := newTestStdioSupervisor(t, MCPStdioSupervisorOptions{InitialBackoff: time.Hour})
+	server := types.NamespacedName{Namespace: "default", Name: "files"}
+	command := stdioHelperCommand(map[string]string{"FAIL_START": "API_KEY is not set"})
+
+	_, err := supervisor.Client(context.Background(), server, command, 30*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "API_KEY is not set")
+
+	_, err = supervisor.Client(context.Background(), server, command, 30*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "restarted in")
+
+	status := supervisor.Status(server)
+	assert.False(t, status.Running)
+	assert.Equal(t, int32(1), status.Restarts)
+	assert.Contains(t, status.LastExit, "API_KEY is not set")
+	assert.WithinDuration(t, time.Now().Add(time.Hour), status.RetryAt, time.Minute)
+}
+
+func TestMCPStdioSupervisorOnlyRunsAllowedCommands(t *testing.T) {
+	server := types.NamespacedName{Namespace: "default", Name: "files"}
+
+	_, err := NewMCPStdioSupervisor(MCPStdioSupervisorOptions{}).Client(context.Background(), server, stdioHelperCommand(nil), 30*time.Second)
+	assert.ErrorIs(t, err, ErrMCPStdioDisabled, "the stdio transport is disabled by default")
+
+	supervisor := NewMCPStdioSupervisor(MCPStdioSupervisorOptions{AllowedCommands: []string{"mcp-server-filesystem"}})
+	_, err = supervisor.Client(context.Background(), server, stdioHelperCommand(nil), 30*time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not allowed for stdio MCP servers, allowed commands are: mcp-server-filesystem")
+	assert.False(t, supervisor.Status(server).Running)
+	assert.NoError(t, supervisor.CheckCommand("mcp-server-filesystem"))
+}
+
+func TestMCPStdioSupervisorResolvesAllowedCommands(t *testing.T) {
+	k8sClient := setupTestClientForTools(nil)
+	supervisor := NewMCPStdioSupervisor(MCPStdioSupervisorOptions{AllowedCommands: []string{"mcp-server-filesystem"}})
+	mcpServer := &arkv1alpha1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "files", Namespace: "default"},
+		Spec:       arkv1alpha1.MCPServerSpec{Transport: MCPStdioTransport},
+	}
+
+	_, err := supervisor.ResolveCommand(context.Background(), k8sClient, mcpServer)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "has no command")
+
+	mcpServer.Spec.Command = &arkv1alpha1.MCPServerCommand{
+		Command: "sh",
+		Env: []arkv1alpha1.MCPServerEnvVar{{Name: "TOKEN", ValueSource: arkv1alpha1.ValueSource{
+			ValueFrom: &arkv1alpha1.ValueFromSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "missing"},
+				Key:                  "token",
+			}},
+		}}},
+	}
+	_, err = supervisor.ResolveCommand(context.Background(), k8sClient, mcpServer)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "is not allowed for stdio MCP servers", "the command is refused before its secrets are read")
+
+	mcpServer.Spec.Command = &arkv1alpha1.MCPServerCommand{
+		Command: "mcp-server-filesystem",
+		Args:    []string{"/data"},
+		Env:     []arkv1alpha1.MCPServerEnvVar{{Name: "ROOT", ValueSource: arkv1alpha1.ValueSource{Value: "/data"}}},
+	}
+	command, err := supervisor.ResolveCommand(context.Background(), k8sClient, mcpServer)
+	require.NoError(t, err)
+	assert.Equal(t, MCPStdioCommand{
+		Command: "mcp-server-filesystem",
+		Args:    []string{"/data"},
+		Env:     map[string]string{"ROOT": "/data"},
+	}, command)
+}
+
+func TestStderrTailKeepsTheLastLines(t *testing.T) {
+	tail := newStderrTail(2)
+	_, _ = tail.Write([]byte("first\nsecond\n\nthi"))
+	_, _ = tail.Write([]byte("rd\nunterminated"))
+	assert.Equal(t, "second\nthird\nunterminated", tail.String())
+}
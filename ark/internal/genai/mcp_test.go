@@ -2,12 +2,15 @@ package genai
 
 import (
 	"context"
+	"encoding/base64"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
+	"github.com/google/jsonschema-go/jsonschema"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/openai/openai-go"
 	"github.com/stretchr/testify/require"
 )
 
@@ -129,6 +132,7 @@ func TestNewMCPClient(t *testing.T) {
 				ctx,
 				fmt.Sprintf("http://%s:%s", tc.mcpClient.connectionOptions.host, tc.mcpClient.connectionOptions.port),
 				nil,
+				nil,
 				tc.mcpClient.connectionOptions.transport,
 				1*time.Second,
 				MCPSettings{},
@@ -247,3 +251,136 @@ func waitForServer(t *testing.T, ctx context.Context, url string, timeout time.D
 
 	return fmt.Errorf("server at %s did not become ready within %v", url, timeout)
 }
+
+// newInMemoryMCPClient connects an MCPClient to an in-process server.
+func newInMemoryMCPClient(t *testing.T, server *mcp.Server) *MCPClient {
+	t.Helper()
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	session, err := createHTTPClient(nil).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return &MCPClient{baseURL: "memory", client: session}
+}
+
+// newDocsMCPServer serves a readme resource and a review prompt with a required and an optional argument.
+func newDocsMCPServer() *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "docs", Version: "v1"}, nil)
+	server.AddResource(&mcp.Resource{URI: "docs://readme", Name: "readme", MIMEType: "text/markdown"},
+		func(ctx context.Context, req *mcp.ReadResourceRequest) (*mcp.ReadResourceResult, error) {
+			return &mcp.ReadResourceResult{Contents: []*mcp.ResourceContents{{URI: req.Params.URI, Text: "# Readme"}}}, nil
+		})
+	server.AddPrompt(&mcp.Prompt{Name: "review", Arguments: []*mcp.PromptArgument{
+		{Name: "language", Required: true},
+		{Name: "style"},
+	}}, func(ctx context.Context, req *mcp.GetPromptRequest) (*mcp.GetPromptResult, error) {
+		return &mcp.GetPromptResult{Messages: []*mcp.PromptMessage{
+			{Role: "user", Content: &mcp.TextContent{Text: fmt.Sprintf("Review %s code.", req.Params.Arguments["language"])}},
+			{Role: "user", Content: &mcp.TextContent{Text: fmt.Sprintf("Arguments: %d", len(req.Params.Arguments))}},
+		}}, nil
+	})
+	return server
+}
+
+func TestMCPClientResourcesAndPrompts(t *testing.T) {
+	ctx := context.Background()
+	mcpClient := newInMemoryMCPClient(t, newDocsMCPServer())
+
+	resources, err := mcpClient.ListResources(ctx)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	require.Equal(t, "docs://readme", resources[0].URI)
+
+	text, err := mcpClient.ReadResource(ctx, "docs://readme")
+	require.NoError(t, err)
+	require.Equal(t, "# Readme", text)
+
+	prompts, err := mcpClient.ListPrompts(ctx)
+	require.NoError(t, err)
+	require.Len(t, prompts, 1)
+
+	prompt, err := mcpClient.GetPrompt(ctx, "review", map[string]string{"language": "Go", "unrelated": "value"})
+	require.NoError(t, err)
+	require.Equal(t, "Review Go code.\n\nArguments: 1", prompt)
+
+	_, err = mcpClient.GetPrompt(ctx, "review", map[string]string{})
+	require.EqualError(t, err, "prompt review requires argument language")
+
+	_, err = mcpClient.GetPrompt(ctx, "summarize", nil)
+	require.EqualError(t, err, "prompt summarize not found")
+}
+
+func TestMCPClientWithoutResourcesAndPrompts(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "tools-only", Version: "v1"}, nil)
+	mcpClient := newInMemoryMCPClient(t, server)
+
+	resources, err := mcpClient.ListResources(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, resources)
+
+	prompts, err := mcpClient.ListPrompts(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, prompts)
+}
+
+// newResultsMCPServer serves a tool per kind of result, returning the result as is.
+func newResultsMCPServer(results map[string]*mcp.CallToolResult) *mcp.Server {
+	server := mcp.NewServer(&mcp.Implementation{Name: "results", Version: "v1"}, nil)
+	for name, result := range results {
+		server.AddTool(&mcp.Tool{Name: name, InputSchema: &jsonschema.Schema{Type: "object"}},
+			func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+				return result, nil
+			})
+	}
+	return server
+}
+
+func TestMCPExecutorResults(t *testing.T) {
+	ctx := context.Background()
+	mcpClient := newInMemoryMCPClient(t, newResultsMCPServer(map[string]*mcp.CallToolResult{
+		"failing": {
+			IsError: true,
+			Content: []mcp.Content{&mcp.TextContent{Text: "city not found"}},
+		},
+		"chart": {
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "Revenue by month"},
+				&mcp.ImageContent{MIMEType: "image/png", Data: []byte("png-bytes")},
+			},
+		},
+		"structured": {
+			Content:           []mcp.Content{&mcp.TextContent{Text: `{"temperature": 21, "unit": "C"}`}},
+			StructuredContent: map[string]any{"unit": "C", "temperature": 21},
+		},
+		"resource": {
+			Content: []mcp.Content{
+				&mcp.TextContent{Text: "Found the report"},
+				&mcp.EmbeddedResource{Resource: &mcp.ResourceContents{URI: "reports://q3", MIMEType: "application/pdf", Blob: []byte("pdf")}},
+			},
+		},
+	}))
+
+	execute := func(toolName string) ToolResult {
+		executor := &MCPExecutor{MCPClient: mcpClient, ToolName: toolName}
+		result, err := executor.Execute(ctx, ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: toolName, Arguments: "{}"}})
+		require.NoError(t, err)
+		return result
+	}
+
+	failing := execute("failing")
+	require.Equal(t, "city not found", failing.Content)
+	require.Equal(t, "city not found", failing.Error)
+
+	chart := execute("chart")
+	require.Empty(t, chart.Error)
+	require.Equal(t, "Revenue by month\n[image 1 of type image/png]", chart.Content)
+	require.Equal(t, []ToolResultImage{{MIMEType: "image/png", Data: base64.StdEncoding.EncodeToString([]byte("png-bytes"))}}, chart.Images)
+
+	require.JSONEq(t, `{"temperature": 21, "unit": "C"}`, execute("structured").Content)
+
+	require.Equal(t, "Found the report\n[resource reports://q3 of type application/pdf, 3 bytes]", execute("resource").Content)
+}
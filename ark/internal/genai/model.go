@@ -3,6 +3,7 @@ package genai
 import (
 	"context"
 	"fmt"
+	"slices"
 
 	"github.com/openai/openai-go/option"
 	"k8s.io/apimachinery/pkg/types"
@@ -65,6 +66,7 @@ func LoadModel(ctx context.Context, k8sClient client.Client, modelSpec interface
 	modelInstance := &Model{
 		Model:             model,
 		Type:              modelCRD.Spec.Type,
+		Vision:            slices.Contains(modelCRD.Spec.Capabilities, arkv1alpha1.ModelCapabilityVision),
 		telemetryRecorder: telemetryRecorder,
 		eventingRecorder:  eventingRecorder,
 	}
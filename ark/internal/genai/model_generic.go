@@ -22,12 +22,14 @@ type ConfigProvider interface {
 }
 
 type Model struct {
-	Model             string
-	Type              string
-	Properties        map[string]string
-	Provider          ChatCompletionProvider
-	OutputSchema      *runtime.RawExtension
-	SchemaName        string
+	Model        string
+	Type         string
+	Properties   map[string]string
+	Provider     ChatCompletionProvider
+	OutputSchema *runtime.RawExtension
+	SchemaName   string
+	// Vision is set for models that accept images, such as images returned by tools
+	Vision            bool
 	telemetryRecorder telemetry.ModelRecorder
 	eventingRecorder  eventing.ModelRecorder
 }
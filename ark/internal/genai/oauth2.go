@@ -0,0 +1,146 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+const (
+	// tokenRefreshMargin is how long before expiry a cached token is replaced
+	tokenRefreshMargin  = time.Minute
+	tokenRequestTimeout = 30 * time.Second
+)
+
+// DefaultTokenCacheSize is the number of credentials whose tokens are kept by the controller-wide cache.
+const DefaultTokenCacheSize = 256
+
+// TokenCache shares OAuth2 tokens across all tools, MCP servers and A2A servers using the same credentials,
+// so that each token is only minted once per controller and reused until shortly before it expires. When full,
+// the token source of the least recently used credentials is evicted, such as that of a secret that was rotated.
+type TokenCache struct {
+	mu      sync.Mutex
+	maxSize int
+	order   *list.List
+	sources map[string]*list.Element
+}
+
+type tokenCacheEntry struct {
+	key    string
+	source oauth2.TokenSource
+}
+
+func NewTokenCache(maxSize int) *TokenCache {
+	return &TokenCache{
+		maxSize: maxSize,
+		order:   list.New(),
+		sources: make(map[string]*list.Element),
+	}
+}
+
+// DefaultTokenCache is the controller-wide token cache.
+var DefaultTokenCache = NewTokenCache(DefaultTokenCacheSize)
+
+// TokenSource returns the cached token source for the client credentials configuration.
+func (c *TokenCache) TokenSource(config *clientcredentials.Config) oauth2.TokenSource {
+	key := tokenCacheKey(config)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if element, exists := c.sources[key]; exists {
+		c.order.MoveToFront(element)
+		return element.Value.(*tokenCacheEntry).source
+	}
+
+	// Tokens outlive the request that first needed them, so they are fetched with their own context
+	fetchCtx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Timeout: tokenRequestTimeout})
+	fetch := tokenSourceFunc(func() (*oauth2.Token, error) {
+		return config.Token(fetchCtx)
+	})
+	source := oauth2.ReuseTokenSourceWithExpiry(nil, fetch, tokenRefreshMargin)
+	c.sources[key] = c.order.PushFront(&tokenCacheEntry{key: key, source: source})
+	for c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.sources, oldest.Value.(*tokenCacheEntry).key)
+	}
+	return source
+}
+
+// tokenCacheKey identifies a configuration. The secret is hashed so that a rotated secret gets a new token.
+func tokenCacheKey(config *clientcredentials.Config) string {
+	secretHash := sha256.Sum256([]byte(config.ClientSecret))
+	return strings.Join([]string{
+		config.TokenURL,
+		config.ClientID,
+		hex.EncodeToString(secretHash[:]),
+		strings.Join(config.Scopes, " "),
+		config.EndpointParams.Encode(),
+	}, "\x00")
+}
+
+type tokenSourceFunc func() (*oauth2.Token, error)
+
+func (f tokenSourceFunc) Token() (*oauth2.Token, error) {
+	return f()
+}
+
+// ResolveAuthTokenSource resolves the credentials of an auth block and returns its cached token source.
+// It returns nil when no OAuth2 authentication is configured.
+func ResolveAuthTokenSource(ctx context.Context, k8sClient client.Client, auth *arkv1alpha1.Auth, namespace string) (oauth2.TokenSource, error) {
+	if auth == nil || auth.OAuth2 == nil {
+		return nil, nil
+	}
+	spec := auth.OAuth2
+
+	resolver := common.NewValueSourceResolver(k8sClient)
+	clientID, err := resolver.ResolveValueSource(ctx, spec.ClientID, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve oauth2 clientID: %w", err)
+	}
+	clientSecret, err := resolver.ResolveValueSource(ctx, spec.ClientSecret, namespace)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve oauth2 clientSecret: %w", err)
+	}
+
+	config := &clientcredentials.Config{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenURL:     spec.TokenURL,
+		Scopes:       spec.Scopes,
+	}
+	if spec.Audience != "" {
+		config.EndpointParams = url.Values{"audience": {spec.Audience}}
+	}
+	return DefaultTokenCache.TokenSource(config), nil
+}
+
+// setBearerToken sets the Authorization header of a request from a token source.
+func setBearerToken(req *http.Request, tokenSource oauth2.TokenSource) error {
+	if tokenSource == nil {
+		return nil
+	}
+	token, err := tokenSource.Token()
+	if err != nil {
+		return fmt.Errorf("failed to get oauth2 token: %w", err)
+	}
+	token.SetAuthHeader(req)
+	return nil
+}
@@ -0,0 +1,162 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2/clientcredentials"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// newTokenServer returns a token endpoint that issues numbered tokens and counts requests.
+func newTokenServer(t *testing.T, expiresIn int) (*httptest.Server, *atomic.Int32) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client_credentials", r.Form.Get("grant_type"))
+
+		n := requests.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"access_token": fmt.Sprintf("token-%d-%s", n, r.Form.Get("audience")),
+			"token_type":   "Bearer",
+			"expires_in":   expiresIn,
+		})
+	}))
+	t.Cleanup(server.Close)
+	return server, &requests
+}
+
+func TestTokenCacheReusesTokens(t *testing.T) {
+	server, requests := newTokenServer(t, 3600)
+	cache := NewTokenCache(DefaultTokenCacheSize)
+	config := &clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: server.URL}
+
+	first, err := cache.TokenSource(config).Token()
+	require.NoError(t, err)
+	second, err := cache.TokenSource(&clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: server.URL}).Token()
+	require.NoError(t, err)
+
+	assert.Equal(t, "token-1-", first.AccessToken)
+	assert.Equal(t, first.AccessToken, second.AccessToken)
+	assert.Equal(t, int32(1), requests.Load())
+
+	// A rotated secret gets its own token
+	_, err = cache.TokenSource(&clientcredentials.Config{ClientID: "id", ClientSecret: "rotated", TokenURL: server.URL}).Token()
+	require.NoError(t, err)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestTokenCacheEvictsLeastRecentlyUsedCredentials(t *testing.T) {
+	server, requests := newTokenServer(t, 3600)
+	cache := NewTokenCache(2)
+	config := func(secret string) *clientcredentials.Config {
+		return &clientcredentials.Config{ClientID: "id", ClientSecret: secret, TokenURL: server.URL}
+	}
+
+	for _, secret := range []string{"first", "second", "first", "rotated"} {
+		_, err := cache.TokenSource(config(secret)).Token()
+		require.NoError(t, err)
+	}
+	assert.Equal(t, int32(3), requests.Load())
+	assert.Equal(t, 2, cache.order.Len())
+
+	// The token of the least recently used secret was evicted
+	_, err := cache.TokenSource(config("second")).Token()
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), requests.Load())
+	_, err = cache.TokenSource(config("rotated")).Token()
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), requests.Load())
+}
+
+func TestTokenCacheRefreshesBeforeExpiry(t *testing.T) {
+	// Tokens expiring within the refresh margin are replaced on every use
+	server, requests := newTokenServer(t, 30)
+	source := NewTokenCache(DefaultTokenCacheSize).TokenSource(&clientcredentials.Config{ClientID: "id", ClientSecret: "secret", TokenURL: server.URL})
+
+	first, err := source.Token()
+	require.NoError(t, err)
+	second, err := source.Token()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, first.AccessToken, second.AccessToken)
+	assert.Equal(t, int32(2), requests.Load())
+}
+
+func TestResolveAuthTokenSource(t *testing.T) {
+	ctx := context.Background()
+	server, _ := newTokenServer(t, 3600)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "oauth", Namespace: "default"},
+		Data:       map[string][]byte{"secret": []byte("s3cret")},
+	}
+	k8sClient := setupTestClient([]client.Object{secret})
+
+	source, err := ResolveAuthTokenSource(ctx, k8sClient, nil, "default")
+	require.NoError(t, err)
+	assert.Nil(t, source)
+
+	auth := &arkv1alpha1.Auth{OAuth2: &arkv1alpha1.OAuth2ClientCredentials{
+		TokenURL: server.URL,
+		ClientID: arkv1alpha1.ValueSource{Value: "client"},
+		ClientSecret: arkv1alpha1.ValueSource{ValueFrom: &arkv1alpha1.ValueFromSource{
+			SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: "secret"},
+		}},
+		Audience: "api",
+	}}
+	source, err = ResolveAuthTokenSource(ctx, k8sClient, auth, "default")
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "http://example.com", nil)
+	require.NoError(t, setBearerToken(req, source))
+	assert.Equal(t, "Bearer token-1-api", req.Header.Get("Authorization"))
+
+	auth.OAuth2.ClientSecret.ValueFrom.SecretKeyRef.Name = "missing"
+	_, err = ResolveAuthTokenSource(ctx, k8sClient, auth, "default")
+	require.ErrorContains(t, err, "failed to resolve oauth2 clientSecret")
+}
+
+func TestHTTPExecutorSendsBearerToken(t *testing.T) {
+	tokenServer, _ := newTokenServer(t, 3600)
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(r.Header.Get("Authorization")))
+	}))
+	t.Cleanup(api.Close)
+
+	tool := &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "secured", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type: ToolTypeHTTP,
+			HTTP: &arkv1alpha1.HTTPSpec{
+				URL:    api.URL,
+				Method: http.MethodGet,
+				Auth: &arkv1alpha1.Auth{OAuth2: &arkv1alpha1.OAuth2ClientCredentials{
+					TokenURL:     tokenServer.URL,
+					ClientID:     arkv1alpha1.ValueSource{Value: "client"},
+					ClientSecret: arkv1alpha1.ValueSource{Value: "secret"},
+				}},
+			},
+		},
+	}
+	executor := &HTTPExecutor{K8sClient: setupTestClient([]client.Object{tool}), ToolName: "secured", ToolNamespace: "default"}
+
+	result, err := executor.Execute(context.Background(), ToolCall{
+		ID:       "call-1",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: "secured"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer token-1-", result.Content)
+}
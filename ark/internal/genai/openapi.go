@@ -0,0 +1,268 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+)
+
+// openAPIMethods are the operation methods that can be turned into http tools, in the order they are listed.
+var openAPIMethods = []string{"get", "post", "put", "patch", "delete"}
+
+// maxOpenAPIRefDepth bounds $ref expansion so that recursive schemas terminate.
+const maxOpenAPIRefDepth = 8
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// OpenAPIDocument is the part of an OpenAPI 3 document needed to generate tools.
+type OpenAPIDocument struct {
+	Servers    []string
+	Operations []OpenAPIOperation
+}
+
+// OpenAPIOperation is a single operation of an OpenAPI document.
+type OpenAPIOperation struct {
+	OperationID string
+	Method      string
+	Path        string
+	Description string
+	// QueryParameters are the names of the query parameters, in document order
+	QueryParameters []string
+	// HasBody is set when the operation accepts a JSON request body, exposed as the "body" argument
+	HasBody     bool
+	InputSchema map[string]any
+}
+
+// ParseOpenAPIDocument parses an OpenAPI 3 document in JSON or YAML. Local $refs are expanded.
+func ParseOpenAPIDocument(data []byte) (*OpenAPIDocument, error) {
+	jsonData, err := yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	var root map[string]any
+	if err := json.Unmarshal(jsonData, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI document: %w", err)
+	}
+
+	version, _ := root["openapi"].(string)
+	if !strings.HasPrefix(version, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q: only OpenAPI 3 documents are supported", version)
+	}
+
+	doc := &OpenAPIDocument{}
+	servers, _ := root["servers"].([]any)
+	for _, server := range servers {
+		if s, ok := server.(map[string]any); ok {
+			if serverURL, ok := s["url"].(string); ok && serverURL != "" {
+				doc.Servers = append(doc.Servers, serverURL)
+			}
+		}
+	}
+
+	// Only the paths are expanded, components are reached through them
+	paths, _ := resolveOpenAPIRefs(root, root["paths"], nil).(map[string]any)
+	pathNames := make([]string, 0, len(paths))
+	for path := range paths {
+		pathNames = append(pathNames, path)
+	}
+	sort.Strings(pathNames)
+
+	for _, path := range pathNames {
+		pathItem, ok := paths[path].(map[string]any)
+		if !ok {
+			continue
+		}
+		pathParameters, _ := pathItem["parameters"].([]any)
+
+		for _, method := range openAPIMethods {
+			operation, ok := pathItem[method].(map[string]any)
+			if !ok {
+				continue
+			}
+			op, ok := buildOpenAPIOperation(method, path, operation, pathParameters)
+			if ok {
+				doc.Operations = append(doc.Operations, op)
+			}
+		}
+	}
+
+	return doc, nil
+}
+
+// buildOpenAPIOperation generates the tool input schema of an operation. Operations that require a request
+// body in a format other than JSON cannot be called by http tools and are skipped.
+func buildOpenAPIOperation(method, path string, operation map[string]any, pathParameters []any) (OpenAPIOperation, bool) {
+	op := OpenAPIOperation{
+		OperationID: stringField(operation, "operationId"),
+		Method:      strings.ToUpper(method),
+		Path:        path,
+		Description: stringField(operation, "summary"),
+	}
+	if op.OperationID == "" {
+		op.OperationID = method + "_" + strings.Trim(nonIdentifierChars.ReplaceAllString(path, "_"), "_")
+	}
+	if description := stringField(operation, "description"); op.Description == "" {
+		op.Description = description
+	}
+
+	properties := map[string]any{}
+	var required []string
+
+	operationParameters, _ := operation["parameters"].([]any)
+	for _, parameter := range mergeOpenAPIParameters(pathParameters, operationParameters) {
+		name := stringField(parameter, "name")
+		in := stringField(parameter, "in")
+		// Header and cookie parameters cannot be templated into http tools
+		if name == "" || (in != "path" && in != "query") {
+			continue
+		}
+
+		property := map[string]any{"type": "string"}
+		if schema, ok := parameter["schema"].(map[string]any); ok {
+			property = maps.Clone(schema)
+		}
+		if description := stringField(parameter, "description"); description != "" {
+			property["description"] = description
+		}
+		properties[name] = property
+
+		if in == "query" {
+			op.QueryParameters = append(op.QueryParameters, name)
+		}
+		if isRequired, _ := parameter["required"].(bool); isRequired || in == "path" {
+			required = append(required, name)
+		}
+	}
+
+	if requestBody, ok := operation["requestBody"].(map[string]any); ok {
+		bodyRequired, _ := requestBody["required"].(bool)
+		schema, isJSON := jsonRequestBodySchema(requestBody)
+		switch {
+		case isJSON:
+			if description := stringField(requestBody, "description"); description != "" {
+				schema["description"] = description
+			}
+			properties["body"] = schema
+			op.HasBody = true
+			if bodyRequired {
+				required = append(required, "body")
+			}
+		case bodyRequired:
+			return op, false
+		}
+	}
+
+	op.InputSchema = map[string]any{
+		"type":       "object",
+		"properties": properties,
+	}
+	if len(required) > 0 {
+		op.InputSchema["required"] = required
+	}
+	return op, true
+}
+
+// mergeOpenAPIParameters combines path-level and operation-level parameters. Operation parameters
+// override path parameters with the same name and location.
+func mergeOpenAPIParameters(pathParameters, operationParameters []any) []map[string]any {
+	var merged []map[string]any
+	index := map[string]int{}
+	for _, parameter := range slices.Concat(pathParameters, operationParameters) {
+		p, ok := parameter.(map[string]any)
+		if !ok {
+			continue
+		}
+		key := stringField(p, "in") + "/" + stringField(p, "name")
+		if i, exists := index[key]; exists {
+			merged[i] = p
+			continue
+		}
+		index[key] = len(merged)
+		merged = append(merged, p)
+	}
+	return merged
+}
+
+func jsonRequestBodySchema(requestBody map[string]any) (map[string]any, bool) {
+	content, _ := requestBody["content"].(map[string]any)
+	mediaTypes := make([]string, 0, len(content))
+	for mediaType := range content {
+		mediaTypes = append(mediaTypes, mediaType)
+	}
+	sort.Strings(mediaTypes)
+
+	for _, mediaType := range mediaTypes {
+		if mediaType != "application/json" && !strings.HasSuffix(mediaType, "+json") {
+			continue
+		}
+		media, _ := content[mediaType].(map[string]any)
+		if schema, ok := media["schema"].(map[string]any); ok {
+			return maps.Clone(schema), true
+		}
+		return map[string]any{"type": "object"}, true
+	}
+	return nil, false
+}
+
+// resolveOpenAPIRefs replaces local $ref objects with the objects they point to. A $ref that is already being
+// expanded, or one nested too deeply, becomes an empty schema.
+func resolveOpenAPIRefs(root map[string]any, node any, stack []string) any {
+	switch v := node.(type) {
+	case map[string]any:
+		if ref, ok := v["$ref"].(string); ok {
+			if slices.Contains(stack, ref) || len(stack) >= maxOpenAPIRefDepth {
+				return map[string]any{}
+			}
+			target, ok := lookupOpenAPIRef(root, ref)
+			if !ok {
+				return map[string]any{}
+			}
+			return resolveOpenAPIRefs(root, target, append(slices.Clone(stack), ref))
+		}
+		resolved := make(map[string]any, len(v))
+		for key, value := range v {
+			resolved[key] = resolveOpenAPIRefs(root, value, stack)
+		}
+		return resolved
+	case []any:
+		resolved := make([]any, len(v))
+		for i, value := range v {
+			resolved[i] = resolveOpenAPIRefs(root, value, stack)
+		}
+		return resolved
+	default:
+		return v
+	}
+}
+
+func lookupOpenAPIRef(root map[string]any, ref string) (any, bool) {
+	if !strings.HasPrefix(ref, "#/") {
+		return nil, false
+	}
+	var current any = root
+	for _, token := range strings.Split(strings.TrimPrefix(ref, "#/"), "/") {
+		token = strings.ReplaceAll(strings.ReplaceAll(token, "~1", "/"), "~0", "~")
+		m, ok := current.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[token]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func stringField(m map[string]any, key string) string {
+	s, _ := m[key].(string)
+	return s
+}
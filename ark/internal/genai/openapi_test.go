@@ -0,0 +1,118 @@
+package genai
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const petstoreDocument = `
+openapi: 3.0.3
+servers:
+  - url: https://petstore.example.com/v1
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      summary: List all pets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+        - name: X-Request-ID
+          in: header
+          schema:
+            type: string
+    post:
+      operationId: createPet
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Pet'
+  /pets/{petId}:
+    parameters:
+      - name: petId
+        in: path
+        schema:
+          type: string
+    get:
+      description: Info for a specific pet
+    put:
+      operationId: uploadPhoto
+      requestBody:
+        required: true
+        content:
+          application/octet-stream: {}
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        parent:
+          $ref: '#/components/schemas/Pet'
+`
+
+func TestParseOpenAPIDocument(t *testing.T) {
+	doc, err := ParseOpenAPIDocument([]byte(petstoreDocument))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"https://petstore.example.com/v1"}, doc.Servers)
+	require.Len(t, doc.Operations, 3, "the octet-stream upload is skipped")
+
+	list := doc.Operations[0]
+	assert.Equal(t, "listPets", list.OperationID)
+	assert.Equal(t, "GET", list.Method)
+	assert.Equal(t, "List all pets", list.Description)
+	assert.Equal(t, []string{"limit"}, list.QueryParameters)
+	assert.False(t, list.HasBody)
+	properties := list.InputSchema["properties"].(map[string]any)
+	assert.Contains(t, properties, "limit")
+	assert.NotContains(t, properties, "X-Request-ID")
+	assert.NotContains(t, list.InputSchema, "required")
+
+	create := doc.Operations[1]
+	assert.Equal(t, "POST", create.Method)
+	assert.True(t, create.HasBody)
+	assert.Equal(t, []string{"body"}, create.InputSchema["required"])
+	body := create.InputSchema["properties"].(map[string]any)["body"].(map[string]any)
+	assert.Equal(t, "object", body["type"])
+	parent := body["properties"].(map[string]any)["parent"].(map[string]any)
+	assert.Empty(t, parent, "a recursive ref becomes an empty schema")
+
+	get := doc.Operations[2]
+	assert.Equal(t, "get_pets_petId", get.OperationID)
+	assert.Equal(t, "/pets/{petId}", get.Path)
+	assert.Equal(t, "Info for a specific pet", get.Description)
+	assert.Equal(t, []string{"petId"}, get.InputSchema["required"], "path parameters are required")
+}
+
+func TestParseOpenAPIDocumentJSON(t *testing.T) {
+	doc, err := ParseOpenAPIDocument([]byte(`{"openapi":"3.1.0","paths":{"/health":{"get":{"operationId":"health"}}}}`))
+	require.NoError(t, err)
+	require.Len(t, doc.Operations, 1)
+	assert.Equal(t, "health", doc.Operations[0].OperationID)
+	assert.Empty(t, doc.Servers)
+}
+
+func TestParseOpenAPIDocumentRejectsSwagger2(t *testing.T) {
+	_, err := ParseOpenAPIDocument([]byte(`{"swagger":"2.0","paths":{}}`))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "only OpenAPI 3 documents are supported")
+}
+
+func TestSubstituteURLParametersDropsMissingQueryParameters(t *testing.T) {
+	executor := &HTTPExecutor{}
+	urlTemplate := "https://example.com/pets/{petId}?limit={limit}&sort={sort}&fixed=1"
+
+	assert.Equal(t, "https://example.com/pets/7?limit=10&fixed=1",
+		executor.substituteURLParameters(urlTemplate, map[string]any{"petId": 7, "limit": 10}))
+	assert.Equal(t, "https://example.com/pets/{petId}?fixed=1",
+		executor.substituteURLParameters(urlTemplate, nil))
+}
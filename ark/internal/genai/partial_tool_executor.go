@@ -16,18 +16,14 @@ type PartialToolExecutor struct {
 	Partial      *arkv1alpha1.ToolPartial
 	K8sClient    client.Client
 	Namespace    string
+	// LenientArguments enables repair of malformed JSON arguments
+	LenientArguments bool
 }
 
 func (p *PartialToolExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
-	var agentParams map[string]any
-	if call.Function.Arguments != "" {
-		if err := json.Unmarshal([]byte(call.Function.Arguments), &agentParams); err != nil {
-			return ToolResult{
-				ID:    call.ID,
-				Name:  call.Function.Name,
-				Error: fmt.Sprintf("failed to parse arguments: %v", err),
-			}, fmt.Errorf("failed to parse arguments: %w", err)
-		}
+	agentParams, _, err := parseToolArguments(call.Function.Arguments, p.LenientArguments)
+	if err != nil {
+		return invalidArgumentsResult(call, fmt.Sprintf("arguments are not valid JSON: %v", err)), nil
 	}
 
 	mergedParams := map[string]any{}
@@ -117,11 +117,11 @@ func ResolveBodyTemplate(ctx context.Context, k8sClient client.Client, namespace
 		return "", nil
 	}
 
-	templateData := make(map[string]any)
-
-	if inputData != nil {
-		templateData["input"] = inputData
+	// Templates can refer to .input fields even when the call has no arguments
+	if inputData == nil {
+		inputData = map[string]any{}
 	}
+	templateData := map[string]any{"input": inputData}
 
 	if len(parameters) > 0 {
 		paramData, err := resolveQueryParameters(ctx, k8sClient, namespace, parameters)
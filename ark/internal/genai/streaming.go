@@ -33,6 +33,10 @@ type StreamMetadata struct {
 	Agent          string             `json:"agent,omitempty"`
 	Model          string             `json:"model,omitempty"`
 	CompletedQuery *arkv1alpha1.Query `json:"completedQuery,omitempty"`
+	// ToolProgress is set on chunks reporting the progress of a tool call
+	ToolProgress *ToolProgressMetadata `json:"toolProgress,omitempty"`
+	// A2ATaskUpdate is set on chunks relaying an update of an A2A task
+	A2ATaskUpdate *A2ATaskUpdateMetadata `json:"a2aTaskUpdate,omitempty"`
 }
 
 // ChunkWithMetadata wraps an OpenAI chunk with ARK metadata
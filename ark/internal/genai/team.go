@@ -9,6 +9,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/eventing"
 	"mckinsey.com/ark/internal/telemetry"
 )
@@ -21,6 +22,10 @@ type Team struct {
 	MaxTurns          *int
 	Selector          *arkv1alpha1.TeamSelectorSpec
 	Graph             *arkv1alpha1.TeamGraphSpec
+	Vote              *arkv1alpha1.TeamVoteSpec
+	HistoryPolicies   map[string]*arkv1alpha1.TeamMemberHistoryPolicy
+	State             *arkv1alpha1.TeamStateSpec
+	TerminateWhen     string
 	telemetryRecorder telemetry.TeamRecorder
 	eventingRecorder  eventing.TeamRecorder
 	telemetry         telemetry.Provider
@@ -29,6 +34,8 @@ type Team struct {
 	Namespace         string
 	memory            MemoryInterface
 	eventStream       EventStreamInterface
+	// voteResult is set by the vote strategy once the vote is decided
+	voteResult *VoteResult
 }
 
 // FullName returns the namespace/name format for the team
@@ -41,9 +48,15 @@ func (t *Team) Execute(ctx context.Context, userInput Message, history []Message
 		return nil, fmt.Errorf("team %s has no members configured", t.FullName())
 	}
 
+	ctx, err := enterExecution(ctx, MemberTypeTeam, t.Namespace, t.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	// Store memory and streaming parameters for member execution
 	t.memory = memory
 	t.eventStream = eventStream
+	t.voteResult = nil
 
 	var execFunc func(context.Context, Message, []Message) ([]Message, error)
 	switch t.Strategy {
@@ -55,12 +68,28 @@ func (t *Team) Execute(ctx context.Context, userInput Message, history []Message
 		execFunc = t.executeSelector
 	case "graph":
 		execFunc = t.executeGraph
+	case "vote":
+		execFunc = t.executeVote
 	default:
 		return nil, fmt.Errorf("unsupported strategy %s for team %s", t.Strategy, t.FullName())
 	}
 
+	var state *TeamState
+	if t.State != nil {
+		var err error
+		state, err = NewTeamState(t.State.Initial)
+		if err != nil {
+			return nil, fmt.Errorf("team %s: %w", t.FullName(), err)
+		}
+		ctx = WithTeamState(ctx, state)
+	}
+
 	messages, err := t.executeWithTracking(execFunc, ctx, userInput, history)
-	return &ExecutionResult{Messages: messages}, err
+	result := &ExecutionResult{Messages: messages, Vote: t.voteResult}
+	if state != nil {
+		result.TeamState = state.Snapshot()
+	}
+	return result, err
 }
 
 func (t *Team) executeSequential(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
@@ -103,6 +132,10 @@ func (t *Team) executeSequential(ctx context.Context, userInput Message, history
 		t.telemetryRecorder.RecordSuccess(turnSpan)
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", i), operationData)
+
+		if terminate, err := t.shouldTerminate(ctx); err != nil || terminate {
+			return newMessages, err
+		}
 	}
 
 	return newMessages, nil
@@ -162,6 +195,10 @@ func (t *Team) executeRoundRobin(ctx context.Context, userInput Message, history
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", messageCount), operationData)
 
+		if terminate, err := t.shouldTerminate(ctx); err != nil || terminate {
+			return newMessages, err
+		}
+
 		messageCount++                                   // Increment message count
 		memberIndex = (memberIndex + 1) % len(t.Members) // Move to next agent in round-robin
 	}
@@ -180,6 +217,12 @@ func (t *Team) GetDescription() string {
 }
 
 func MakeTeam(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Team, telemetryProvider telemetry.Provider, eventingProvider eventing.Provider) (*Team, error) {
+	// Nested teams are loaded recursively, so a cycle of teams would never finish loading
+	ctx, err := enterExecution(ctx, MemberTypeTeam, crd.Namespace, crd.Name)
+	if err != nil {
+		return nil, err
+	}
+
 	members, err := loadTeamMembers(ctx, k8sClient, crd, telemetryProvider, eventingProvider)
 	if err != nil {
 		return nil, err
@@ -193,6 +236,10 @@ func MakeTeam(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Tea
 		MaxTurns:          crd.Spec.MaxTurns,
 		Selector:          crd.Spec.Selector,
 		Graph:             crd.Spec.Graph,
+		Vote:              crd.Spec.Vote,
+		HistoryPolicies:   loadHistoryPolicies(crd),
+		State:             crd.Spec.State,
+		TerminateWhen:     crd.Spec.TerminateWhen,
 		telemetryRecorder: telemetryProvider.TeamRecorder(),
 		eventingRecorder:  eventingProvider.TeamRecorder(),
 		telemetry:         telemetryProvider,
@@ -210,12 +257,52 @@ func loadTeamMembers(ctx context.Context, k8sClient client.Client, crd *arkv1alp
 		if err != nil {
 			return nil, err
 		}
+
+		if agent, ok := member.(*Agent); ok && crd.Spec.Instructions != "" {
+			agent.Instructions, err = resolveTeamInstructions(ctx, k8sClient, crd, memberSpec.Name)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if agent, ok := member.(*Agent); ok && crd.Spec.State != nil {
+			agent.Tools.RegisterTool(GetTeamStateGetTool(), &TeamStateGetExecutor{})
+			agent.Tools.RegisterTool(GetTeamStateSetTool(), &TeamStateSetExecutor{})
+		}
 		members = append(members, member)
 	}
 
 	return members, nil
 }
 
+// resolveTeamInstructions renders the team instructions for a member. Team parameters are available
+// by name, along with Team and Member.
+func resolveTeamInstructions(ctx context.Context, k8sClient client.Client, crd *arkv1alpha1.Team, memberName string) (string, error) {
+	params, err := resolveQueryParameters(ctx, k8sClient, crd.Namespace, crd.Spec.Parameters)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve parameters for team %s/%s: %w", crd.Namespace, crd.Name, err)
+	}
+
+	templateData := toAnyMap(params)
+	templateData["Team"] = crd.Name
+	templateData["Member"] = memberName
+
+	instructions, err := common.ResolveTemplate(crd.Spec.Instructions, templateData)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve instructions for team %s/%s: %w", crd.Namespace, crd.Name, err)
+	}
+	return instructions, nil
+}
+
+func loadHistoryPolicies(crd *arkv1alpha1.Team) map[string]*arkv1alpha1.TeamMemberHistoryPolicy {
+	policies := make(map[string]*arkv1alpha1.TeamMemberHistoryPolicy)
+	for _, memberSpec := range crd.Spec.Members {
+		if memberSpec.HistoryPolicy != nil {
+			policies[memberSpec.Name] = memberSpec.HistoryPolicy
+		}
+	}
+	return policies
+}
+
 func (t *Team) executeWithTracking(execFunc func(context.Context, Message, []Message) ([]Message, error), ctx context.Context, userInput Message, history []Message) ([]Message, error) {
 	maxTurns := 0
 	if t.MaxTurns != nil {
@@ -269,7 +356,13 @@ func (t *Team) executeMemberAndAccumulate(ctx context.Context, member TeamMember
 	}
 	ctx = t.eventingRecorder.Start(ctx, "TeamMember", fmt.Sprintf("Executing member %s in team %s", member.GetName(), t.Name), operationData)
 
-	result, err := member.Execute(ctx, userInput, *messages, t.memory, t.eventStream)
+	history, err := t.memberHistory(ctx, member, *messages)
+	if err != nil {
+		t.eventingRecorder.Fail(ctx, "TeamMember", fmt.Sprintf("Team member history preparation failed: %v", err), err, operationData)
+		return err
+	}
+
+	result, err := member.Execute(ctx, userInput, history, t.memory, t.eventStream)
 	if err != nil {
 		// Still accumulate messages even on error if result is not nil
 		if result != nil {
@@ -295,6 +388,10 @@ func loadTeamMember(ctx context.Context, k8sClient client.Client, memberSpec ark
 		if err := k8sClient.Get(ctx, key, &agentCRD); err != nil {
 			return nil, fmt.Errorf("failed to get agent %s for team %s: %w", memberSpec.Name, teamName, err)
 		}
+		// The team-level model override only applies to this team, so it is set on the local copy
+		if memberSpec.ModelRef != nil {
+			agentCRD.Spec.ModelRef = memberSpec.ModelRef.DeepCopy()
+		}
 		return MakeAgent(ctx, k8sClient, &agentCRD, telemetryProvider, eventingProvider)
 
 	case "team":
@@ -18,13 +18,6 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 		memberMap[member.GetName()] = member
 	}
 
-	transitionMap := make(map[string]string)
-	if t.Graph != nil {
-		for _, edge := range t.Graph.Edges {
-			transitionMap[edge.From] = edge.To
-		}
-	}
-
 	currentMemberName := t.Members[0].GetName()
 
 	for turns := 0; ; turns++ {
@@ -64,7 +57,14 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", turns), operationData)
 
-		nextMember := transitionMap[currentMemberName]
+		if terminate, err := t.shouldTerminate(ctx); err != nil || terminate {
+			return newMessages, err
+		}
+
+		nextMember, err := t.nextGraphMember(ctx, currentMemberName)
+		if err != nil {
+			return newMessages, err
+		}
 		if nextMember == "" {
 			break
 		}
@@ -78,3 +78,23 @@ func (t *Team) executeGraph(ctx context.Context, userInput Message, history []Me
 
 	return newMessages, nil
 }
+
+// nextGraphMember follows the first outgoing edge of a member whose condition holds. Edges without a condition always hold.
+func (t *Team) nextGraphMember(ctx context.Context, from string) (string, error) {
+	if t.Graph == nil {
+		return "", nil
+	}
+	for _, edge := range t.Graph.Edges {
+		if edge.From != from {
+			continue
+		}
+		matched, err := t.evaluateCondition(ctx, edge.Condition)
+		if err != nil {
+			return "", fmt.Errorf("edge %s -> %s in team %s: %w", edge.From, edge.To, t.FullName(), err)
+		}
+		if matched {
+			return edge.To, nil
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,129 @@
+package genai
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+	"text/template"
+
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const defaultSummaryPrompt = `Summarize the following team conversation for {{.Member}}. Keep decisions, facts and open questions. Leave out tool call details.
+
+{{.History}}`
+
+type SummaryTemplateData struct {
+	Member  string
+	History string
+}
+
+// memberHistory returns the part of the accumulated team history visible to a member.
+func (t *Team) memberHistory(ctx context.Context, member TeamMember, messages []Message) ([]Message, error) {
+	policy := t.HistoryPolicies[member.GetName()]
+	if policy == nil {
+		return messages, nil
+	}
+
+	switch policy.Type {
+	case "", arkv1alpha1.HistoryPolicyFull:
+		return messages, nil
+	case arkv1alpha1.HistoryPolicyUserInput:
+		return nil, nil
+	case arkv1alpha1.HistoryPolicyLastN:
+		if policy.LastN == nil {
+			return nil, fmt.Errorf("history policy lastN for member %s requires lastN", member.GetName())
+		}
+		return lastNMessages(messages, *policy.LastN), nil
+	case arkv1alpha1.HistoryPolicyMembers:
+		return messagesFromMembers(messages, policy.Members), nil
+	case arkv1alpha1.HistoryPolicySummary:
+		return t.summarizeHistory(ctx, member, policy, messages)
+	default:
+		return nil, fmt.Errorf("unsupported history policy %s for member %s", policy.Type, member.GetName())
+	}
+}
+
+// lastNMessages keeps the last n messages, dropping tool results whose tool call was cut off.
+func lastNMessages(messages []Message, n int) []Message {
+	if n <= 0 {
+		return nil
+	}
+	if len(messages) > n {
+		messages = messages[len(messages)-n:]
+	}
+	for len(messages) > 0 && messages[0].OfTool != nil {
+		messages = messages[1:]
+	}
+	return slices.Clone(messages)
+}
+
+// messagesFromMembers keeps user messages and the text answers of the given members, without tool chatter.
+func messagesFromMembers(messages []Message, members []string) []Message {
+	var filtered []Message
+	for _, msg := range messages {
+		switch {
+		case msg.OfUser != nil:
+			filtered = append(filtered, msg)
+		case msg.OfAssistant != nil:
+			m := msg.OfAssistant
+			if !slices.Contains(members, m.Name.Value) || m.Content.OfString.Value == "" {
+				continue
+			}
+			answer := NewAssistantMessage(m.Content.OfString.Value)
+			answer.OfAssistant.Name = m.Name
+			filtered = append(filtered, answer)
+		}
+	}
+	return filtered
+}
+
+func (t *Team) summarizeHistory(ctx context.Context, member TeamMember, policy *arkv1alpha1.TeamMemberHistoryPolicy, messages []Message) ([]Message, error) {
+	if len(messages) == 0 {
+		return nil, nil
+	}
+	if policy.Summarizer == "" {
+		return nil, fmt.Errorf("history policy summary for member %s requires summarizer", member.GetName())
+	}
+
+	promptTemplate := defaultSummaryPrompt
+	if policy.SummaryPrompt != "" {
+		promptTemplate = policy.SummaryPrompt
+	}
+
+	tmpl, err := template.New("summary").Parse(promptTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, SummaryTemplateData{Member: member.GetName(), History: buildHistory(messages)}); err != nil {
+		return nil, err
+	}
+
+	var agentCRD arkv1alpha1.Agent
+	key := types.NamespacedName{Name: policy.Summarizer, Namespace: t.Namespace}
+	if err := t.Client.Get(ctx, key, &agentCRD); err != nil {
+		return nil, fmt.Errorf("failed to get summarizer agent %s in namespace %s: %w", policy.Summarizer, t.Namespace, err)
+	}
+
+	summarizer, err := MakeAgent(ctx, t.Client, &agentCRD, t.telemetry, t.eventing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create summarizer agent: %w", err)
+	}
+
+	result, err := summarizer.Execute(ctx, NewUserMessage(buf.String()), nil, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("summarizer agent call failed: %w", err)
+	}
+
+	summary := ExtractLastAssistantMessageContent(result.Messages)
+	if summary == "" {
+		return nil, fmt.Errorf("summarizer agent returned no summary")
+	}
+
+	return []Message{NewUserMessage("Summary of the conversation so far:\n" + summary)}, nil
+}
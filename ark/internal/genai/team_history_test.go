@@ -0,0 +1,132 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/openai/openai-go/packages/param"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func namedAssistantMessage(name, content string) Message {
+	msg := NewAssistantMessage(content)
+	msg.OfAssistant.Name = param.Opt[string]{Value: name}
+	return msg
+}
+
+func teamHistoryFixture() []Message {
+	toolCall := namedAssistantMessage("researcher", "")
+	toolCall.OfAssistant.ToolCalls = []openai.ChatCompletionMessageToolCallParam{
+		{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunctionParam{Name: "search", Arguments: "{}"}},
+	}
+
+	return []Message{
+		NewUserMessage("question"),
+		toolCall,
+		ToolMessage("search results", "call-1"),
+		namedAssistantMessage("researcher", "findings"),
+		namedAssistantMessage("analyst", "analysis"),
+	}
+}
+
+func TestMemberHistory(t *testing.T) {
+	lastTwo := 2
+	lastThree := 3
+
+	tests := []struct {
+		name     string
+		policy   *arkv1alpha1.TeamMemberHistoryPolicy
+		wantLen  int
+		wantText []string
+		wantErr  bool
+	}{
+		{
+			name:    "no policy passes full history",
+			wantLen: 5,
+		},
+		{
+			name:    "full",
+			policy:  &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicyFull},
+			wantLen: 5,
+		},
+		{
+			name:    "user input only",
+			policy:  &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicyUserInput},
+			wantLen: 0,
+		},
+		{
+			name:     "last n",
+			policy:   &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicyLastN, LastN: &lastTwo},
+			wantLen:  2,
+			wantText: []string{"findings", "analysis"},
+		},
+		{
+			name:     "last n drops orphaned tool results",
+			policy:   &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicyLastN, LastN: &lastThree},
+			wantLen:  2,
+			wantText: []string{"findings", "analysis"},
+		},
+		{
+			name:    "last n without count",
+			policy:  &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicyLastN},
+			wantErr: true,
+		},
+		{
+			name:     "members drops tool chatter and other members",
+			policy:   &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicyMembers, Members: []string{"researcher"}},
+			wantLen:  2,
+			wantText: []string{"question", "findings"},
+		},
+		{
+			name:    "summary without summarizer",
+			policy:  &arkv1alpha1.TeamMemberHistoryPolicy{Type: arkv1alpha1.HistoryPolicySummary},
+			wantErr: true,
+		},
+		{
+			name:    "unsupported policy",
+			policy:  &arkv1alpha1.TeamMemberHistoryPolicy{Type: "everything"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			member := &mockTeamMember{name: "writer"}
+			team := &Team{HistoryPolicies: map[string]*arkv1alpha1.TeamMemberHistoryPolicy{}}
+			if tt.policy != nil {
+				team.HistoryPolicies["writer"] = tt.policy
+			}
+
+			history, err := team.memberHistory(context.Background(), member, teamHistoryFixture())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, history, tt.wantLen)
+
+			for i, text := range tt.wantText {
+				assert.Equal(t, text, messageContent(history[i]))
+			}
+		})
+	}
+}
+
+func TestMessagesFromMembersStripsToolCalls(t *testing.T) {
+	history := messagesFromMembers(teamHistoryFixture(), []string{"researcher", "analyst"})
+
+	require.Len(t, history, 3)
+	for _, msg := range history {
+		assert.Nil(t, msg.OfTool)
+		if msg.OfAssistant != nil {
+			assert.Empty(t, msg.OfAssistant.ToolCalls)
+		}
+	}
+	assert.Equal(t, "analyst", history[2].OfAssistant.Name.Value)
+}
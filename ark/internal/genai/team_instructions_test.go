@@ -0,0 +1,54 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func TestResolveTeamInstructions(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, corev1.AddToScheme(scheme))
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: "team-config", Namespace: "default"},
+			Data:       map[string]string{"tone": "formal"},
+		},
+	).Build()
+
+	team := &arkv1alpha1.Team{
+		ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "default"},
+		Spec: arkv1alpha1.TeamSpec{
+			Instructions: "You are {{.Member}} in team {{.Team}}. Use a {{.tone}} tone.",
+			Parameters: []arkv1alpha1.Parameter{
+				{
+					Name: "tone",
+					ValueFrom: &arkv1alpha1.ValueFromSource{
+						ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "team-config"},
+							Key:                  "tone",
+						},
+					},
+				},
+			},
+		},
+	}
+
+	instructions, err := resolveTeamInstructions(context.Background(), fakeClient, team, "reviewer")
+	require.NoError(t, err)
+	assert.Equal(t, "You are reviewer in team support. Use a formal tone.", instructions)
+
+	team.Spec.Parameters[0].ValueFrom.ConfigMapKeyRef.Key = "missing"
+	_, err = resolveTeamInstructions(context.Background(), fakeClient, team, "reviewer")
+	assert.Error(t, err)
+}
@@ -197,6 +197,36 @@ func (t *Team) selectFromGraphConstraints(ctx context.Context, messages []Messag
 	}
 }
 
+// buildLegalTransitions maps each member to the members it may hand over to, skipping edges whose condition is not met.
+func (t *Team) buildLegalTransitions(ctx context.Context) (map[string][]TeamMember, error) {
+	// Map from member name to list of TeamMember objects (not strings)
+	legalTransitions := make(map[string][]TeamMember)
+	if t.Graph == nil {
+		return legalTransitions, nil
+	}
+
+	// Build member lookup map for converting names to TeamMember objects
+	memberLookup := make(map[string]TeamMember)
+	for _, member := range t.Members {
+		memberLookup[member.GetName()] = member
+	}
+
+	for _, edge := range t.Graph.Edges {
+		matched, err := t.evaluateCondition(ctx, edge.Condition)
+		if err != nil {
+			return nil, fmt.Errorf("edge %s -> %s in team %s: %w", edge.From, edge.To, t.FullName(), err)
+		}
+		if !matched {
+			continue
+		}
+		// Convert edge.To (string) to TeamMember object
+		if member, exists := memberLookup[edge.To]; exists {
+			legalTransitions[edge.From] = append(legalTransitions[edge.From], member)
+		}
+	}
+	return legalTransitions, nil
+}
+
 //nolint:gocognit // Complex function orchestrating selector logic with graph constraints, but cohesive responsibilities
 func (t *Team) executeSelector(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
 	messages := append([]Message{}, history...)
@@ -212,27 +242,15 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 		return newMessages, err
 	}
 
-	// Build legal transitions map if graph constraints are provided
-	// Map from member name to list of TeamMember objects (not strings)
-	legalTransitions := make(map[string][]TeamMember)
-	if t.Graph != nil {
-		// Build member lookup map for converting names to TeamMember objects
-		memberLookup := make(map[string]TeamMember)
-		for _, member := range t.Members {
-			memberLookup[member.GetName()] = member
-		}
-
-		for _, edge := range t.Graph.Edges {
-			// Convert edge.To (string) to TeamMember object
-			if member, exists := memberLookup[edge.To]; exists {
-				legalTransitions[edge.From] = append(legalTransitions[edge.From], member)
-			}
-		}
-	}
-
 	previousMember := ""
 
 	for turn := 0; ; turn++ {
+		// Edge conditions read the team state, so legal transitions are rebuilt every turn
+		legalTransitions, err := t.buildLegalTransitions(ctx)
+		if err != nil {
+			return newMessages, err
+		}
+
 		// Determine next member based on graph constraints (if any)
 		nextMember, err := t.determineNextMember(ctx, messages, tmpl, previousMember, legalTransitions)
 		if err != nil {
@@ -273,6 +291,10 @@ func (t *Team) executeSelector(ctx context.Context, userInput Message, history [
 		turnSpan.End()
 		t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", turn), operationData)
 
+		if terminate, err := t.shouldTerminate(ctx); err != nil || terminate {
+			return newMessages, err
+		}
+
 		previousMember = nextMember.GetName()
 
 		if t.MaxTurns != nil && turn+1 >= *t.MaxTurns {
@@ -0,0 +1,200 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/itchyny/gojq"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+type teamStateKeyType struct{}
+
+var teamStateKey = teamStateKeyType{}
+
+// TeamState is the key/value blackboard shared by the members of a team while it runs.
+type TeamState struct {
+	mu     sync.RWMutex
+	values map[string]any
+}
+
+func NewTeamState(initial *runtime.RawExtension) (*TeamState, error) {
+	values := make(map[string]any)
+	if initial != nil && len(initial.Raw) > 0 {
+		if err := json.Unmarshal(initial.Raw, &values); err != nil {
+			return nil, fmt.Errorf("initial team state must be a JSON object: %w", err)
+		}
+	}
+	return &TeamState{values: values}, nil
+}
+
+func (s *TeamState) Get(key string) (any, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, exists := s.values[key]
+	return value, exists
+}
+
+func (s *TeamState) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.values[key] = value
+}
+
+// Snapshot returns a copy of the state that is safe to read while members keep writing.
+func (s *TeamState) Snapshot() map[string]any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshot := make(map[string]any, len(s.values))
+	raw, err := json.Marshal(s.values)
+	if err != nil {
+		return snapshot
+	}
+	_ = json.Unmarshal(raw, &snapshot)
+	return snapshot
+}
+
+func WithTeamState(ctx context.Context, state *TeamState) context.Context {
+	return context.WithValue(ctx, teamStateKey, state)
+}
+
+func GetTeamState(ctx context.Context) *TeamState {
+	if state, ok := ctx.Value(teamStateKey).(*TeamState); ok {
+		return state
+	}
+	return nil
+}
+
+// EvaluateStateCondition runs a jq expression against the team state and reports whether it yielded true.
+func EvaluateStateCondition(expr string, state map[string]any) (bool, error) {
+	query, err := gojq.Parse(expr)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse condition '%s': %w", expr, err)
+	}
+
+	iter := query.Run(state)
+	v, ok := iter.Next()
+	if !ok {
+		return false, nil
+	}
+	if err, ok := v.(error); ok {
+		return false, fmt.Errorf("condition '%s' execution error: %w", expr, err)
+	}
+	result, ok := v.(bool)
+	return ok && result, nil
+}
+
+// evaluateCondition evaluates a condition against the team state in the context.
+func (t *Team) evaluateCondition(ctx context.Context, expr string) (bool, error) {
+	if expr == "" {
+		return true, nil
+	}
+	snapshot := map[string]any{}
+	if state := GetTeamState(ctx); state != nil {
+		snapshot = state.Snapshot()
+	}
+	return EvaluateStateCondition(expr, snapshot)
+}
+
+// shouldTerminate reports whether the terminateWhen rule of the team is met.
+func (t *Team) shouldTerminate(ctx context.Context) (bool, error) {
+	if t.TerminateWhen == "" {
+		return false, nil
+	}
+	terminate, err := t.evaluateCondition(ctx, t.TerminateWhen)
+	if err != nil {
+		return false, fmt.Errorf("team %s terminateWhen failed: %w", t.FullName(), err)
+	}
+	return terminate, nil
+}
+
+type TeamStateGetExecutor struct{}
+
+func (e *TeamStateGetExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	state := GetTeamState(ctx)
+	if state == nil {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: "team state is not available"}, fmt.Errorf("team state is not available")
+	}
+
+	var arguments struct {
+		Key string `json:"key"`
+	}
+	if call.Function.Arguments != "" {
+		if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+			return ToolResult{ID: call.ID, Name: call.Function.Name, Error: fmt.Sprintf("failed to parse arguments: %v", err)}, fmt.Errorf("failed to parse arguments: %w", err)
+		}
+	}
+
+	var value any = state.Snapshot()
+	if arguments.Key != "" {
+		value, _ = state.Get(arguments.Key)
+	}
+
+	content, err := json.Marshal(value)
+	if err != nil {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: fmt.Sprintf("failed to marshal state: %v", err)}, fmt.Errorf("failed to marshal state: %w", err)
+	}
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: string(content)}, nil
+}
+
+type TeamStateSetExecutor struct{}
+
+func (e *TeamStateSetExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	state := GetTeamState(ctx)
+	if state == nil {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: "team state is not available"}, fmt.Errorf("team state is not available")
+	}
+
+	var arguments struct {
+		Key   string `json:"key"`
+		Value any    `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(call.Function.Arguments), &arguments); err != nil {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: fmt.Sprintf("failed to parse arguments: %v", err)}, fmt.Errorf("failed to parse arguments: %w", err)
+	}
+	if arguments.Key == "" {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Error: "key is required"}, fmt.Errorf("key is required")
+	}
+
+	state.Set(arguments.Key, arguments.Value)
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: fmt.Sprintf("stored %s", arguments.Key)}, nil
+}
+
+func GetTeamStateGetTool() ToolDefinition {
+	return ToolDefinition{
+		Name:        BuiltinToolTeamStateGet,
+		Description: "Read a value from the state shared by the team. Returns the whole state when no key is given",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"key": map[string]any{
+					"type":        "string",
+					"description": "The key to read",
+				},
+			},
+		},
+	}
+}
+
+func GetTeamStateSetTool() ToolDefinition {
+	return ToolDefinition{
+		Name:        BuiltinToolTeamStateSet,
+		Description: "Store a value in the state shared by the team so that other members can read it",
+		Parameters: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"key": map[string]any{
+					"type":        "string",
+					"description": "The key to write",
+				},
+				"value": map[string]any{
+					"description": "The JSON value to store",
+				},
+			},
+			"required": []string{"key", "value"},
+		},
+	}
+}
@@ -0,0 +1,159 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// stateMember writes its updates to the team state through the team_state_set tool and records its turns
+type stateMember struct {
+	mockTeamMember
+	updates map[string]any
+	turns   *[]string
+}
+
+func (m *stateMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) (*ExecutionResult, error) {
+	*m.turns = append(*m.turns, m.name)
+	for key, value := range m.updates {
+		arguments, _ := json.Marshal(map[string]any{"key": key, "value": value})
+		call := ToolCall{ID: "call-" + key}
+		call.Function.Name = BuiltinToolTeamStateSet
+		call.Function.Arguments = string(arguments)
+		if _, err := (&TeamStateSetExecutor{}).Execute(ctx, call); err != nil {
+			return nil, err
+		}
+	}
+	return &ExecutionResult{Messages: []Message{NewAssistantMessage(m.name + " done")}}, nil
+}
+
+func newStateTeam(strategy string, members ...TeamMember) *Team {
+	maxTurns := 10
+	return &Team{
+		Name:              "reviewers",
+		Namespace:         "default",
+		Strategy:          strategy,
+		Members:           members,
+		MaxTurns:          &maxTurns,
+		State:             &arkv1alpha1.TeamStateSpec{Initial: &runtime.RawExtension{Raw: []byte(`{"approved": false}`)}},
+		telemetryRecorder: noop.NewProvider().TeamRecorder(),
+		eventingRecorder:  eventnoop.NewProvider().TeamRecorder(),
+	}
+}
+
+func TestEvaluateStateCondition(t *testing.T) {
+	state := map[string]any{"approved": true, "score": 0.4, "issues": []any{"typo"}}
+
+	tests := []struct {
+		name    string
+		expr    string
+		want    bool
+		wantErr bool
+	}{
+		{name: "boolean field", expr: ".approved", want: true},
+		{name: "comparison", expr: ".score > 0.5", want: false},
+		{name: "array length", expr: ".issues | length > 0", want: true},
+		{name: "missing field", expr: ".missing", want: false},
+		{name: "non boolean result", expr: ".score", want: false},
+		{name: "invalid expression", expr: ".score >", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateStateCondition(tt.expr, state)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTeamStateExecutors(t *testing.T) {
+	state, err := NewTeamState(&runtime.RawExtension{Raw: []byte(`{"draft": "v1"}`)})
+	require.NoError(t, err)
+	ctx := WithTeamState(context.Background(), state)
+
+	set := ToolCall{ID: "1"}
+	set.Function.Name = BuiltinToolTeamStateSet
+	set.Function.Arguments = `{"key": "issues", "value": ["typo"]}`
+	_, err = (&TeamStateSetExecutor{}).Execute(ctx, set)
+	require.NoError(t, err)
+
+	get := ToolCall{ID: "2"}
+	get.Function.Name = BuiltinToolTeamStateGet
+	get.Function.Arguments = `{"key": "issues"}`
+	result, err := (&TeamStateGetExecutor{}).Execute(ctx, get)
+	require.NoError(t, err)
+	assert.JSONEq(t, `["typo"]`, result.Content)
+
+	get.Function.Arguments = `{}`
+	result, err = (&TeamStateGetExecutor{}).Execute(ctx, get)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"draft": "v1", "issues": ["typo"]}`, result.Content)
+
+	_, err = (&TeamStateGetExecutor{}).Execute(context.Background(), get)
+	assert.Error(t, err)
+
+	_, err = NewTeamState(&runtime.RawExtension{Raw: []byte(`[1]`)})
+	assert.Error(t, err)
+}
+
+func TestGraphEdgeConditions(t *testing.T) {
+	var turns []string
+	researcher := &stateMember{mockTeamMember: mockTeamMember{name: "researcher"}, turns: &turns}
+	reviewer := &stateMember{mockTeamMember: mockTeamMember{name: "reviewer"}, updates: map[string]any{"approved": true}, turns: &turns}
+	writer := &stateMember{mockTeamMember: mockTeamMember{name: "writer"}, turns: &turns}
+
+	team := newStateTeam("graph", researcher, reviewer, writer)
+	team.Graph = &arkv1alpha1.TeamGraphSpec{
+		Edges: []arkv1alpha1.TeamGraphEdge{
+			{From: "researcher", To: "writer", Condition: ".approved"},
+			{From: "researcher", To: "reviewer"},
+			{From: "reviewer", To: "researcher"},
+		},
+	}
+
+	result, err := team.Execute(context.Background(), NewUserMessage("write a report"), nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"researcher", "reviewer", "researcher", "writer"}, turns)
+	assert.Equal(t, map[string]any{"approved": true}, result.TeamState)
+}
+
+func TestTerminateWhen(t *testing.T) {
+	var turns []string
+	drafter := &stateMember{mockTeamMember: mockTeamMember{name: "drafter"}, turns: &turns}
+	reviewer := &stateMember{mockTeamMember: mockTeamMember{name: "reviewer"}, updates: map[string]any{"approved": true}, turns: &turns}
+
+	team := newStateTeam("round-robin", drafter, reviewer)
+	team.TerminateWhen = ".approved"
+
+	result, err := team.Execute(context.Background(), NewUserMessage("draft"), nil, nil, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"drafter", "reviewer"}, turns)
+	assert.Len(t, result.Messages, 2)
+}
+
+func TestTeamWithoutStateHasNoSnapshot(t *testing.T) {
+	var turns []string
+	team := newStateTeam("sequential", &stateMember{mockTeamMember: mockTeamMember{name: "solo"}, turns: &turns})
+	team.State = nil
+
+	result, err := team.Execute(context.Background(), NewUserMessage("hi"), nil, nil, nil)
+	require.NoError(t, err)
+	assert.Nil(t, result.TeamState)
+}
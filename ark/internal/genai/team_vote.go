@@ -0,0 +1,381 @@
+package genai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/openai/openai-go/packages/param"
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	VoteMethodJudge    = "judge"
+	VoteMethodMajority = "majority"
+)
+
+const defaultJudgePrompt = `You are the judge of a panel. Each panel member answered the same question independently.
+
+Question:
+{{.Question}}
+
+Answers:
+{{.Answers}}
+
+Pick the best answer. Respond only with a JSON object of the form {"winner": "<member name>", "rationale": "<why this answer was picked>"} where winner is one of: {{.Participants}}.`
+
+const debatePrompt = `Question:
+%s
+
+Your previous answer:
+%s
+
+Other panel members answered:
+%s
+
+Considering these answers, give your final answer to the question.`
+
+type JudgeTemplateData struct {
+	Question     string
+	Answers      string
+	Participants string
+}
+
+// VoteAnswer is the final answer given by a single team member in a vote.
+type VoteAnswer struct {
+	Member string `json:"member"`
+	Round  int    `json:"round"`
+	Answer string `json:"answer"`
+}
+
+// VoteOutcome describes how the final answer of a vote was picked.
+type VoteOutcome struct {
+	Method    string         `json:"method"`
+	Winner    string         `json:"winner"`
+	Answer    string         `json:"answer"`
+	Rationale string         `json:"rationale"`
+	Votes     map[string]int `json:"votes,omitempty"`
+}
+
+// VoteResult is the outcome of a vote together with the final answer of every member.
+type VoteResult struct {
+	VoteOutcome
+	Answers []VoteAnswer `json:"answers"`
+}
+
+type judgeVerdict struct {
+	Winner    string `json:"winner"`
+	Rationale string `json:"rationale"`
+}
+
+func (t *Team) executeVote(ctx context.Context, userInput Message, history []Message) ([]Message, error) {
+	var newMessages []Message
+	question := messageContent(userInput)
+
+	debateRounds := 0
+	if t.Vote != nil {
+		debateRounds = t.Vote.DebateRounds
+	}
+
+	// Round 0 runs every member on the original input; later rounds are debate rounds
+	answers := make([]VoteAnswer, len(t.Members))
+	for round := 0; round <= debateRounds; round++ {
+		roundAnswers := make([]VoteAnswer, len(t.Members))
+		for i, member := range t.Members {
+			if ctx.Err() != nil {
+				return newMessages, ctx.Err()
+			}
+
+			input := userInput
+			if round > 0 {
+				input = NewUserMessage(fmt.Sprintf(debatePrompt, question, answers[i].Answer, formatVoteAnswers(answers, member.GetName())))
+			}
+
+			turn := round*len(t.Members) + i
+			answer, err := t.executeVoteTurn(ctx, member, input, history, &newMessages, turn)
+			if err != nil {
+				if IsTerminateTeam(err) {
+					return newMessages, nil
+				}
+				return newMessages, err
+			}
+			roundAnswers[i] = VoteAnswer{Member: member.GetName(), Round: round, Answer: answer}
+		}
+		answers = roundAnswers
+	}
+
+	outcome, judgeMessages, err := t.decideVote(ctx, question, answers)
+	newMessages = append(newMessages, judgeMessages...)
+	if err != nil {
+		return newMessages, err
+	}
+
+	t.voteResult = &VoteResult{VoteOutcome: *outcome, Answers: answers}
+	finalMessage := NewAssistantMessage(outcome.Answer)
+	finalMessage.OfAssistant.Name = param.Opt[string]{Value: outcome.Winner}
+	newMessages = append(newMessages, finalMessage)
+	return newMessages, nil
+}
+
+// executeVoteTurn executes a single member without the answers of the other members and returns its final answer.
+func (t *Team) executeVoteTurn(ctx context.Context, member TeamMember, input Message, history []Message, newMessages *[]Message, turn int) (string, error) {
+	turnCtx, turnSpan := t.telemetryRecorder.StartTurn(ctx, turn, member.GetName(), member.GetType())
+	defer turnSpan.End()
+
+	operationData := map[string]string{
+		"teamName": t.Name,
+		"strategy": t.Strategy,
+		"turn":     fmt.Sprintf("%d", turn),
+	}
+	turnCtx = t.eventingRecorder.Start(turnCtx, "TeamTurn", fmt.Sprintf("Executing turn %d for team %s", turn, t.Name), operationData)
+
+	// Each member starts from the shared history so that answers stay independent
+	messages := append([]Message{}, history...)
+	var memberMessages []Message
+	err := t.executeMemberAndAccumulate(turnCtx, member, input, &messages, &memberMessages, turn)
+	*newMessages = append(*newMessages, memberMessages...)
+
+	if len(memberMessages) > 0 {
+		t.telemetryRecorder.RecordTurnOutput(turnSpan, memberMessages, len(memberMessages))
+	}
+
+	if err != nil {
+		t.telemetryRecorder.RecordError(turnSpan, err)
+		t.eventingRecorder.Fail(turnCtx, "TeamTurn", fmt.Sprintf("Team turn failed: %v", err), err, operationData)
+		return "", err
+	}
+
+	answer := ExtractLastAssistantMessageContent(memberMessages)
+	t.telemetryRecorder.RecordSuccess(turnSpan)
+	t.eventingRecorder.Complete(turnCtx, "TeamTurn", fmt.Sprintf("Team turn %d completed successfully", turn), operationData)
+	return answer, nil
+}
+
+func (t *Team) decideVote(ctx context.Context, question string, answers []VoteAnswer) (*VoteOutcome, []Message, error) {
+	method := VoteMethodMajority
+	if t.Vote != nil && t.Vote.Judge != "" {
+		method = VoteMethodJudge
+	}
+
+	answersJSON, _ := json.Marshal(answers)
+	operationData := map[string]string{
+		"teamName": t.Name,
+		"method":   method,
+		"answers":  string(answersJSON),
+	}
+	ctx = t.eventingRecorder.Start(ctx, "TeamVote", fmt.Sprintf("Deciding vote for team %s", t.Name), operationData)
+
+	var (
+		outcome  *VoteOutcome
+		messages []Message
+		err      error
+	)
+	if method == VoteMethodJudge {
+		outcome, messages, err = t.judgeVote(ctx, question, answers)
+	} else {
+		outcome, err = majorityVote(answers, t.voteField())
+	}
+	if err != nil {
+		t.eventingRecorder.Fail(ctx, "TeamVote", fmt.Sprintf("Team vote failed: %v", err), err, operationData)
+		return nil, messages, err
+	}
+
+	operationData["winner"] = outcome.Winner
+	operationData["rationale"] = outcome.Rationale
+	if outcome.Votes != nil {
+		votesJSON, _ := json.Marshal(outcome.Votes)
+		operationData["votes"] = string(votesJSON)
+	}
+	t.eventingRecorder.Complete(ctx, "TeamVote", fmt.Sprintf("Team vote won by %s", outcome.Winner), operationData)
+	return outcome, messages, nil
+}
+
+func (t *Team) voteField() string {
+	if t.Vote == nil {
+		return ""
+	}
+	return t.Vote.Field
+}
+
+func (t *Team) loadJudgeAgent(ctx context.Context) (*Agent, error) {
+	var agentCRD arkv1alpha1.Agent
+	key := types.NamespacedName{Name: t.Vote.Judge, Namespace: t.Namespace}
+	if err := t.Client.Get(ctx, key, &agentCRD); err != nil {
+		return nil, fmt.Errorf("failed to get judge agent %s in namespace %s: %w", t.Vote.Judge, t.Namespace, err)
+	}
+
+	agent, err := MakeAgent(ctx, t.Client, &agentCRD, t.telemetry, t.eventing)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create judge agent: %w", err)
+	}
+
+	return agent, nil
+}
+
+func (t *Team) judgeVote(ctx context.Context, question string, answers []VoteAnswer) (*VoteOutcome, []Message, error) {
+	promptTemplate := defaultJudgePrompt
+	if t.Vote.JudgePrompt != "" {
+		promptTemplate = t.Vote.JudgePrompt
+	}
+
+	tmpl, err := template.New("judge").Parse(promptTemplate)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	participants := make([]string, 0, len(answers))
+	for _, answer := range answers {
+		participants = append(participants, answer.Member)
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, JudgeTemplateData{
+		Question:     question,
+		Answers:      formatVoteAnswers(answers, ""),
+		Participants: strings.Join(participants, ", "),
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	judge, err := t.loadJudgeAgent(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	result, err := judge.Execute(ctx, NewUserMessage("Pick the best answer."), []Message{NewSystemMessage(buf.String())}, nil, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("judge agent call failed: %w", err)
+	}
+
+	verdict, err := parseJudgeVerdict(ExtractLastAssistantMessageContent(result.Messages))
+	if err != nil {
+		return nil, result.Messages, err
+	}
+
+	for _, answer := range answers {
+		if answer.Member == verdict.Winner {
+			return &VoteOutcome{
+				Method:    VoteMethodJudge,
+				Winner:    answer.Member,
+				Answer:    answer.Answer,
+				Rationale: verdict.Rationale,
+			}, result.Messages, nil
+		}
+	}
+
+	return nil, result.Messages, fmt.Errorf("judge agent picked unknown member %q", verdict.Winner)
+}
+
+func parseJudgeVerdict(content string) (*judgeVerdict, error) {
+	content = strings.TrimSpace(content)
+	// Tolerate verdicts wrapped in markdown code fences
+	content = strings.TrimPrefix(content, "```json")
+	content = strings.TrimPrefix(content, "```")
+	content = strings.TrimSuffix(content, "```")
+
+	var verdict judgeVerdict
+	if err := json.Unmarshal([]byte(strings.TrimSpace(content)), &verdict); err != nil {
+		return nil, fmt.Errorf("judge agent returned invalid verdict: %w", err)
+	}
+	if verdict.Winner == "" {
+		return nil, fmt.Errorf("judge agent verdict has no winner")
+	}
+	return &verdict, nil
+}
+
+// majorityVote picks the most common answer. Ties go to the answer given first.
+func majorityVote(answers []VoteAnswer, field string) (*VoteOutcome, error) {
+	if len(answers) == 0 {
+		return nil, fmt.Errorf("no answers to vote on")
+	}
+
+	votes := make(map[string]int)
+	firstIndex := make(map[string]int)
+	for i, answer := range answers {
+		key, err := voteKey(answer.Answer, field)
+		if err != nil {
+			return nil, fmt.Errorf("member %s: %w", answer.Member, err)
+		}
+		if _, seen := firstIndex[key]; !seen {
+			firstIndex[key] = i
+		}
+		votes[key]++
+	}
+
+	winnerKey := ""
+	for key, count := range votes {
+		best := votes[winnerKey]
+		if winnerKey == "" || count > best || (count == best && firstIndex[key] < firstIndex[winnerKey]) {
+			winnerKey = key
+		}
+	}
+
+	winner := answers[firstIndex[winnerKey]]
+	return &VoteOutcome{
+		Method:    VoteMethodMajority,
+		Winner:    winner.Member,
+		Answer:    winner.Answer,
+		Rationale: fmt.Sprintf("%d of %d members voted for %q", votes[winnerKey], len(answers), winnerKey),
+		Votes:     votes,
+	}, nil
+}
+
+// voteKey normalizes an answer so that equivalent answers are counted together.
+func voteKey(answer, field string) (string, error) {
+	var parsed any
+	if err := json.Unmarshal([]byte(answer), &parsed); err != nil {
+		if field != "" {
+			return "", fmt.Errorf("answer is not structured output: %w", err)
+		}
+		return strings.ToLower(strings.TrimSpace(answer)), nil
+	}
+
+	if field != "" {
+		obj, ok := parsed.(map[string]any)
+		if !ok {
+			return "", fmt.Errorf("answer is not a JSON object")
+		}
+		value, ok := obj[field]
+		if !ok {
+			return "", fmt.Errorf("answer has no field %q", field)
+		}
+		parsed = value
+	}
+
+	if s, ok := parsed.(string); ok {
+		return strings.ToLower(strings.TrimSpace(s)), nil
+	}
+	canonical, err := json.Marshal(parsed)
+	if err != nil {
+		return "", err
+	}
+	return string(canonical), nil
+}
+
+// formatVoteAnswers renders answers for prompts, leaving out the given member.
+func formatVoteAnswers(answers []VoteAnswer, exclude string) string {
+	var sb strings.Builder
+	for _, answer := range answers {
+		if answer.Member == exclude {
+			continue
+		}
+		fmt.Fprintf(&sb, "# %s:\n%s\n\n", answer.Member, answer.Answer)
+	}
+	return strings.TrimSpace(sb.String())
+}
+
+func messageContent(message Message) string {
+	switch {
+	case message.OfUser != nil:
+		return message.OfUser.Content.OfString.Value
+	case message.OfAssistant != nil:
+		return message.OfAssistant.Content.OfString.Value
+	default:
+		return ""
+	}
+}
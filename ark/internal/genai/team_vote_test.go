@@ -0,0 +1,197 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+// answeringMember returns a fixed answer per round and records the inputs it received
+type answeringMember struct {
+	mockTeamMember
+	answers []string
+	inputs  []string
+}
+
+func (m *answeringMember) Execute(ctx context.Context, userInput Message, history []Message, memory MemoryInterface, eventStream EventStreamInterface) (*ExecutionResult, error) {
+	m.inputs = append(m.inputs, messageContent(userInput))
+	answer := m.answers[len(m.inputs)-1]
+	return &ExecutionResult{Messages: []Message{NewAssistantMessage(answer)}}, nil
+}
+
+func newVoteTeam(vote *arkv1alpha1.TeamVoteSpec, members ...TeamMember) *Team {
+	return &Team{
+		Name:              "panel",
+		Namespace:         "default",
+		Strategy:          "vote",
+		Members:           members,
+		Vote:              vote,
+		telemetryRecorder: noop.NewProvider().TeamRecorder(),
+		eventingRecorder:  eventnoop.NewProvider().TeamRecorder(),
+	}
+}
+
+func TestMajorityVote(t *testing.T) {
+	tests := []struct {
+		name       string
+		answers    []VoteAnswer
+		field      string
+		wantWinner string
+		wantAnswer string
+		wantErr    string
+	}{
+		{
+			name: "majority wins ignoring case and whitespace",
+			answers: []VoteAnswer{
+				{Member: "a", Answer: "Spam"},
+				{Member: "b", Answer: "ham"},
+				{Member: "c", Answer: " spam "},
+			},
+			wantWinner: "a",
+			wantAnswer: "Spam",
+		},
+		{
+			name: "tie goes to first answer",
+			answers: []VoteAnswer{
+				{Member: "a", Answer: "ham"},
+				{Member: "b", Answer: "spam"},
+			},
+			wantWinner: "a",
+			wantAnswer: "ham",
+		},
+		{
+			name: "structured output field",
+			answers: []VoteAnswer{
+				{Member: "a", Answer: `{"label":"fraud","confidence":0.6}`},
+				{Member: "b", Answer: `{"label":"ok","confidence":0.9}`},
+				{Member: "c", Answer: `{"label":"fraud","confidence":0.8}`},
+			},
+			field:      "label",
+			wantWinner: "a",
+			wantAnswer: `{"label":"fraud","confidence":0.6}`,
+		},
+		{
+			name: "missing structured output field",
+			answers: []VoteAnswer{
+				{Member: "a", Answer: `{"confidence":0.6}`},
+			},
+			field:   "label",
+			wantErr: "has no field",
+		},
+		{
+			name: "unstructured answer with field",
+			answers: []VoteAnswer{
+				{Member: "a", Answer: "fraud"},
+			},
+			field:   "label",
+			wantErr: "not structured output",
+		},
+		{
+			name:    "no answers",
+			wantErr: "no answers",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outcome, err := majorityVote(tt.answers, tt.field)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, VoteMethodMajority, outcome.Method)
+			assert.Equal(t, tt.wantWinner, outcome.Winner)
+			assert.Equal(t, tt.wantAnswer, outcome.Answer)
+			assert.NotEmpty(t, outcome.Rationale)
+		})
+	}
+}
+
+func TestParseJudgeVerdict(t *testing.T) {
+	verdict, err := parseJudgeVerdict("```json\n{\"winner\": \"b\", \"rationale\": \"most complete\"}\n```")
+	require.NoError(t, err)
+	assert.Equal(t, "b", verdict.Winner)
+	assert.Equal(t, "most complete", verdict.Rationale)
+
+	_, err = parseJudgeVerdict("b is best")
+	assert.Error(t, err)
+
+	_, err = parseJudgeVerdict(`{"rationale": "none"}`)
+	assert.Error(t, err)
+}
+
+func TestExecuteVoteMajority(t *testing.T) {
+	a := &answeringMember{mockTeamMember: mockTeamMember{name: "a"}, answers: []string{"spam"}}
+	b := &answeringMember{mockTeamMember: mockTeamMember{name: "b"}, answers: []string{"ham"}}
+	c := &answeringMember{mockTeamMember: mockTeamMember{name: "c"}, answers: []string{"spam"}}
+	team := newVoteTeam(nil, a, b, c)
+
+	messages, err := team.executeVote(context.Background(), NewUserMessage("classify"), nil)
+	require.NoError(t, err)
+
+	// One answer per member plus the final answer
+	require.Len(t, messages, 4)
+	final := messages[len(messages)-1].OfAssistant
+	require.NotNil(t, final)
+	assert.Equal(t, "spam", final.Content.OfString.Value)
+	assert.Equal(t, "a", final.Name.Value)
+}
+
+func TestTeamExecuteReturnsTheVote(t *testing.T) {
+	a := &answeringMember{mockTeamMember: mockTeamMember{name: "a"}, answers: []string{"spam"}}
+	b := &answeringMember{mockTeamMember: mockTeamMember{name: "b"}, answers: []string{"ham"}}
+	c := &answeringMember{mockTeamMember: mockTeamMember{name: "c"}, answers: []string{"Spam"}}
+	team := newVoteTeam(nil, a, b, c)
+
+	result, err := team.Execute(context.Background(), NewUserMessage("classify"), nil, nil, nil)
+	require.NoError(t, err)
+	require.NotNil(t, result.Vote)
+
+	raw, err := json.Marshal(result.Vote)
+	require.NoError(t, err)
+	var vote VoteResult
+	require.NoError(t, json.Unmarshal(raw, &vote))
+	assert.Equal(t, VoteResult{
+		VoteOutcome: VoteOutcome{
+			Method:    VoteMethodMajority,
+			Winner:    "a",
+			Answer:    "spam",
+			Rationale: `2 of 3 members voted for "spam"`,
+			Votes:     map[string]int{"spam": 2, "ham": 1},
+		},
+		Answers: []VoteAnswer{
+			{Member: "a", Answer: "spam"},
+			{Member: "b", Answer: "ham"},
+			{Member: "c", Answer: "Spam"},
+		},
+	}, vote)
+}
+
+func TestExecuteVoteDebateRounds(t *testing.T) {
+	a := &answeringMember{mockTeamMember: mockTeamMember{name: "a"}, answers: []string{"spam", "ham"}}
+	b := &answeringMember{mockTeamMember: mockTeamMember{name: "b"}, answers: []string{"ham", "ham"}}
+	team := newVoteTeam(&arkv1alpha1.TeamVoteSpec{DebateRounds: 1}, a, b)
+
+	messages, err := team.executeVote(context.Background(), NewUserMessage("classify"), nil)
+	require.NoError(t, err)
+
+	require.Len(t, a.inputs, 2)
+	assert.Equal(t, "classify", a.inputs[0])
+	assert.Contains(t, a.inputs[1], "# b:\nham")
+	assert.NotContains(t, a.inputs[1], "# a:")
+
+	final := messages[len(messages)-1].OfAssistant
+	require.NotNil(t, final)
+	assert.Equal(t, "ham", final.Content.OfString.Value)
+}
@@ -0,0 +1,214 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// ArgumentValidatingExecutor checks tool call arguments against the tool input schema before they reach the
+// base executor. Invalid arguments are returned to the model as the tool result, so that it can correct the
+// call instead of the query failing on an error from the downstream service.
+type ArgumentValidatingExecutor struct {
+	BaseExecutor ToolExecutor
+	// Schema is nil when the tool has no usable input schema, in which case only the JSON is checked
+	Schema *jsonschema.Resolved
+	// Lenient enables repair of malformed JSON arguments
+	Lenient bool
+}
+
+// newArgumentValidatingExecutor wraps an executor with argument validation for the tool. Tools whose input
+// schema cannot be resolved are still called, without schema validation.
+func newArgumentValidatingExecutor(ctx context.Context, tool *arkv1alpha1.Tool, base ToolExecutor) ToolExecutor {
+	executor := &ArgumentValidatingExecutor{BaseExecutor: base, Lenient: tool.Spec.LenientArguments}
+	raw := toolInputSchema(tool)
+	if len(raw) == 0 {
+		return executor
+	}
+
+	var schema jsonschema.Schema
+	err := json.Unmarshal(raw, &schema)
+	if err == nil {
+		executor.Schema, err = schema.Resolve(nil)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Info("tool input schema cannot be used for argument validation", "tool", tool.Name, "error", err.Error())
+	}
+	return executor
+}
+
+func (v *ArgumentValidatingExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	arguments, repaired, err := parseToolArguments(call.Function.Arguments, v.Lenient)
+	if err != nil {
+		return invalidArgumentsResult(call, fmt.Sprintf("arguments are not valid JSON: %v", err)), nil
+	}
+	if repaired != "" {
+		logf.FromContext(ctx).Info("repaired malformed tool arguments", "tool", call.Function.Name, "toolID", call.ID)
+		call.Function.Arguments = repaired
+	}
+
+	if v.Schema != nil {
+		// Partial parameters are always injected as strings
+		if coerceScalarArguments(v.Schema.Schema(), arguments) {
+			coerced, err := json.Marshal(arguments)
+			if err != nil {
+				return invalidArgumentsResult(call, err.Error()), nil
+			}
+			call.Function.Arguments = string(coerced)
+		}
+		if err := v.Schema.Validate(arguments); err != nil {
+			return invalidArgumentsResult(call, err.Error()), nil
+		}
+	}
+
+	return v.BaseExecutor.Execute(ctx, call)
+}
+
+func invalidArgumentsResult(call ToolCall, reason string) ToolResult {
+	message := fmt.Sprintf("Invalid arguments for tool %s: %s. Correct the arguments and call the tool again.", call.Function.Name, reason)
+	return ToolResult{
+		ID:      call.ID,
+		Name:    call.Function.Name,
+		Content: message,
+		Error:   message,
+	}
+}
+
+// coerceScalarArguments converts top-level string arguments to the integer, number or boolean type of their
+// property, when the string holds such a value. It reports whether any argument was converted.
+func coerceScalarArguments(schema *jsonschema.Schema, arguments map[string]any) bool {
+	coerced := false
+	for name, value := range arguments {
+		text, ok := value.(string)
+		property := schema.Properties[name]
+		if !ok || property == nil {
+			continue
+		}
+
+		var converted any
+		switch property.Type {
+		case "integer":
+			if n, err := strconv.ParseInt(text, 10, 64); err == nil {
+				converted = n
+			}
+		case "number":
+			if f, err := strconv.ParseFloat(text, 64); err == nil {
+				converted = f
+			}
+		case "boolean":
+			if b, err := strconv.ParseBool(text); err == nil {
+				converted = b
+			}
+		}
+		if converted != nil {
+			arguments[name] = converted
+			coerced = true
+		}
+	}
+	return coerced
+}
+
+// parseToolArguments parses the JSON arguments of a tool call. Empty arguments are an empty object. When
+// lenient is set, malformed JSON is repaired and the repaired JSON is returned as well.
+func parseToolArguments(raw string, lenient bool) (map[string]any, string, error) {
+	arguments := map[string]any{}
+	if strings.TrimSpace(raw) == "" {
+		return arguments, "", nil
+	}
+
+	err := json.Unmarshal([]byte(raw), &arguments)
+	if err == nil || !lenient {
+		return arguments, "", err
+	}
+
+	repaired := repairJSON(raw)
+	arguments = map[string]any{}
+	if repairErr := json.Unmarshal([]byte(repaired), &arguments); repairErr != nil {
+		return nil, "", err
+	}
+	return arguments, repaired, nil
+}
+
+// repairJSON fixes the mistakes weaker models commonly make in JSON: trailing commas, single-quoted strings,
+// raw newlines in strings, and strings, objects or arrays that are not terminated.
+func repairJSON(input string) string {
+	var out strings.Builder
+	var closers []byte
+	var quote rune // quote of the string being read, 0 outside strings
+
+	runes := []rune(input)
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+
+		if quote != 0 {
+			switch {
+			case r == '\\' && i+1 < len(runes):
+				i++
+				if quote == '\'' && runes[i] == '\'' {
+					out.WriteRune('\'')
+				} else {
+					out.WriteRune('\\')
+					out.WriteRune(runes[i])
+				}
+			case r == quote:
+				out.WriteRune('"')
+				quote = 0
+			case r == '"':
+				out.WriteString(`\"`)
+			case r == '\n':
+				out.WriteString(`\n`)
+			default:
+				out.WriteRune(r)
+			}
+			continue
+		}
+
+		switch r {
+		case '"', '\'':
+			quote = r
+			out.WriteRune('"')
+		case '{':
+			closers = append(closers, '}')
+			out.WriteRune(r)
+		case '[':
+			closers = append(closers, ']')
+			out.WriteRune(r)
+		case '}', ']':
+			if len(closers) > 0 {
+				closers = closers[:len(closers)-1]
+			}
+			out.WriteRune(r)
+		case ',':
+			// Drop commas directly followed by the end of an object or array
+			j := i + 1
+			for j < len(runes) && strings.ContainsRune(" \t\r\n", runes[j]) {
+				j++
+			}
+			if j < len(runes) && (runes[j] == '}' || runes[j] == ']') {
+				continue
+			}
+			out.WriteRune(r)
+		default:
+			out.WriteRune(r)
+		}
+	}
+
+	if quote != 0 {
+		out.WriteRune('"')
+	}
+	result := strings.TrimRight(out.String(), " \t\r\n")
+	result = strings.TrimSuffix(result, ",")
+	for i := len(closers) - 1; i >= 0; i-- {
+		result += string(closers[i])
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// recordingExecutor returns the arguments it was called with.
+type recordingExecutor struct {
+	calls int
+}
+
+func (r *recordingExecutor) Execute(_ context.Context, call ToolCall) (ToolResult, error) {
+	r.calls++
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: call.Function.Arguments}, nil
+}
+
+func newSchemaTool(schema string, lenient bool) *arkv1alpha1.Tool {
+	return &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type:             ToolTypeHTTP,
+			InputSchema:      &runtime.RawExtension{Raw: []byte(schema)},
+			LenientArguments: lenient,
+		},
+	}
+}
+
+func toolCallWithArguments(arguments string) ToolCall {
+	return ToolCall{
+		ID:       "call-1",
+		Function: openai.ChatCompletionMessageToolCallFunction{Name: "weather", Arguments: arguments},
+	}
+}
+
+const weatherSchema = `{
+	"type": "object",
+	"properties": {
+		"city": {"type": "string"},
+		"days": {"type": "integer", "minimum": 1}
+	},
+	"required": ["city"]
+}`
+
+func TestArgumentValidatingExecutor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("passes valid arguments through", func(t *testing.T) {
+		base := &recordingExecutor{}
+		executor := newArgumentValidatingExecutor(ctx, newSchemaTool(weatherSchema, false), base)
+
+		result, err := executor.Execute(ctx, toolCallWithArguments(`{"city":"Paris","days":3}`))
+		require.NoError(t, err)
+		assert.Equal(t, 1, base.calls)
+		assert.Equal(t, `{"city":"Paris","days":3}`, result.Content)
+	})
+
+	t.Run("returns schema violations to the model", func(t *testing.T) {
+		base := &recordingExecutor{}
+		executor := newArgumentValidatingExecutor(ctx, newSchemaTool(weatherSchema, false), base)
+
+		result, err := executor.Execute(ctx, toolCallWithArguments(`{"days":2}`))
+		require.NoError(t, err, "invalid arguments must not fail the query")
+		assert.Equal(t, 0, base.calls)
+		assert.Contains(t, result.Content, "Invalid arguments for tool weather")
+		assert.Contains(t, result.Content, "city")
+		assert.Equal(t, result.Content, result.Error)
+	})
+
+	t.Run("rejects malformed JSON unless lenient", func(t *testing.T) {
+		base := &recordingExecutor{}
+		strict := newArgumentValidatingExecutor(ctx, newSchemaTool(weatherSchema, false), base)
+
+		result, err := strict.Execute(ctx, toolCallWithArguments(`{'city': 'Paris',}`))
+		require.NoError(t, err)
+		assert.Contains(t, result.Content, "arguments are not valid JSON")
+		assert.Equal(t, 0, base.calls)
+
+		lenient := newArgumentValidatingExecutor(ctx, newSchemaTool(weatherSchema, true), base)
+		result, err = lenient.Execute(ctx, toolCallWithArguments(`{'city': 'Paris',}`))
+		require.NoError(t, err)
+		assert.Equal(t, 1, base.calls)
+		assert.JSONEq(t, `{"city":"Paris"}`, result.Content)
+	})
+
+	t.Run("coerces injected string parameters to the schema type", func(t *testing.T) {
+		base := &recordingExecutor{}
+		executor := newArgumentValidatingExecutor(ctx, newSchemaTool(weatherSchema, false), base)
+
+		result, err := executor.Execute(ctx, toolCallWithArguments(`{"city":"Paris","days":"2"}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"city":"Paris","days":2}`, result.Content)
+	})
+
+	t.Run("only checks JSON when the tool has no schema", func(t *testing.T) {
+		base := &recordingExecutor{}
+		tool := newSchemaTool(weatherSchema, false)
+		tool.Spec.InputSchema = nil
+		executor := newArgumentValidatingExecutor(ctx, tool, base)
+
+		_, err := executor.Execute(ctx, toolCallWithArguments(`{"anything":true}`))
+		require.NoError(t, err)
+		assert.Equal(t, 1, base.calls)
+	})
+}
+
+func TestRepairJSON(t *testing.T) {
+	testCases := map[string]struct {
+		input    string
+		expected string
+	}{
+		"trailing commas":     {`{"a": [1, 2,], "b": 1,}`, `{"a":[1,2],"b":1}`},
+		"single quotes":       {`{'a': 'it\'s "quoted"'}`, `{"a":"it's \"quoted\""}`},
+		"unterminated string": {`{"query": "weather in Par`, `{"query":"weather in Par"}`},
+		"unclosed containers": {`{"a": {"b": [1, 2`, `{"a":{"b":[1,2]}}`},
+		"raw newline":         {"{\"a\": \"line1\nline2\"}", `{"a":"line1\nline2"}`},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			repaired := repairJSON(tc.input)
+			var actual any
+			require.NoError(t, json.Unmarshal([]byte(repaired), &actual), repaired)
+			assert.JSONEq(t, tc.expected, repaired)
+		})
+	}
+}
@@ -0,0 +1,190 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// DefaultToolCacheSize is the number of tool results kept by the controller-wide cache.
+const DefaultToolCacheSize = 1000
+
+// ToolResultCache is a size-bounded cache of tool results with per-entry expiry. When full, the least
+// recently used entry is evicted.
+type ToolResultCache struct {
+	mu      sync.Mutex
+	maxSize int
+	order   *list.List
+	entries map[string]*list.Element
+	now     func() time.Time
+}
+
+type toolCacheEntry struct {
+	key       string
+	content   string
+	expiresAt time.Time
+}
+
+func NewToolResultCache(maxSize int) *ToolResultCache {
+	return &ToolResultCache{
+		maxSize: maxSize,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+		now:     time.Now,
+	}
+}
+
+// DefaultToolResultCache is shared by all queries of the controller.
+var DefaultToolResultCache = NewToolResultCache(DefaultToolCacheSize)
+
+func (c *ToolResultCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	element, exists := c.entries[key]
+	if !exists {
+		return "", false
+	}
+	entry := element.Value.(*toolCacheEntry)
+	if !c.now().Before(entry.expiresAt) {
+		c.order.Remove(element)
+		delete(c.entries, key)
+		return "", false
+	}
+	c.order.MoveToFront(element)
+	return entry.content, true
+}
+
+func (c *ToolResultCache) Set(key, content string, ttl time.Duration) {
+	if c.maxSize <= 0 || ttl <= 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := c.now().Add(ttl)
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*toolCacheEntry)
+		entry.content = content
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&toolCacheEntry{key: key, content: content, expiresAt: expiresAt})
+	for c.order.Len() > c.maxSize {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*toolCacheEntry).key)
+	}
+}
+
+func (c *ToolResultCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// CachingToolExecutor returns cached results for repeated calls with the same arguments.
+type CachingToolExecutor struct {
+	BaseExecutor ToolExecutor
+	Cache        *ToolResultCache
+	// ToolKey identifies the tool resource and its generation, so that tools with the same name in other namespaces
+	// do not share results, and results are not reused once the tool is changed
+	ToolKey string
+	// SettingsKey identifies the per-query MCP settings, whose headers may change what the tool returns
+	SettingsKey string
+	TTL         time.Duration
+	KeyFields   []string
+}
+
+// newCachingToolExecutor wraps an executor with result caching when the tool enables it. Only tools that are
+// marked read-only or idempotent are cached.
+func newCachingToolExecutor(tool *arkv1alpha1.Tool, base ToolExecutor, mcpSettings map[string]MCPSettings) ToolExecutor {
+	if !isToolCacheable(tool) {
+		return base
+	}
+	return &CachingToolExecutor{
+		BaseExecutor: base,
+		Cache:        DefaultToolResultCache,
+		ToolKey:      fmt.Sprintf("%s/%s/%d", tool.Namespace, tool.Name, tool.Generation),
+		SettingsKey:  mcpSettingsKey(mcpSettings),
+		TTL:          tool.Spec.Cache.TTL.Duration,
+		KeyFields:    tool.Spec.Cache.KeyFields,
+	}
+}
+
+// mcpSettingsKey hashes the MCP settings, so that header values are not kept in cache keys.
+func mcpSettingsKey(mcpSettings map[string]MCPSettings) string {
+	if len(mcpSettings) == 0 {
+		return ""
+	}
+	encoded, _ := json.Marshal(mcpSettings)
+	hash := sha256.Sum256(encoded)
+	return hex.EncodeToString(hash[:8])
+}
+
+func isToolCacheable(tool *arkv1alpha1.Tool) bool {
+	annotations := tool.Spec.Annotations
+	return tool.Spec.Cache != nil && tool.Spec.Cache.TTL.Duration > 0 &&
+		annotations != nil && (annotations.ReadOnlyHint || annotations.IdempotentHint)
+}
+
+func (c *CachingToolExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	key, ok := c.cacheKey(ctx, call.Function.Arguments)
+	if !ok {
+		return c.BaseExecutor.Execute(ctx, call)
+	}
+
+	if content, hit := c.Cache.Get(key); hit {
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: content, Cached: true}, nil
+	}
+
+	result, err := c.BaseExecutor.Execute(ctx, call)
+	// Images are not kept by the cache, so results with images are not cached
+	if err == nil && result.Error == "" && len(result.Images) == 0 {
+		c.Cache.Set(key, result.Content, c.TTL)
+	}
+	return result, err
+}
+
+// cacheKey canonicalizes the arguments. Encoding a map sorts its keys, so argument order does not matter. Results
+// are only shared by queries with the same service account and MCP settings, as both may change what the tool
+// returns.
+func (c *CachingToolExecutor) cacheKey(ctx context.Context, rawArguments string) (string, bool) {
+	arguments, _, err := parseToolArguments(rawArguments, false)
+	if err != nil {
+		return "", false
+	}
+
+	if len(c.KeyFields) > 0 {
+		selected := make(map[string]any, len(c.KeyFields))
+		for _, field := range c.KeyFields {
+			if value, exists := arguments[field]; exists {
+				selected[field] = value
+			}
+		}
+		arguments = selected
+	}
+
+	canonical, err := json.Marshal(arguments)
+	if err != nil {
+		return "", false
+	}
+	var serviceAccount string
+	if query, ok := ctx.Value(QueryContextKey).(*arkv1alpha1.Query); ok && query != nil {
+		serviceAccount = query.Spec.ServiceAccount
+	}
+	return strings.Join([]string{c.ToolKey, serviceAccount, c.SettingsKey, string(canonical)}, "\x00"), true
+}
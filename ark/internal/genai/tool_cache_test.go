@@ -0,0 +1,164 @@
+package genai
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+func TestToolResultCache(t *testing.T) {
+	t.Run("expires entries after their ttl", func(t *testing.T) {
+		now := time.Now()
+		cache := NewToolResultCache(10)
+		cache.now = func() time.Time { return now }
+
+		cache.Set("key", "value", time.Minute)
+		content, hit := cache.Get("key")
+		assert.True(t, hit)
+		assert.Equal(t, "value", content)
+
+		now = now.Add(time.Minute)
+		_, hit = cache.Get("key")
+		assert.False(t, hit)
+		assert.Equal(t, 0, cache.Len())
+	})
+
+	t.Run("evicts the least recently used entry", func(t *testing.T) {
+		cache := NewToolResultCache(2)
+		cache.Set("a", "1", time.Hour)
+		cache.Set("b", "2", time.Hour)
+		_, _ = cache.Get("a")
+		cache.Set("c", "3", time.Hour)
+
+		_, hit := cache.Get("b")
+		assert.False(t, hit)
+		_, hit = cache.Get("a")
+		assert.True(t, hit)
+		assert.Equal(t, 2, cache.Len())
+	})
+}
+
+func newCachedTool(annotations *arkv1alpha1.ToolAnnotations, keyFields ...string) *arkv1alpha1.Tool {
+	return &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "weather", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type:        ToolTypeHTTP,
+			Annotations: annotations,
+			Cache:       &arkv1alpha1.ToolCacheSpec{TTL: metav1.Duration{Duration: time.Hour}, KeyFields: keyFields},
+		},
+	}
+}
+
+func TestCachingToolExecutor(t *testing.T) {
+	ctx := context.Background()
+	readOnly := &arkv1alpha1.ToolAnnotations{ReadOnlyHint: true}
+
+	t.Run("returns cached results regardless of argument order", func(t *testing.T) {
+		DefaultToolResultCache = NewToolResultCache(10)
+		base := &recordingExecutor{}
+		executor := newCachingToolExecutor(newCachedTool(readOnly), base, nil)
+
+		first, err := executor.Execute(ctx, toolCallWithArguments(`{"city":"Paris","days":3}`))
+		require.NoError(t, err)
+		assert.False(t, first.Cached)
+
+		second, err := executor.Execute(ctx, toolCallWithArguments(`{"days":3, "city":"Paris"}`))
+		require.NoError(t, err)
+		assert.True(t, second.Cached)
+		assert.Equal(t, first.Content, second.Content)
+		assert.Equal(t, 1, base.calls)
+
+		_, err = executor.Execute(ctx, toolCallWithArguments(`{"city":"Rome","days":3}`))
+		require.NoError(t, err)
+		assert.Equal(t, 2, base.calls)
+	})
+
+	t.Run("only uses key fields for the cache key", func(t *testing.T) {
+		DefaultToolResultCache = NewToolResultCache(10)
+		base := &recordingExecutor{}
+		executor := newCachingToolExecutor(newCachedTool(readOnly, "city"), base, nil)
+
+		_, err := executor.Execute(ctx, toolCallWithArguments(`{"city":"Paris","reason":"user asked"}`))
+		require.NoError(t, err)
+		result, err := executor.Execute(ctx, toolCallWithArguments(`{"city":"Paris","reason":"double check"}`))
+		require.NoError(t, err)
+		assert.True(t, result.Cached)
+		assert.Equal(t, 1, base.calls)
+	})
+
+	t.Run("does not share results across service accounts and MCP settings", func(t *testing.T) {
+		DefaultToolResultCache = NewToolResultCache(10)
+		base := &recordingExecutor{}
+		call := toolCallWithArguments(`{"city":"Paris"}`)
+		queryWith := func(serviceAccount string) context.Context {
+			query := &arkv1alpha1.Query{Spec: arkv1alpha1.QuerySpec{ServiceAccount: serviceAccount}}
+			return context.WithValue(ctx, QueryContextKey, query)
+		}
+
+		executor := newCachingToolExecutor(newCachedTool(readOnly), base, nil)
+		_, err := executor.Execute(queryWith("reader"), call)
+		require.NoError(t, err)
+		result, err := executor.Execute(queryWith("admin"), call)
+		require.NoError(t, err)
+		assert.False(t, result.Cached)
+		result, err = executor.Execute(queryWith("reader"), call)
+		require.NoError(t, err)
+		assert.True(t, result.Cached)
+
+		settings := map[string]MCPSettings{"default/weather": {Headers: map[string]string{"Authorization": "Bearer other"}}}
+		executor = newCachingToolExecutor(newCachedTool(readOnly), base, settings)
+		result, err = executor.Execute(queryWith("reader"), call)
+		require.NoError(t, err)
+		assert.False(t, result.Cached)
+		assert.Equal(t, 3, base.calls)
+	})
+
+	t.Run("does not reuse results once the tool changes", func(t *testing.T) {
+		DefaultToolResultCache = NewToolResultCache(10)
+		base := &recordingExecutor{}
+		call := toolCallWithArguments(`{"city":"Paris"}`)
+		tool := newCachedTool(readOnly)
+		tool.Generation = 1
+
+		_, err := newCachingToolExecutor(tool, base, nil).Execute(ctx, call)
+		require.NoError(t, err)
+		result, err := newCachingToolExecutor(tool, base, nil).Execute(ctx, call)
+		require.NoError(t, err)
+		assert.True(t, result.Cached)
+
+		tool.Generation = 2
+		result, err = newCachingToolExecutor(tool, base, nil).Execute(ctx, call)
+		require.NoError(t, err)
+		assert.False(t, result.Cached)
+		assert.Equal(t, 2, base.calls)
+	})
+
+	t.Run("does not cache tools without read-only or idempotent hints", func(t *testing.T) {
+		base := &recordingExecutor{}
+		executor := newCachingToolExecutor(newCachedTool(&arkv1alpha1.ToolAnnotations{DestructiveHint: true}), base, nil)
+		assert.Same(t, base, executor)
+	})
+
+	t.Run("does not cache failed results", func(t *testing.T) {
+		DefaultToolResultCache = NewToolResultCache(10)
+		executor := newCachingToolExecutor(newCachedTool(readOnly), failingToolExecutor{}, nil)
+
+		result, err := executor.Execute(ctx, toolCallWithArguments(`{"city":"Paris"}`))
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.Error)
+		assert.Equal(t, 0, DefaultToolResultCache.Len())
+	})
+}
+
+// failingToolExecutor returns an error result to the model.
+type failingToolExecutor struct{}
+
+func (failingToolExecutor) Execute(_ context.Context, call ToolCall) (ToolResult, error) {
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: "service unavailable", Error: "service unavailable"}, nil
+}
@@ -0,0 +1,231 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/itchyny/gojq"
+	"golang.org/x/net/html"
+	"k8s.io/client-go/util/jsonpath"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+)
+
+// Tool output function names, applied in order to the result of a tool
+const (
+	ToolFunctionJQ         = "jq"
+	ToolFunctionTruncate   = "truncate"
+	ToolFunctionTemplate   = "template"
+	ToolFunctionJSONPath   = "jsonpath"
+	ToolFunctionHTMLToText = "html-to-text"
+	ToolFunctionRedact     = "redact"
+)
+
+// bytesPerToken approximates the token count of text without a model specific tokenizer
+const bytesPerToken = 4
+
+const (
+	truncationMarker = "\n[... output truncated ...]"
+	redactionMarker  = "[REDACTED]"
+)
+
+var truncateLimitPattern = regexp.MustCompile(`^(\d+)\s*(bytes|tokens)?$`)
+
+// ValidateToolFunction checks that the function is known and that its value can be used.
+func ValidateToolFunction(fn arkv1alpha1.ToolFunction) error {
+	var err error
+	switch fn.Name {
+	case ToolFunctionJQ:
+		if fn.Value != "" {
+			_, err = gojq.Parse(fn.Value)
+		}
+	case ToolFunctionTruncate:
+		_, err = parseTruncateLimit(fn.Value)
+	case ToolFunctionTemplate:
+		if fn.Value == "" {
+			return fmt.Errorf("template requires a Go template as value")
+		}
+		err = common.ValidateTemplate(fn.Value)
+	case ToolFunctionJSONPath:
+		if fn.Value == "" {
+			return fmt.Errorf("jsonpath requires a JSONPath expression as value")
+		}
+		err = jsonpath.New(fn.Name).Parse(jsonPathTemplate(fn.Value))
+	case ToolFunctionHTMLToText:
+	case ToolFunctionRedact:
+		if fn.Value == "" {
+			return fmt.Errorf("redact requires a regular expression as value")
+		}
+		_, err = regexp.Compile(fn.Value)
+	default:
+		return fmt.Errorf("unsupported function '%s': supported functions are: %s", fn.Name,
+			strings.Join([]string{ToolFunctionJQ, ToolFunctionTruncate, ToolFunctionTemplate, ToolFunctionJSONPath, ToolFunctionHTMLToText, ToolFunctionRedact}, ", "))
+	}
+	if err != nil {
+		return fmt.Errorf("invalid value for function '%s': %w", fn.Name, err)
+	}
+	return nil
+}
+
+// parseTruncateLimit parses a limit such as "4096", "4096 bytes" or "1000 tokens" to a number of bytes.
+func parseTruncateLimit(value string) (int, error) {
+	match := truncateLimitPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return 0, fmt.Errorf("limit '%s' must be a number of bytes or tokens, for example '4096' or '1000 tokens'", value)
+	}
+	limit, err := strconv.Atoi(match[1])
+	if err != nil || limit <= 0 {
+		return 0, fmt.Errorf("limit '%s' must be greater than zero", value)
+	}
+	if match[2] == "tokens" {
+		limit *= bytesPerToken
+	}
+	return limit, nil
+}
+
+func applyTruncate(content, value string) (string, error) {
+	limit, err := parseTruncateLimit(value)
+	if err != nil {
+		return "", err
+	}
+	if len(content) <= limit {
+		return content, nil
+	}
+	return content[:runeBoundary(content, limit)] + truncationMarker, nil
+}
+
+// runeBoundary returns the largest index up to limit that does not cut a multi-byte character in half.
+func runeBoundary(content string, limit int) int {
+	for limit > 0 && !utf8.RuneStart(content[limit]) {
+		limit--
+	}
+	return limit
+}
+
+// applyTemplate renders a Go template with the parsed JSON result as .result and the raw content as .content.
+func applyTemplate(content, tmpl string) (string, error) {
+	data := map[string]any{"content": content}
+	var parsed any
+	if err := json.Unmarshal([]byte(content), &parsed); err == nil {
+		data["result"] = parsed
+	}
+	return common.ResolveTemplate(tmpl, data)
+}
+
+// jsonPathTemplate accepts expressions with or without the surrounding braces, like kubectl does.
+func jsonPathTemplate(expr string) string {
+	if strings.Contains(expr, "{") {
+		return expr
+	}
+	return "{" + expr + "}"
+}
+
+func applyJSONPath(content, expr string) (string, error) {
+	jp := jsonpath.New(ToolFunctionJSONPath)
+	if err := jp.Parse(jsonPathTemplate(expr)); err != nil {
+		return "", fmt.Errorf("failed to parse jsonpath expression '%s': %w", expr, err)
+	}
+
+	var data any
+	if err := json.Unmarshal([]byte(content), &data); err != nil {
+		return "", fmt.Errorf("jsonpath requires JSON output, got: %s", contentPreview(content))
+	}
+
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return "", fmt.Errorf("jsonpath query execution error: %w", err)
+	}
+	var values []any
+	for _, result := range results {
+		for _, value := range result {
+			values = append(values, value.Interface())
+		}
+	}
+
+	var output any = values
+	if len(values) == 1 {
+		output = values[0]
+	}
+	filteredBytes, err := json.Marshal(output)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal jsonpath result: %w", err)
+	}
+	return string(filteredBytes), nil
+}
+
+// htmlSkippedElements hold no text that is meant to be read
+var htmlSkippedElements = map[string]bool{
+	"head": true, "script": true, "style": true, "noscript": true, "template": true, "svg": true, "iframe": true,
+}
+
+// htmlBlockElements start on a new line
+var htmlBlockElements = map[string]bool{
+	"address": true, "article": true, "aside": true, "blockquote": true, "br": true, "dd": true, "div": true,
+	"dl": true, "dt": true, "fieldset": true, "figcaption": true, "figure": true, "footer": true, "form": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true, "header": true, "hr": true, "li": true,
+	"main": true, "nav": true, "ol": true, "p": true, "pre": true, "section": true, "table": true, "tr": true, "ul": true,
+}
+
+// applyHTMLToText strips markup, scripts and styles and keeps the readable text, one block per line.
+func applyHTMLToText(content string) (string, error) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse html: %w", err)
+	}
+
+	var text bytes.Buffer
+	var walk func(node *html.Node)
+	walk = func(node *html.Node) {
+		if node.Type == html.ElementNode && htmlSkippedElements[node.Data] {
+			return
+		}
+		if node.Type == html.TextNode {
+			text.WriteString(node.Data)
+			return
+		}
+		block := node.Type == html.ElementNode && htmlBlockElements[node.Data]
+		if block {
+			text.WriteByte('\n')
+		}
+		for child := node.FirstChild; child != nil; child = child.NextSibling {
+			walk(child)
+		}
+		if block {
+			text.WriteByte('\n')
+		}
+	}
+	walk(doc)
+
+	var lines []string
+	for _, line := range strings.Split(text.String(), "\n") {
+		if line = strings.Join(strings.Fields(line), " "); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+func applyRedact(content, pattern string) (string, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return "", fmt.Errorf("failed to compile redact pattern '%s': %w", pattern, err)
+	}
+	return re.ReplaceAllLiteralString(content, redactionMarker), nil
+}
+
+// contentPreview shortens content for error messages.
+func contentPreview(content string) string {
+	const maxPreview = 100
+	if len(content) > maxPreview {
+		content = content[:runeBoundary(content, maxPreview)] + "..."
+	}
+	return strconv.Quote(content)
+}
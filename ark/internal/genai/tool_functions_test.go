@@ -0,0 +1,158 @@
+package genai
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// staticExecutor returns fixed content.
+type staticExecutor struct {
+	content string
+}
+
+func (s staticExecutor) Execute(_ context.Context, call ToolCall) (ToolResult, error) {
+	return ToolResult{ID: call.ID, Name: call.Function.Name, Content: s.content}, nil
+}
+
+func executeFunctions(t *testing.T, content string, functions ...arkv1alpha1.ToolFunction) ToolResult {
+	t.Helper()
+	executor := &FilteredToolExecutor{BaseExecutor: staticExecutor{content: content}, Functions: functions}
+	result, err := executor.Execute(context.Background(), toolCallWithArguments(`{}`))
+	require.NoError(t, err)
+	return result
+}
+
+func TestToolFunctions(t *testing.T) {
+	testCases := map[string]struct {
+		content   string
+		functions []arkv1alpha1.ToolFunction
+		expected  string
+	}{
+		"jq": {
+			content:   `{"items":[{"name":"a"},{"name":"b"}]}`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "jq", Value: "[.items[].name]"}},
+			expected:  `["a","b"]`,
+		},
+		"jsonpath without braces": {
+			content:   `{"items":[{"name":"a"},{"name":"b"}]}`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "jsonpath", Value: ".items[*].name"}},
+			expected:  `["a","b"]`,
+		},
+		"jsonpath single value": {
+			content:   `{"weather":{"temperature":21}}`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "jsonpath", Value: "{.weather.temperature}"}},
+			expected:  `21`,
+		},
+		"template over parsed result": {
+			content:   `{"city":"Paris","temperature":21}`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "template", Value: "{{ .result.city }}: {{ .result.temperature }}°C"}},
+			expected:  `Paris: 21°C`,
+		},
+		"template over raw content": {
+			content:   `plain text`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "template", Value: "Result: {{ .content }}"}},
+			expected:  `Result: plain text`,
+		},
+		"truncate bytes": {
+			content:   `0123456789`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "truncate", Value: "4"}},
+			expected:  "0123" + truncationMarker,
+		},
+		"truncate tokens": {
+			content:   strings.Repeat("x", 20),
+			functions: []arkv1alpha1.ToolFunction{{Name: "truncate", Value: "2 tokens"}},
+			expected:  strings.Repeat("x", 8) + truncationMarker,
+		},
+		"truncate keeps multi-byte characters whole": {
+			content:   `aéb`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "truncate", Value: "2 bytes"}},
+			expected:  "a" + truncationMarker,
+		},
+		"html-to-text": {
+			content: `<html><head><title>t</title><style>p{}</style></head><body>
+				<h1>Weather</h1><script>track()</script><p>Sunny   and <b>warm</b></p><ul><li>Mon</li><li>Tue</li></ul></body></html>`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "html-to-text"}},
+			expected:  "Weather\nSunny and warm\nMon\nTue",
+		},
+		"redact": {
+			content:   `contact jane@example.com or john@example.com`,
+			functions: []arkv1alpha1.ToolFunction{{Name: "redact", Value: `[\w.]+@[\w.]+`}},
+			expected:  `contact [REDACTED] or [REDACTED]`,
+		},
+		"functions are applied in order": {
+			content: `<p>token: abc123</p>`,
+			functions: []arkv1alpha1.ToolFunction{
+				{Name: "html-to-text"},
+				{Name: "redact", Value: `abc\d+`},
+				{Name: "truncate", Value: "12"},
+			},
+			expected: "token: [REDA" + truncationMarker,
+		},
+	}
+
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			result := executeFunctions(t, tc.content, tc.functions...)
+			assert.Empty(t, result.Error)
+			assert.Equal(t, tc.expected, result.Content)
+		})
+	}
+}
+
+func TestToolFunctionFailures(t *testing.T) {
+	t.Run("jq reports non-JSON output", func(t *testing.T) {
+		result := executeFunctions(t, "<html>not json</html>", arkv1alpha1.ToolFunction{Name: "jq", Value: ".items"})
+		assert.Contains(t, result.Error, "could not be processed by function jq (functions[0])")
+		assert.Contains(t, result.Error, `jq requires JSON output, got: "<html>not json</html>"`)
+		assert.Equal(t, result.Error, result.Content)
+	})
+
+	t.Run("unknown functions are reported", func(t *testing.T) {
+		result := executeFunctions(t, "{}", arkv1alpha1.ToolFunction{Name: "summarize"})
+		assert.Contains(t, result.Error, "unsupported function 'summarize'")
+	})
+
+	t.Run("error results are passed through", func(t *testing.T) {
+		executor := &FilteredToolExecutor{
+			BaseExecutor: failingToolExecutor{},
+			Functions:    []arkv1alpha1.ToolFunction{{Name: "jq", Value: ".items"}},
+		}
+		result, err := executor.Execute(context.Background(), toolCallWithArguments(`{}`))
+		require.NoError(t, err)
+		assert.Equal(t, "service unavailable", result.Content)
+	})
+}
+
+func TestValidateToolFunction(t *testing.T) {
+	valid := []arkv1alpha1.ToolFunction{
+		{Name: "jq", Value: ".items"},
+		{Name: "truncate", Value: "4096"},
+		{Name: "truncate", Value: "1000 tokens"},
+		{Name: "template", Value: "{{ toJson .result }}"},
+		{Name: "jsonpath", Value: "{.items[*].name}"},
+		{Name: "html-to-text"},
+		{Name: "redact", Value: `\d{16}`},
+	}
+	for _, fn := range valid {
+		assert.NoError(t, ValidateToolFunction(fn), fn.Name+" "+fn.Value)
+	}
+
+	invalid := []arkv1alpha1.ToolFunction{
+		{Name: "jq", Value: ".items["},
+		{Name: "truncate", Value: "0"},
+		{Name: "truncate", Value: "10 lines"},
+		{Name: "template", Value: "{{ .result"},
+		{Name: "jsonpath"},
+		{Name: "redact", Value: "("},
+		{Name: "summarize"},
+	}
+	for _, fn := range invalid {
+		assert.Error(t, ValidateToolFunction(fn), fn.Name+" "+fn.Value)
+	}
+}
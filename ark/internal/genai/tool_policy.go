@@ -0,0 +1,319 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/google/cel-go/cel"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// ToolCaller identifies who uses tools when ToolPolicies are evaluated.
+type ToolCaller struct {
+	Agent          string
+	AgentLabels    map[string]string
+	Team           string
+	ServiceAccount string
+}
+
+// toolPolicyBinding holds the policies that apply to a registered tool, to check its calls.
+type toolPolicyBinding struct {
+	toolName string
+	policies []arkv1alpha1.ToolPolicy
+}
+
+// toolPolicyDecision is the result of evaluating the policies of a tool.
+type toolPolicyDecision struct {
+	allowed bool
+	policy  string
+	message string
+}
+
+// reason describes a denial for the agent and the operation events.
+func (d toolPolicyDecision) reason(toolName string) string {
+	if d.message != "" {
+		return fmt.Sprintf("Tool %s was denied by policy %s: %s", toolName, d.policy, d.message)
+	}
+	return fmt.Sprintf("Tool %s was denied by policy %s", toolName, d.policy)
+}
+
+// ruleMatch is the result of matching a rule. Matches are deferred when they depend on the call, such as its
+// arguments or the team the agent runs in.
+type ruleMatch int
+
+const (
+	ruleNotMatched ruleMatch = iota
+	ruleMatched
+	ruleDeferred
+)
+
+var (
+	toolPolicyEnv      = sync.OnceValues(newToolPolicyEnv)
+	toolPolicyPrograms sync.Map // condition -> cel.Program
+)
+
+func newToolPolicyEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("args", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("tool", cel.StringType),
+		cel.Variable("agent", cel.StringType),
+		cel.Variable("team", cel.StringType),
+		cel.Variable("serviceAccount", cel.StringType),
+	)
+}
+
+// CompileToolPolicyCondition compiles the CEL condition of a ToolPolicy rule, which must evaluate to a bool.
+func CompileToolPolicyCondition(condition string) (cel.Program, error) {
+	if program, exists := toolPolicyPrograms.Load(condition); exists {
+		return program.(cel.Program), nil
+	}
+
+	env, err := toolPolicyEnv()
+	if err != nil {
+		return nil, err
+	}
+	ast, issues := env.Compile(condition)
+	if issues != nil && issues.Err() != nil {
+		return nil, issues.Err()
+	}
+	if ast.OutputType() != cel.BoolType {
+		return nil, fmt.Errorf("condition must evaluate to bool, not %s", ast.OutputType())
+	}
+	program, err := env.Program(ast)
+	if err != nil {
+		return nil, err
+	}
+
+	toolPolicyPrograms.Store(condition, program)
+	return program, nil
+}
+
+// listToolPolicies lists the ToolPolicies of a namespace. Errors are returned, so that tools are not used
+// when their policies cannot be read.
+func listToolPolicies(ctx context.Context, k8sClient client.Client, namespace string) ([]arkv1alpha1.ToolPolicy, error) {
+	var policies arkv1alpha1.ToolPolicyList
+	if err := k8sClient.List(ctx, &policies, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list tool policies in namespace %s: %w", namespace, err)
+	}
+	return policies.Items, nil
+}
+
+// toolPoliciesFor returns the policies that select the tool.
+func toolPoliciesFor(policies []arkv1alpha1.ToolPolicy, tool *arkv1alpha1.Tool) []arkv1alpha1.ToolPolicy {
+	var selected []arkv1alpha1.ToolPolicy
+	for _, policy := range policies {
+		if toolPolicySelects(policy, tool) {
+			selected = append(selected, policy)
+		}
+	}
+	return selected
+}
+
+func toolPolicySelects(policy arkv1alpha1.ToolPolicy, tool *arkv1alpha1.Tool) bool {
+	if len(policy.Spec.Tools) == 0 && policy.Spec.ToolSelector == nil {
+		return true
+	}
+	for _, name := range policy.Spec.Tools {
+		if name == tool.Name {
+			return true
+		}
+	}
+	return matchesLabelSelector(policy.Spec.ToolSelector, tool.Labels)
+}
+
+func matchesLabelSelector(labelSelector *metav1.LabelSelector, objectLabels map[string]string) bool {
+	if labelSelector == nil {
+		return false
+	}
+	selector, err := metav1.LabelSelectorAsSelector(labelSelector)
+	if err != nil {
+		return false
+	}
+	return selector.Matches(labels.Set(objectLabels))
+}
+
+// evaluateToolPolicies decides whether the caller may use the tool. A matching Deny rule denies the tool, and
+// every policy with Allow rules must have one that matches. Without arguments, rules that depend on the call
+// are deferred: they do not deny the tool, but count as allowing it.
+func evaluateToolPolicies(ctx context.Context, policies []arkv1alpha1.ToolPolicy, toolName string, caller ToolCaller, arguments map[string]any) toolPolicyDecision {
+	atCall := arguments != nil
+	variables := map[string]any{
+		"args":           arguments,
+		"tool":           toolName,
+		"agent":          caller.Agent,
+		"team":           caller.Team,
+		"serviceAccount": caller.ServiceAccount,
+	}
+	if !atCall {
+		variables["args"] = map[string]any{}
+	}
+
+	for _, policy := range policies {
+		hasAllowRules, allowed := false, false
+		for _, rule := range policy.Spec.Rules {
+			match := matchToolPolicyRule(ctx, rule, caller, variables, atCall)
+			switch rule.Effect {
+			case arkv1alpha1.ToolPolicyEffectDeny:
+				if match == ruleMatched {
+					return toolPolicyDecision{policy: policy.Name, message: rule.Message}
+				}
+			case arkv1alpha1.ToolPolicyEffectAllow:
+				hasAllowRules = true
+				if match != ruleNotMatched {
+					allowed = true
+				}
+			}
+		}
+		if hasAllowRules && !allowed {
+			return toolPolicyDecision{policy: policy.Name, message: "no rule allows it"}
+		}
+	}
+	return toolPolicyDecision{allowed: true}
+}
+
+func matchToolPolicyRule(ctx context.Context, rule arkv1alpha1.ToolPolicyRule, caller ToolCaller, variables map[string]any, atCall bool) ruleMatch {
+	match := matchToolPolicySubjects(rule.Subjects, caller, atCall)
+	if match == ruleNotMatched || rule.Condition == "" {
+		return match
+	}
+	if !atCall {
+		return ruleDeferred
+	}
+
+	conditionMet, err := evaluateToolPolicyCondition(rule.Condition, variables)
+	if err != nil {
+		// A condition that cannot be evaluated denies the call: Deny rules match and Allow rules do not
+		logf.FromContext(ctx).Error(err, "failed to evaluate tool policy condition", "condition", rule.Condition)
+		if rule.Effect == arkv1alpha1.ToolPolicyEffectDeny {
+			return ruleMatched
+		}
+		return ruleNotMatched
+	}
+	if !conditionMet {
+		return ruleNotMatched
+	}
+	return match
+}
+
+func matchToolPolicySubjects(subjects []arkv1alpha1.ToolPolicySubject, caller ToolCaller, atCall bool) ruleMatch {
+	if len(subjects) == 0 {
+		return ruleMatched
+	}
+
+	match := ruleNotMatched
+	for _, subject := range subjects {
+		switch subject.Kind {
+		case arkv1alpha1.ToolPolicySubjectAgent:
+			if (subject.Name != "" && subject.Name == caller.Agent) || matchesLabelSelector(subject.Selector, caller.AgentLabels) {
+				return ruleMatched
+			}
+		case arkv1alpha1.ToolPolicySubjectServiceAccount:
+			if subject.Name != "" && subject.Name == caller.ServiceAccount {
+				return ruleMatched
+			}
+		case arkv1alpha1.ToolPolicySubjectTeam:
+			// Agents are created before the team they run in is known
+			if !atCall {
+				match = ruleDeferred
+			} else if subject.Name != "" && subject.Name == caller.Team {
+				return ruleMatched
+			}
+		}
+	}
+	return match
+}
+
+func evaluateToolPolicyCondition(condition string, variables map[string]any) (bool, error) {
+	program, err := CompileToolPolicyCondition(condition)
+	if err != nil {
+		return false, err
+	}
+	result, _, err := program.Eval(variables)
+	if err != nil {
+		return false, err
+	}
+	conditionMet, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition evaluated to %v, not a bool", result.Value())
+	}
+	return conditionMet, nil
+}
+
+// ApplyToolPolicies checks a tool registered with RegisterTool against the ToolPolicies of its namespace, and
+// checks its calls as well. It returns an error when the policies deny the tool to the caller.
+func (tr *ToolRegistry) ApplyToolPolicies(ctx context.Context, k8sClient client.Client, tool *arkv1alpha1.Tool, name string, caller ToolCaller) error {
+	policies, err := listToolPolicies(ctx, k8sClient, tool.Namespace)
+	if err != nil {
+		return err
+	}
+	tr.toolPolicies = policies
+	tr.caller = caller
+
+	if decision := tr.bindToolPolicies(ctx, tool, name); !decision.allowed {
+		return fmt.Errorf("%s", decision.reason(name))
+	}
+	return nil
+}
+
+// bindToolPolicies evaluates the policies that select the tool when it is registered, and keeps them to check
+// its calls. Denials are recorded as operation events.
+func (tr *ToolRegistry) bindToolPolicies(ctx context.Context, tool *arkv1alpha1.Tool, name string) toolPolicyDecision {
+	policies := toolPoliciesFor(tr.toolPolicies, tool)
+	if len(policies) == 0 {
+		return toolPolicyDecision{allowed: true}
+	}
+
+	decision := evaluateToolPolicies(ctx, policies, tool.Name, tr.caller, nil)
+	if !decision.allowed {
+		tr.recordToolPolicyDenial(ctx, name, tr.caller, decision)
+		return decision
+	}
+
+	tr.policyBindings[name] = &toolPolicyBinding{toolName: tool.Name, policies: policies}
+	return decision
+}
+
+// checkToolCall evaluates the policies of a tool for a call, with its arguments and the team it is made in.
+func (tr *ToolRegistry) checkToolCall(ctx context.Context, binding *toolPolicyBinding, call ToolCall) toolPolicyDecision {
+	arguments := map[string]any{}
+	if call.Function.Arguments != "" {
+		// Arguments that are not an object are checked as empty, conditions on them do not match
+		_ = json.Unmarshal([]byte(call.Function.Arguments), &arguments)
+	}
+
+	caller := tr.caller
+	if team, ok := GetExecutionMetadata(ctx)["team"].(string); ok {
+		caller.Team = team
+	}
+
+	decision := evaluateToolPolicies(ctx, binding.policies, binding.toolName, caller, arguments)
+	if !decision.allowed {
+		tr.recordToolPolicyDenial(ctx, call.Function.Name, caller, decision)
+	}
+	return decision
+}
+
+func (tr *ToolRegistry) recordToolPolicyDenial(ctx context.Context, name string, caller ToolCaller, decision toolPolicyDecision) {
+	reason := decision.reason(name)
+	logf.FromContext(ctx).Info("tool denied by policy", "tool", name, "policy", decision.policy,
+		"agent", caller.Agent, "team", caller.Team, "serviceAccount", caller.ServiceAccount)
+
+	operationData := map[string]string{
+		"toolName":       name,
+		"policy":         decision.policy,
+		"agent":          caller.Agent,
+		"team":           caller.Team,
+		"serviceAccount": caller.ServiceAccount,
+	}
+	ctx = tr.eventingRecorder.Start(ctx, "ToolPolicy", fmt.Sprintf("Checking policies of tool %s", name), operationData)
+	tr.eventingRecorder.Fail(ctx, "ToolPolicy", reason, fmt.Errorf("%s", reason), operationData)
+}
@@ -0,0 +1,221 @@
+package genai
+
+import (
+	"context"
+	"testing"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	eventnoop "mckinsey.com/ark/internal/eventing/noop"
+	"mckinsey.com/ark/internal/telemetry/noop"
+)
+
+func newTestToolPolicy(name string, rules ...arkv1alpha1.ToolPolicyRule) arkv1alpha1.ToolPolicy {
+	return arkv1alpha1.ToolPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+		Spec:       arkv1alpha1.ToolPolicySpec{Rules: rules},
+	}
+}
+
+func TestEvaluateToolPolicies(t *testing.T) {
+	ctx := context.Background()
+	caller := ToolCaller{Agent: "researcher", AgentLabels: map[string]string{"tier": "trusted"}, ServiceAccount: "analyst"}
+	agentSubject := func(name string) []arkv1alpha1.ToolPolicySubject {
+		return []arkv1alpha1.ToolPolicySubject{{Kind: arkv1alpha1.ToolPolicySubjectAgent, Name: name}}
+	}
+
+	tests := []struct {
+		name      string
+		policies  []arkv1alpha1.ToolPolicy
+		caller    ToolCaller
+		arguments map[string]any
+		allowed   bool
+		message   string
+	}{
+		{
+			name:     "allows tools without policies",
+			policies: nil,
+			allowed:  true,
+		},
+		{
+			name: "denies matching subjects",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("no-research", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectDeny, Subjects: agentSubject("researcher"), Message: "not for research",
+			})},
+			allowed: false,
+			message: "not for research",
+		},
+		{
+			name: "requires an allow rule to match",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("writers-only", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectAllow, Subjects: agentSubject("writer"),
+			})},
+			allowed: false,
+			message: "no rule allows it",
+		},
+		{
+			name: "allows agents by label",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("trusted", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectAllow,
+				Subjects: []arkv1alpha1.ToolPolicySubject{{
+					Kind:     arkv1alpha1.ToolPolicySubjectAgent,
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "trusted"}},
+				}},
+			})},
+			allowed: true,
+		},
+		{
+			name: "deny wins over allow",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("mixed",
+				arkv1alpha1.ToolPolicyRule{Effect: arkv1alpha1.ToolPolicyEffectAllow},
+				arkv1alpha1.ToolPolicyRule{
+					Effect:   arkv1alpha1.ToolPolicyEffectDeny,
+					Subjects: []arkv1alpha1.ToolPolicySubject{{Kind: arkv1alpha1.ToolPolicySubjectServiceAccount, Name: "analyst"}},
+				},
+			)},
+			allowed: false,
+		},
+		{
+			name: "defers conditions until the call",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("small-amounts", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectDeny, Condition: "args.amount > 100",
+			})},
+			allowed: true,
+		},
+		{
+			name: "denies calls matching a condition",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("small-amounts", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectDeny, Condition: "args.amount > 100",
+			})},
+			arguments: map[string]any{"amount": 500},
+			allowed:   false,
+		},
+		{
+			name: "allows calls not matching a condition",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("small-amounts", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectDeny, Condition: "args.amount > 100",
+			})},
+			arguments: map[string]any{"amount": 50},
+			allowed:   true,
+		},
+		{
+			name: "denies calls when a condition cannot be evaluated",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("small-amounts", arkv1alpha1.ToolPolicyRule{
+				Effect: arkv1alpha1.ToolPolicyEffectDeny, Condition: "args.amount > 100",
+			})},
+			arguments: map[string]any{},
+			allowed:   false,
+		},
+		{
+			name: "defers team subjects until the call",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("team-only", arkv1alpha1.ToolPolicyRule{
+				Effect:   arkv1alpha1.ToolPolicyEffectAllow,
+				Subjects: []arkv1alpha1.ToolPolicySubject{{Kind: arkv1alpha1.ToolPolicySubjectTeam, Name: "finance"}},
+			})},
+			allowed: true,
+		},
+		{
+			name: "matches teams at the call",
+			policies: []arkv1alpha1.ToolPolicy{newTestToolPolicy("team-only", arkv1alpha1.ToolPolicyRule{
+				Effect:   arkv1alpha1.ToolPolicyEffectAllow,
+				Subjects: []arkv1alpha1.ToolPolicySubject{{Kind: arkv1alpha1.ToolPolicySubjectTeam, Name: "finance"}},
+			})},
+			caller:    ToolCaller{Agent: "researcher", Team: "marketing"},
+			arguments: map[string]any{},
+			allowed:   false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testCaller := caller
+			if tt.caller.Agent != "" {
+				testCaller = tt.caller
+			}
+			decision := evaluateToolPolicies(ctx, tt.policies, "payments", testCaller, tt.arguments)
+			assert.Equal(t, tt.allowed, decision.allowed)
+			if tt.message != "" {
+				assert.Equal(t, tt.message, decision.message)
+			}
+		})
+	}
+}
+
+func TestToolPolicySelects(t *testing.T) {
+	tool := &arkv1alpha1.Tool{ObjectMeta: metav1.ObjectMeta{Name: "payments", Labels: map[string]string{"risk": "high"}}}
+
+	assert.True(t, toolPolicySelects(newTestToolPolicy("all"), tool))
+
+	byName := newTestToolPolicy("by-name")
+	byName.Spec.Tools = []string{"payments"}
+	assert.True(t, toolPolicySelects(byName, tool))
+
+	byLabel := newTestToolPolicy("by-label")
+	byLabel.Spec.ToolSelector = &metav1.LabelSelector{MatchLabels: map[string]string{"risk": "low"}}
+	assert.False(t, toolPolicySelects(byLabel, tool))
+}
+
+func TestCompileToolPolicyCondition(t *testing.T) {
+	_, err := CompileToolPolicyCondition(`tool == "payments" && args.amount < 100`)
+	require.NoError(t, err)
+
+	_, err = CompileToolPolicyCondition(`args.amount`)
+	assert.ErrorContains(t, err, "must evaluate to bool")
+
+	_, err = CompileToolPolicyCondition(`unknown == 1`)
+	assert.Error(t, err)
+}
+
+func TestToolRegistryEnforcesToolPolicies(t *testing.T) {
+	ctx := context.Background()
+	tools := []client.Object{
+		&arkv1alpha1.Tool{
+			ObjectMeta: metav1.ObjectMeta{Name: "noop", Namespace: "default"},
+			Spec:       arkv1alpha1.ToolSpec{Type: ToolTypeBuiltin},
+		},
+		&arkv1alpha1.Tool{
+			ObjectMeta: metav1.ObjectMeta{Name: "terminate", Namespace: "default"},
+			Spec:       arkv1alpha1.ToolSpec{Type: ToolTypeBuiltin},
+		},
+	}
+	deniedTool := newTestToolPolicy("no-terminate", arkv1alpha1.ToolPolicyRule{Effect: arkv1alpha1.ToolPolicyEffectDeny})
+	deniedTool.Spec.Tools = []string{"terminate"}
+	deniedCall := newTestToolPolicy("quiet-noop", arkv1alpha1.ToolPolicyRule{
+		Effect: arkv1alpha1.ToolPolicyEffectDeny, Condition: `has(args.message)`, Message: "messages are not allowed",
+	})
+	deniedCall.Spec.Tools = []string{"noop"}
+
+	agent := &arkv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "researcher", Namespace: "default"},
+		Spec: arkv1alpha1.AgentSpec{Tools: []arkv1alpha1.AgentTool{
+			{Type: "built-in", Name: "noop"},
+			{Type: "built-in", Name: "terminate"},
+		}},
+	}
+	k8sClient := setupTestClientForTools(append(tools, &deniedTool, &deniedCall))
+
+	telemetryProvider := noop.NewProvider()
+	eventingProvider := eventnoop.NewProvider()
+	registry := NewToolRegistry(nil, telemetryProvider.ToolRecorder(), eventingProvider.ToolRecorder())
+	require.NoError(t, registry.registerTools(ctx, k8sClient, agent, telemetryProvider, eventingProvider))
+
+	definitions := registry.GetToolDefinitions()
+	require.Len(t, definitions, 1)
+	assert.Equal(t, "noop", definitions[0].Name)
+
+	call := func(arguments string) ToolCall {
+		return ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: "noop", Arguments: arguments}}
+	}
+	result, err := registry.ExecuteTool(ctx, call(`{}`))
+	require.NoError(t, err)
+	assert.Empty(t, result.Error)
+
+	result, err = registry.ExecuteTool(ctx, call(`{"message":"hello"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "Tool noop was denied by policy quiet-noop: messages are not allowed", result.Error)
+}
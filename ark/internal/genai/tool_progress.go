@@ -0,0 +1,143 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/openai/openai-go"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ToolProgress is a progress update reported by a running tool call.
+type ToolProgress struct {
+	Progress float64
+	// Total is zero when unknown
+	Total   float64
+	Message string
+}
+
+// String describes the progress for users, such as "indexing 40/100".
+func (p ToolProgress) String() string {
+	progress := strconv.FormatFloat(p.Progress, 'f', -1, 64)
+	if p.Total > 0 {
+		progress += "/" + strconv.FormatFloat(p.Total, 'f', -1, 64)
+	}
+	if p.Message == "" {
+		return progress
+	}
+	return p.Message + " " + progress
+}
+
+// toolProgressEventInterval limits how often the progress of a tool call is recorded as an event, as tools may
+// report progress many times a second.
+const toolProgressEventInterval = 5 * time.Second
+
+type toolProgressKey struct{}
+
+// withToolProgressReporter returns a context in which tool calls report their progress to report.
+func withToolProgressReporter(ctx context.Context, report func(ToolProgress)) context.Context {
+	return context.WithValue(ctx, toolProgressKey{}, report)
+}
+
+// reportToolProgress reports progress to the reporter of the context, if any.
+func reportToolProgress(ctx context.Context, progress ToolProgress) {
+	if report, ok := ctx.Value(toolProgressKey{}).(func(ToolProgress)); ok {
+		report(progress)
+	}
+}
+
+func hasToolProgressReporter(ctx context.Context) bool {
+	_, ok := ctx.Value(toolProgressKey{}).(func(ToolProgress))
+	return ok
+}
+
+// ToolProgressMetadata describes the progress of a tool call in the metadata of streaming chunks.
+type ToolProgressMetadata struct {
+	Tool       string  `json:"tool"`
+	ToolCallID string  `json:"toolCallId"`
+	Progress   float64 `json:"progress"`
+	Total      float64 `json:"total,omitempty"`
+	Message    string  `json:"message,omitempty"`
+}
+
+// WithToolProgressStream returns a context in which the progress of a tool call is streamed to the event stream,
+// if available.
+func WithToolProgressStream(ctx context.Context, eventStream EventStreamInterface, toolName, toolCallID string) context.Context {
+	if eventStream == nil {
+		return ctx
+	}
+	return withToolProgressReporter(ctx, func(progress ToolProgress) {
+		streamToolProgress(ctx, eventStream, toolName, toolCallID, progress)
+	})
+}
+
+// streamToolProgress streams the progress of a tool call as a chunk without choices, so that OpenAI clients ignore
+// it, with the progress in its ARK metadata.
+func streamToolProgress(ctx context.Context, eventStream EventStreamInterface, toolName, toolCallID string, progress ToolProgress) {
+	chunk := &openai.ChatCompletionChunk{
+		ID:      toolCallID,
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Choices: []openai.ChatCompletionChunkChoice{},
+	}
+	metadata := buildMetadata(ctx, "")
+	metadata.ToolProgress = &ToolProgressMetadata{
+		Tool:       toolName,
+		ToolCallID: toolCallID,
+		Progress:   progress.Progress,
+		Total:      progress.Total,
+		Message:    progress.Message,
+	}
+	if err := eventStream.StreamChunk(ctx, ChunkWithMetadata{ChatCompletionChunk: chunk, Ark: metadata}); err != nil {
+		logf.FromContext(ctx).Error(err, "failed to send tool progress chunk to event stream", "tool", toolName)
+	}
+}
+
+// toolProgressEvents records the progress of a tool call as events at most every toolProgressEventInterval. The
+// last progress held back is recorded by flush, when the call ends.
+type toolProgressEvents struct {
+	record func(ToolProgress)
+
+	mu         sync.Mutex
+	recordedAt time.Time
+	pending    *ToolProgress
+}
+
+func (e *toolProgressEvents) report(progress ToolProgress) {
+	e.mu.Lock()
+	if !e.recordedAt.IsZero() && time.Since(e.recordedAt) < toolProgressEventInterval {
+		e.pending = &progress
+		e.mu.Unlock()
+		return
+	}
+	e.recordedAt = time.Now()
+	e.pending = nil
+	e.mu.Unlock()
+	e.record(progress)
+}
+
+func (e *toolProgressEvents) flush() {
+	e.mu.Lock()
+	pending := e.pending
+	e.pending = nil
+	e.mu.Unlock()
+	if pending != nil {
+		e.record(*pending)
+	}
+}
+
+// toolProgressData describes progress in the data of operation events.
+func toolProgressData(progress ToolProgress) map[string]string {
+	data := map[string]string{"progress": strconv.FormatFloat(progress.Progress, 'f', -1, 64)}
+	if progress.Total > 0 {
+		data["total"] = strconv.FormatFloat(progress.Total, 'f', -1, 64)
+	}
+	if progress.Message != "" {
+		data["progressMessage"] = progress.Message
+	}
+	return data
+}
@@ -3,6 +3,7 @@ package genai
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
@@ -133,6 +134,18 @@ func (h *HTTPExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult,
 		req.Header.Set(header.Name, value)
 	}
 
+	tokenSource, err := ResolveAuthTokenSource(ctx, h.K8sClient, httpSpec.Auth, tool.Namespace)
+	if err == nil {
+		err = setBearerToken(req, tokenSource)
+	}
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to authenticate: %v", err),
+		}, fmt.Errorf("failed to authenticate: %w", err)
+	}
+
 	// Set timeout
 	timeout := h.getTimeout(httpSpec.Timeout)
 	httpClient := &http.Client{Timeout: timeout}
@@ -186,6 +199,10 @@ type ToolRegistry struct {
 	mcpSettings       map[string]MCPSettings // MCP settings per MCP server (namespace/name)
 	telemetryRecorder telemetry.ToolRecorder
 	eventingRecorder  eventing.ToolRecorder
+	// ToolPolicies of the namespace, the caller they are evaluated for, and the policies of registered tools
+	toolPolicies   []arkv1alpha1.ToolPolicy
+	caller         ToolCaller
+	policyBindings map[string]*toolPolicyBinding
 }
 
 func NewToolRegistry(mcpSettings map[string]MCPSettings, telemetryRecorder telemetry.ToolRecorder, eventingRecorder eventing.ToolRecorder) *ToolRegistry {
@@ -196,6 +213,7 @@ func NewToolRegistry(mcpSettings map[string]MCPSettings, telemetryRecorder telem
 		mcpSettings:       mcpSettings,
 		telemetryRecorder: telemetryRecorder,
 		eventingRecorder:  eventingRecorder,
+		policyBindings:    make(map[string]*toolPolicyBinding),
 	}
 }
 
@@ -217,16 +235,29 @@ func (tr *ToolRegistry) GetToolType(toolName string) string {
 	if !exists {
 		return "unknown"
 	}
+	return executorType(executor)
+}
 
-	switch executor.(type) {
+func executorType(executor ToolExecutor) string {
+	switch e := executor.(type) {
+	case *ArgumentValidatingExecutor:
+		return executorType(e.BaseExecutor)
+	case *CachingToolExecutor:
+		return executorType(e.BaseExecutor)
 	case *NoopExecutor:
 		return "builtin"
 	case *TerminateExecutor:
 		return "builtin"
+	case *TeamStateGetExecutor, *TeamStateSetExecutor, *KubernetesToolExecutor:
+		return "builtin"
 	case *HTTPExecutor:
 		return "custom"
 	case *MCPExecutor:
 		return "mcp"
+	case *GraphQLExecutor:
+		return "graphql"
+	case *WasmExecutor:
+		return "wasm"
 	case *FilteredToolExecutor:
 		return "filtered"
 	default:
@@ -244,6 +275,13 @@ func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall) (ToolRes
 		}, fmt.Errorf("tool %s not found", call.Function.Name)
 	}
 
+	if binding, exists := tr.policyBindings[call.Function.Name]; exists {
+		if decision := tr.checkToolCall(ctx, binding, call); !decision.allowed {
+			reason := decision.reason(call.Function.Name)
+			return ToolResult{ID: call.ID, Name: call.Function.Name, Content: reason, Error: reason}, nil
+		}
+	}
+
 	toolType := tr.GetToolType(call.Function.Name)
 	ctx, span := tr.telemetryRecorder.StartToolExecution(ctx, call.Function.Name, toolType, call.ID, call.Function.Arguments)
 	defer span.End()
@@ -256,7 +294,18 @@ func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall) (ToolRes
 	}
 	ctx = tr.eventingRecorder.Start(ctx, "ToolCall", fmt.Sprintf("Executing tool %s", call.Function.Name), operationData)
 
+	// Progress of the call is passed on to the reporter of the caller, and recorded as throttled operation events
+	operationCtx := ctx
+	progressEvents := &toolProgressEvents{record: func(progress ToolProgress) {
+		tr.eventingRecorder.Progress(operationCtx, "ToolCall", fmt.Sprintf("Tool %s: %s", call.Function.Name, progress), toolProgressData(progress))
+	}}
+	ctx = withToolProgressReporter(ctx, func(progress ToolProgress) {
+		progressEvents.report(progress)
+		reportToolProgress(operationCtx, progress)
+	})
+
 	result, err := executor.Execute(ctx, call)
+	progressEvents.flush()
 	if err != nil {
 		tr.telemetryRecorder.RecordError(span, err)
 		if IsTerminateTeam(err) {
@@ -268,6 +317,19 @@ func (tr *ToolRegistry) ExecuteTool(ctx context.Context, call ToolCall) (ToolRes
 		return result, err
 	}
 
+	// Errors returned to the model still fail the tool call
+	if result.Error != "" {
+		toolErr := errors.New(result.Error)
+		tr.telemetryRecorder.RecordError(span, toolErr)
+		tr.eventingRecorder.Fail(ctx, "ToolCall", fmt.Sprintf("Tool execution failed: %s", result.Error), toolErr, operationData)
+		return result, nil
+	}
+
+	if result.Cached {
+		operationData["cacheHit"] = "true"
+		tr.telemetryRecorder.RecordCacheHit(span)
+	}
+
 	tr.telemetryRecorder.RecordToolResult(span, result.Content)
 	tr.telemetryRecorder.RecordSuccess(span)
 	tr.eventingRecorder.Complete(ctx, "ToolCall", "Tool execution completed successfully", operationData)
@@ -371,6 +433,11 @@ func GetTerminateTool() ToolDefinition {
 }
 
 func (h *HTTPExecutor) getTimeout(timeoutStr string) time.Duration {
+	return toolTimeout(timeoutStr)
+}
+
+// toolTimeout parses the request timeout of a tool, defaulting to 30 seconds.
+func toolTimeout(timeoutStr string) time.Duration {
 	if timeoutStr == "" {
 		return 30 * time.Second
 	}
@@ -385,7 +452,7 @@ func (h *HTTPExecutor) getTimeout(timeoutStr string) time.Duration {
 
 func (h *HTTPExecutor) substituteURLParameters(urlTemplate string, arguments map[string]any) string {
 	if arguments == nil {
-		return urlTemplate
+		return dropUnresolvedQueryParameters(urlTemplate)
 	}
 
 	paramRegex := regexp.MustCompile(`\{([^}]+)\}`)
@@ -407,7 +474,31 @@ func (h *HTTPExecutor) substituteURLParameters(urlTemplate string, arguments map
 		}
 	}
 
-	return result
+	return dropUnresolvedQueryParameters(result)
+}
+
+// dropUnresolvedQueryParameters removes query parameters whose placeholder was not filled in,
+// so that optional query parameters can be left out of a call.
+func dropUnresolvedQueryParameters(rawURL string) string {
+	base, query, found := strings.Cut(rawURL, "?")
+	if !found {
+		return rawURL
+	}
+
+	placeholderRegex := regexp.MustCompile(`^\{[^}]+\}$`)
+	var kept []string
+	for _, pair := range strings.Split(query, "&") {
+		_, value, _ := strings.Cut(pair, "=")
+		if placeholderRegex.MatchString(value) {
+			continue
+		}
+		kept = append(kept, pair)
+	}
+
+	if len(kept) == 0 {
+		return base
+	}
+	return base + "?" + strings.Join(kept, "&")
 }
 
 func CreateToolFromCRD(toolCRD *arkv1alpha1.Tool) ToolDefinition {
@@ -527,7 +618,16 @@ func getDefaultToolDescription(toolCRD *arkv1alpha1.Tool) string {
 		if toolCRD.Spec.HTTP != nil {
 			return fmt.Sprintf("HTTP request to %s", toolCRD.Spec.HTTP.URL)
 		}
+	case ToolTypeGraphQL:
+		if toolCRD.Spec.GraphQL != nil {
+			return fmt.Sprintf("GraphQL operation on %s", toolCRD.Spec.GraphQL.Endpoint)
+		}
+	case ToolTypeWasm:
+		return fmt.Sprintf("WebAssembly function: %s", toolCRD.Name)
 	case ToolTypeBuiltin:
+		if description, exists := kubernetesToolDescriptions[BuiltinToolName(toolCRD)]; exists {
+			return description
+		}
 		// For builtin tools, use the description from the CRD itself
 		return fmt.Sprintf("Built-in tool: %s", toolCRD.Name)
 	default:
@@ -542,8 +642,8 @@ func getToolParameters(toolCRD *arkv1alpha1.Tool) map[string]any {
 		"properties": map[string]any{},
 	}
 
-	if toolCRD.Spec.InputSchema != nil && len(toolCRD.Spec.InputSchema.Raw) > 0 {
-		if err := json.Unmarshal(toolCRD.Spec.InputSchema.Raw, &parameters); err != nil {
+	if raw := toolInputSchema(toolCRD); len(raw) > 0 {
+		if err := json.Unmarshal(raw, &parameters); err != nil {
 			logf.Log.Error(err, "failed to unmarshal tool input schema")
 		}
 	}
@@ -551,6 +651,36 @@ func getToolParameters(toolCRD *arkv1alpha1.Tool) map[string]any {
 	return parameters
 }
 
+// toolInputSchema returns the input schema of a tool. GraphQL tools without an input schema get one generated
+// from the variables of their operation, Kubernetes built-in tools one with their allowed kinds and namespaces.
+func toolInputSchema(toolCRD *arkv1alpha1.Tool) []byte {
+	if toolCRD.Spec.InputSchema != nil && len(toolCRD.Spec.InputSchema.Raw) > 0 {
+		return toolCRD.Spec.InputSchema.Raw
+	}
+
+	var schema map[string]any
+	switch {
+	case toolCRD.Spec.Type == ToolTypeGraphQL && toolCRD.Spec.GraphQL != nil:
+		var err error
+		if schema, err = graphQLInputSchema(toolCRD.Spec.GraphQL); err != nil {
+			logf.Log.Error(err, "failed to generate input schema from GraphQL query", "tool", toolCRD.Name)
+			return nil
+		}
+	case toolCRD.Spec.Type == ToolTypeBuiltin && toolCRD.Spec.Builtin != nil && toolCRD.Spec.Builtin.Kubernetes != nil &&
+		IsKubernetesBuiltinTool(BuiltinToolName(toolCRD)):
+		schema = kubernetesToolInputSchema(BuiltinToolName(toolCRD), toolCRD.Spec.Builtin.Kubernetes)
+	default:
+		return nil
+	}
+
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		logf.Log.Error(err, "failed to marshal generated GraphQL input schema", "tool", toolCRD.Name)
+		return nil
+	}
+	return raw
+}
+
 func CreateHTTPTool(toolCRD *arkv1alpha1.Tool) ToolDefinition {
 	return CreateToolFromCRD(toolCRD)
 }
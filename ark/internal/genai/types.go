@@ -43,6 +43,16 @@ type ToolResult struct {
 	Name    string `json:"name"`
 	Content string `json:"content,omitempty"`
 	Error   string `json:"error,omitempty"`
+	// Images returned by the tool, forwarded to models with the vision capability
+	Images []ToolResultImage `json:"images,omitempty"`
+	// Cached is set when the result was served from the tool result cache
+	Cached bool `json:"-"`
+}
+
+type ToolResultImage struct {
+	MIMEType string `json:"mimeType"`
+	// Data is base64 encoded
+	Data string `json:"data"`
 }
 
 type ToolExecutor interface {
@@ -0,0 +1,407 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"github.com/tetratelabs/wazero/sys"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+const (
+	defaultWasmMaxMemory = 64 << 20
+	wasmPageSize         = 64 << 10
+	maxWasmModuleBytes   = 64 << 20
+	maxWasmOutputBytes   = 1 << 20
+	maxWasmStderrBytes   = 4 << 10
+	// wasmModuleCacheTTL is how long downloaded modules are used before they are downloaded again
+	wasmModuleCacheTTL = 10 * time.Minute
+	// wasmModuleCacheBytes bounds the total size of the downloaded modules kept in memory
+	wasmModuleCacheBytes = 256 << 20
+)
+
+// wasmMagic starts every WebAssembly binary module
+var wasmMagic = []byte{0x00, 'a', 's', 'm'}
+
+// wasmCompilationCache shares compiled modules between the runtimes of all calls
+var wasmCompilationCache = wazero.NewCompilationCache()
+
+// wasmModules caches modules downloaded from URLs and registries
+var wasmModules = newWasmModuleCache(wasmModuleCacheBytes)
+
+// wasmModuleCache is a cache of modules bounded by their total size. Expired modules are dropped whenever a module
+// is added, and the least recently used modules are evicted when the cache is full. Concurrent calls for the same
+// module share a single fetch.
+type wasmModuleCache struct {
+	mu       sync.Mutex
+	maxBytes int
+	size     int
+	order    *list.List
+	entries  map[string]*list.Element
+	fetches  map[string]*wasmModuleFetch
+	now      func() time.Time
+}
+
+type wasmModuleCacheEntry struct {
+	key       string
+	module    []byte
+	fetchedAt time.Time
+}
+
+// wasmModuleFetch is a fetch in progress, whose result is available once done is closed.
+type wasmModuleFetch struct {
+	done   chan struct{}
+	module []byte
+	err    error
+}
+
+func newWasmModuleCache(maxBytes int) *wasmModuleCache {
+	return &wasmModuleCache{
+		maxBytes: maxBytes,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		fetches:  make(map[string]*wasmModuleFetch),
+		now:      time.Now,
+	}
+}
+
+// get returns the cached module for the key, or fetches and caches it.
+func (c *wasmModuleCache) get(ctx context.Context, key string, fetch func() ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	if element, exists := c.entries[key]; exists {
+		entry := element.Value.(*wasmModuleCacheEntry)
+		if c.now().Sub(entry.fetchedAt) < wasmModuleCacheTTL {
+			c.order.MoveToFront(element)
+			c.mu.Unlock()
+			return entry.module, nil
+		}
+		c.remove(element)
+	}
+	if pending, exists := c.fetches[key]; exists {
+		c.mu.Unlock()
+		select {
+		case <-pending.done:
+			return pending.module, pending.err
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	pending := &wasmModuleFetch{done: make(chan struct{})}
+	c.fetches[key] = pending
+	c.mu.Unlock()
+
+	pending.module, pending.err = fetch()
+
+	c.mu.Lock()
+	delete(c.fetches, key)
+	if pending.err == nil {
+		c.add(key, pending.module)
+	}
+	c.mu.Unlock()
+	close(pending.done)
+	return pending.module, pending.err
+}
+
+// add caches a module. It must be called with the lock held.
+func (c *wasmModuleCache) add(key string, module []byte) {
+	if len(module) > c.maxBytes {
+		return
+	}
+	if element, exists := c.entries[key]; exists {
+		c.remove(element)
+	}
+	for element := c.order.Back(); element != nil; {
+		previous := element.Prev()
+		if c.now().Sub(element.Value.(*wasmModuleCacheEntry).fetchedAt) >= wasmModuleCacheTTL {
+			c.remove(element)
+		}
+		element = previous
+	}
+	c.entries[key] = c.order.PushFront(&wasmModuleCacheEntry{key: key, module: module, fetchedAt: c.now()})
+	c.size += len(module)
+	for c.size > c.maxBytes {
+		c.remove(c.order.Back())
+	}
+}
+
+func (c *wasmModuleCache) remove(element *list.Element) {
+	entry := element.Value.(*wasmModuleCacheEntry)
+	c.order.Remove(element)
+	delete(c.entries, entry.key)
+	c.size -= len(entry.module)
+}
+
+// WasmExecutor executes WebAssembly tools
+type WasmExecutor struct {
+	K8sClient     client.Client
+	ToolName      string
+	ToolNamespace string
+}
+
+// wasmRunOptions configures a single run of a module.
+type wasmRunOptions struct {
+	Name      string
+	Stdin     []byte
+	Env       []arkv1alpha1.WasmEnvVar
+	MaxMemory int64
+	Timeout   time.Duration
+}
+
+// Execute implements ToolExecutor interface for WebAssembly tools
+func (w *WasmExecutor) Execute(ctx context.Context, call ToolCall) (ToolResult, error) {
+	tool := &arkv1alpha1.Tool{}
+	if err := w.K8sClient.Get(ctx, client.ObjectKey{Name: w.ToolName, Namespace: w.ToolNamespace}, tool); err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to get tool %s: %v", w.ToolName, err),
+		}, fmt.Errorf("failed to get tool %s: %w", w.ToolName, err)
+	}
+
+	spec := tool.Spec.Wasm
+	if spec == nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: "wasm spec is required",
+		}, fmt.Errorf("wasm spec is required")
+	}
+
+	module, err := loadWasmModule(ctx, w.K8sClient, &spec.Module, tool.Namespace)
+	if err != nil {
+		return ToolResult{
+			ID:    call.ID,
+			Name:  call.Function.Name,
+			Error: fmt.Sprintf("failed to load WebAssembly module: %v", err),
+		}, fmt.Errorf("failed to load WebAssembly module: %w", err)
+	}
+
+	arguments := call.Function.Arguments
+	if strings.TrimSpace(arguments) == "" {
+		arguments = "{}"
+	}
+
+	log := logf.FromContext(ctx).WithValues("tool", tool.Name, "toolID", call.ID)
+	log.Info("running WebAssembly module", "moduleBytes", len(module))
+
+	output, err := runWasmModule(ctx, module, wasmRunOptions{
+		Name:      tool.Name,
+		Stdin:     []byte(arguments),
+		Env:       spec.Env,
+		MaxMemory: wasmMaxMemory(spec),
+		Timeout:   toolTimeout(spec.Timeout),
+	})
+	if err != nil {
+		// Failures of the module are reported to the model, like errors of an HTTP API
+		message := fmt.Sprintf("WebAssembly module of tool %s failed: %v", tool.Name, err)
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: message, Error: message}, nil
+	}
+
+	output = bytes.TrimSpace(output)
+	if !json.Valid(output) {
+		message := fmt.Sprintf("WebAssembly module of tool %s returned output that is not JSON: %s", tool.Name, contentPreview(string(output)))
+		return ToolResult{ID: call.ID, Name: call.Function.Name, Content: message, Error: message}, nil
+	}
+
+	return ToolResult{
+		ID:      call.ID,
+		Name:    call.Function.Name,
+		Content: string(output),
+	}, nil
+}
+
+// wasmMaxMemory returns the memory limit of a module in bytes.
+func wasmMaxMemory(spec *arkv1alpha1.WasmSpec) int64 {
+	if spec.MaxMemory == nil || spec.MaxMemory.Value() <= 0 {
+		return defaultWasmMaxMemory
+	}
+	return spec.MaxMemory.Value()
+}
+
+// runWasmModule runs the WASI command module with the stdin of the options and returns its stdout. The
+// module gets no filesystem, no network, and only the environment variables of the options.
+func runWasmModule(ctx context.Context, module []byte, options wasmRunOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	pages := uint32((options.MaxMemory + wasmPageSize - 1) / wasmPageSize)
+	runtime := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().
+		WithCompilationCache(wasmCompilationCache).
+		WithMemoryLimitPages(pages).
+		WithCloseOnContextDone(true))
+	defer func() {
+		_ = runtime.Close(context.Background())
+	}()
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, runtime); err != nil {
+		return nil, fmt.Errorf("failed to instantiate WASI: %w", err)
+	}
+
+	compiled, err := runtime.CompileModule(ctx, module)
+	if err != nil {
+		return nil, fmt.Errorf("invalid module: %w", err)
+	}
+
+	stdout := &limitedBuffer{limit: maxWasmOutputBytes}
+	stderr := &limitedBuffer{limit: maxWasmStderrBytes}
+	config := wazero.NewModuleConfig().
+		WithName("").
+		WithArgs(options.Name).
+		WithStdin(bytes.NewReader(options.Stdin)).
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader)
+	for _, env := range options.Env {
+		config = config.WithEnv(env.Name, env.Value)
+	}
+
+	_, err = runtime.InstantiateModule(ctx, compiled, config)
+	var exitErr *sys.ExitError
+	switch {
+	case err == nil, errors.As(err, &exitErr) && exitErr.ExitCode() == 0:
+	case errors.As(err, &exitErr) && exitErr.ExitCode() == sys.ExitCodeDeadlineExceeded:
+		return nil, fmt.Errorf("exceeded the timeout of %s", options.Timeout)
+	case errors.As(err, &exitErr):
+		return nil, withStderr(fmt.Errorf("exited with code %d", exitErr.ExitCode()), stderr)
+	default:
+		return nil, withStderr(err, stderr)
+	}
+
+	if stdout.truncated {
+		return nil, fmt.Errorf("output exceeds %d bytes", maxWasmOutputBytes)
+	}
+	return stdout.Bytes(), nil
+}
+
+func withStderr(err error, stderr *limitedBuffer) error {
+	if message := strings.TrimSpace(stderr.String()); message != "" {
+		return fmt.Errorf("%w: %s", err, message)
+	}
+	return err
+}
+
+// limitedBuffer keeps the first limit bytes written to it and discards the rest.
+type limitedBuffer struct {
+	bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if remaining := b.limit - b.Len(); len(p) > remaining {
+		b.truncated = true
+		b.Buffer.Write(p[:max(remaining, 0)])
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+// loadWasmModule returns the module of the source and verifies its digest. Downloaded modules are cached per
+// namespace, so that modules pulled with the credentials of one namespace are not shared with another.
+func loadWasmModule(ctx context.Context, k8sClient client.Client, source *arkv1alpha1.WasmModuleSource, namespace string) ([]byte, error) {
+	var module []byte
+	var err error
+	switch {
+	case source.ConfigMapKeyRef != nil:
+		module, err = loadWasmModuleFromConfigMap(ctx, k8sClient, source.ConfigMapKeyRef, namespace)
+	case source.URL != "":
+		module, err = wasmModules.get(ctx, namespace+"/"+source.URL, func() ([]byte, error) {
+			return downloadWasmModule(ctx, source.URL)
+		})
+	case source.Image != "":
+		pullSecret := ""
+		if source.ImagePullSecret != nil {
+			pullSecret = source.ImagePullSecret.Name
+		}
+		module, err = wasmModules.get(ctx, namespace+"/"+pullSecret+"/"+source.Image, func() ([]byte, error) {
+			credentials, err := resolveRegistryCredentials(ctx, k8sClient, source.Image, source.ImagePullSecret, namespace)
+			if err != nil {
+				return nil, err
+			}
+			return pullWasmImage(ctx, source.Image, credentials)
+		})
+	default:
+		return nil, fmt.Errorf("wasm.module requires configMapKeyRef, url or image")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if source.SHA256 != "" {
+		digest := sha256.Sum256(module)
+		if actual := hex.EncodeToString(digest[:]); actual != source.SHA256 {
+			return nil, fmt.Errorf("module digest %s does not match sha256 %s", actual, source.SHA256)
+		}
+	}
+	if !bytes.HasPrefix(module, wasmMagic) {
+		return nil, fmt.Errorf("module is not a WebAssembly binary")
+	}
+	return module, nil
+}
+
+func loadWasmModuleFromConfigMap(ctx context.Context, k8sClient client.Client, ref *corev1.ConfigMapKeySelector, namespace string) ([]byte, error) {
+	var configMap corev1.ConfigMap
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: namespace}, &configMap); err != nil {
+		return nil, fmt.Errorf("failed to get configmap %s/%s: %w", namespace, ref.Name, err)
+	}
+	module, exists := configMap.BinaryData[ref.Key]
+	if !exists {
+		return nil, fmt.Errorf("key %s not found in binaryData of configmap %s/%s", ref.Key, namespace, ref.Name)
+	}
+	return module, nil
+}
+
+func downloadWasmModule(ctx context.Context, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpClient := &http.Client{Timeout: 60 * time.Second}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download module: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download module from %s: HTTP %d", url, resp.StatusCode)
+	}
+	return readWasmModule(resp.Body)
+}
+
+// readWasmModule reads a module of at most maxWasmModuleBytes.
+func readWasmModule(r io.Reader) ([]byte, error) {
+	module, err := io.ReadAll(io.LimitReader(r, maxWasmModuleBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read module: %w", err)
+	}
+	if len(module) > maxWasmModuleBytes {
+		return nil, fmt.Errorf("module exceeds %d bytes", maxWasmModuleBytes)
+	}
+	return module, nil
+}
@@ -0,0 +1,388 @@
+/* Copyright 2025. McKinsey & Company */
+
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	dockerHubRegistry = "registry-1.docker.io"
+
+	ociImageIndexMediaType    = "application/vnd.oci.image.index.v1+json"
+	ociImageManifestMediaType = "application/vnd.oci.image.manifest.v1+json"
+	dockerManifestListType    = "application/vnd.docker.distribution.manifest.list.v2+json"
+	dockerManifestType        = "application/vnd.docker.distribution.manifest.v2+json"
+	maxOCIManifestBytes       = 4 << 20
+)
+
+var authChallengeParamRegex = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// ociReference is a parsed image reference, such as ghcr.io/acme/calculator:v1.
+type ociReference struct {
+	Registry   string
+	Repository string
+	// Reference is a tag or a digest
+	Reference string
+}
+
+// registryCredentials authenticate to a registry with basic auth, or to its token service.
+type registryCredentials struct {
+	Username string
+	Password string
+}
+
+type ociDescriptor struct {
+	MediaType string `json:"mediaType"`
+	Digest    string `json:"digest"`
+	Platform  *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform,omitempty"`
+}
+
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests"`
+	Layers    []ociDescriptor `json:"layers"`
+}
+
+// parseOCIReference parses an image reference. Images without a registry are pulled from Docker Hub.
+func parseOCIReference(image string) (ociReference, error) {
+	image = strings.TrimPrefix(image, "oci://")
+	ref := ociReference{Registry: dockerHubRegistry}
+
+	name := image
+	if first, rest, found := strings.Cut(image, "/"); found && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		ref.Registry = first
+		name = rest
+	}
+	if ref.Registry == "docker.io" || ref.Registry == "index.docker.io" {
+		ref.Registry = dockerHubRegistry
+	}
+
+	if repository, digest, found := strings.Cut(name, "@"); found {
+		ref.Repository, ref.Reference = repository, digest
+	} else if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Repository, ref.Reference = name[:i], name[i+1:]
+	} else {
+		ref.Repository, ref.Reference = name, "latest"
+	}
+	if ref.Registry == dockerHubRegistry && !strings.Contains(ref.Repository, "/") {
+		ref.Repository = "library/" + ref.Repository
+	}
+
+	if ref.Repository == "" || ref.Reference == "" {
+		return ociReference{}, fmt.Errorf("invalid image reference %q", image)
+	}
+	return ref, nil
+}
+
+// baseURL returns the URL of the registry API. Registries on the loopback interface are reached over plain
+// HTTP, which is meant for local development.
+func (r ociReference) baseURL() string {
+	host := r.Registry
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+		return "http://" + r.Registry
+	}
+	return "https://" + r.Registry
+}
+
+// resolveRegistryCredentials reads the credentials for the registry of the image from a
+// kubernetes.io/dockerconfigjson Secret.
+func resolveRegistryCredentials(ctx context.Context, k8sClient client.Client, image string, secretRef *corev1.LocalObjectReference, namespace string) (*registryCredentials, error) {
+	if secretRef == nil || secretRef.Name == "" {
+		return nil, nil
+	}
+	ref, err := parseOCIReference(image)
+	if err != nil {
+		return nil, err
+	}
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(ctx, client.ObjectKey{Name: secretRef.Name, Namespace: namespace}, &secret); err != nil {
+		return nil, fmt.Errorf("failed to get image pull secret %s/%s: %w", namespace, secretRef.Name, err)
+	}
+	data, exists := secret.Data[corev1.DockerConfigJsonKey]
+	if !exists {
+		return nil, fmt.Errorf("image pull secret %s/%s has no %s key", namespace, secretRef.Name, corev1.DockerConfigJsonKey)
+	}
+
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return nil, fmt.Errorf("failed to parse image pull secret %s/%s: %w", namespace, secretRef.Name, err)
+	}
+
+	for server, auth := range config.Auths {
+		if registryHost(server) != ref.Registry && !(ref.Registry == dockerHubRegistry && isDockerHub(registryHost(server))) {
+			continue
+		}
+		if auth.Auth != "" {
+			decoded, err := base64.StdEncoding.DecodeString(auth.Auth)
+			if err != nil {
+				return nil, fmt.Errorf("failed to decode auth of %s in image pull secret %s/%s: %w", server, namespace, secretRef.Name, err)
+			}
+			username, password, _ := strings.Cut(string(decoded), ":")
+			return &registryCredentials{Username: username, Password: password}, nil
+		}
+		return &registryCredentials{Username: auth.Username, Password: auth.Password}, nil
+	}
+	return nil, fmt.Errorf("image pull secret %s/%s has no credentials for %s", namespace, secretRef.Name, ref.Registry)
+}
+
+// registryHost returns the host of a server in a docker config, which may be a URL.
+func registryHost(server string) string {
+	if parsed, err := url.Parse(server); err == nil && parsed.Host != "" {
+		return parsed.Host
+	}
+	host, _, _ := strings.Cut(server, "/")
+	return host
+}
+
+func isDockerHub(host string) bool {
+	return host == "docker.io" || host == "index.docker.io" || host == dockerHubRegistry
+}
+
+// pullWasmImage pulls a WebAssembly module stored as an OCI artifact.
+func pullWasmImage(ctx context.Context, image string, credentials *registryCredentials) ([]byte, error) {
+	ref, err := parseOCIReference(image)
+	if err != nil {
+		return nil, err
+	}
+	registry := &ociRegistryClient{
+		httpClient:  &http.Client{Timeout: 60 * time.Second},
+		ref:         ref,
+		credentials: credentials,
+	}
+
+	manifest, err := registry.manifest(ctx, ref.Reference)
+	if err != nil {
+		return nil, err
+	}
+	if len(manifest.Manifests) > 0 {
+		descriptor, err := selectWasmManifest(manifest.Manifests)
+		if err != nil {
+			return nil, fmt.Errorf("image %s: %w", image, err)
+		}
+		if manifest, err = registry.manifest(ctx, descriptor.Digest); err != nil {
+			return nil, err
+		}
+	}
+
+	layer, err := selectWasmLayer(manifest.Layers)
+	if err != nil {
+		return nil, fmt.Errorf("image %s: %w", image, err)
+	}
+	return registry.blob(ctx, layer.Digest)
+}
+
+// selectWasmManifest selects the WebAssembly platform of an image index.
+func selectWasmManifest(manifests []ociDescriptor) (ociDescriptor, error) {
+	for _, descriptor := range manifests {
+		if descriptor.Platform != nil && (descriptor.Platform.Architecture == "wasm" || strings.HasPrefix(descriptor.Platform.OS, "wasi")) {
+			return descriptor, nil
+		}
+	}
+	if len(manifests) == 1 {
+		return manifests[0], nil
+	}
+	return ociDescriptor{}, fmt.Errorf("image index has no wasm platform")
+}
+
+// selectWasmLayer selects the layer with a WebAssembly media type, or the only layer of the artifact.
+func selectWasmLayer(layers []ociDescriptor) (ociDescriptor, error) {
+	for _, layer := range layers {
+		if strings.Contains(layer.MediaType, "wasm") {
+			return layer, nil
+		}
+	}
+	if len(layers) == 1 {
+		return layers[0], nil
+	}
+	return ociDescriptor{}, fmt.Errorf("expected a single layer or a layer with a wasm media type, found %d layers", len(layers))
+}
+
+// ociRegistryClient reads from a repository with the OCI distribution API.
+type ociRegistryClient struct {
+	httpClient  *http.Client
+	ref         ociReference
+	credentials *registryCredentials
+	token       string
+}
+
+func (c *ociRegistryClient) manifest(ctx context.Context, reference string) (*ociManifest, error) {
+	accept := strings.Join([]string{ociImageManifestMediaType, ociImageIndexMediaType, dockerManifestType, dockerManifestListType}, ", ")
+	body, err := c.get(ctx, "/manifests/"+reference, accept, maxOCIManifestBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest %s: %w", reference, err)
+	}
+	if err := verifyOCIDigest(reference, body); err != nil {
+		return nil, err
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(body, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest %s: %w", reference, err)
+	}
+	return &manifest, nil
+}
+
+func (c *ociRegistryClient) blob(ctx context.Context, digest string) ([]byte, error) {
+	body, err := c.get(ctx, "/blobs/"+digest, "", maxWasmModuleBytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get layer %s: %w", digest, err)
+	}
+	if err := verifyOCIDigest(digest, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// get reads a path of the repository. On 401 it authenticates as the registry requests, with a bearer token
+// from its token service or with basic auth, and retries once.
+func (c *ociRegistryClient) get(ctx context.Context, path, accept string, limit int64) ([]byte, error) {
+	resp, err := c.do(ctx, path, accept)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode == http.StatusUnauthorized && c.token == "" {
+		challenge := resp.Header.Get("WWW-Authenticate")
+		_ = resp.Body.Close()
+		if err := c.authenticate(ctx, challenge); err != nil {
+			return nil, err
+		}
+		if resp, err = c.do(ctx, path, accept); err != nil {
+			return nil, err
+		}
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("HTTP %d from %s", resp.StatusCode, c.ref.Registry)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, limit+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > limit {
+		return nil, fmt.Errorf("response exceeds %d bytes", limit)
+	}
+	return body, nil
+}
+
+func (c *ociRegistryClient) do(ctx context.Context, path, accept string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.ref.baseURL()+"/v2/"+c.ref.Repository+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	switch {
+	case c.token != "":
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	case c.credentials != nil:
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+	return c.httpClient.Do(req)
+}
+
+// authenticate gets a pull token for the repository from the token service named in the challenge.
+func (c *ociRegistryClient) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("registry %s requires authentication", c.ref.Registry)
+	}
+
+	values := map[string]string{}
+	for _, match := range authChallengeParamRegex.FindAllStringSubmatch(params, -1) {
+		values[match[1]] = match[2]
+	}
+	realm, err := url.Parse(values["realm"])
+	if err != nil || realm.Host == "" {
+		return fmt.Errorf("registry %s sent an invalid authentication challenge", c.ref.Registry)
+	}
+	query := realm.Query()
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	scope := values["scope"]
+	if scope == "" {
+		scope = "repository:" + c.ref.Repository + ":pull"
+	}
+	query.Set("scope", scope)
+	realm.RawQuery = query.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, realm.String(), nil)
+	if err != nil {
+		return err
+	}
+	if c.credentials != nil {
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get registry token: HTTP %d", resp.StatusCode)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return fmt.Errorf("failed to parse registry token: %w", err)
+	}
+	c.token = token.Token
+	if c.token == "" {
+		c.token = token.AccessToken
+	}
+	if c.token == "" {
+		return fmt.Errorf("registry %s returned an empty token", c.ref.Registry)
+	}
+	return nil
+}
+
+// verifyOCIDigest checks content fetched by a sha256 digest. Content fetched by tag is not verified.
+func verifyOCIDigest(reference string, content []byte) error {
+	expected, found := strings.CutPrefix(reference, "sha256:")
+	if !found {
+		return nil
+	}
+	digest := sha256.Sum256(content)
+	if actual := hex.EncodeToString(digest[:]); actual != expected {
+		return fmt.Errorf("content of %s has digest sha256:%s", reference, actual)
+	}
+	return nil
+}
@@ -0,0 +1,322 @@
+package genai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// wasmSection encodes a module section with a single-byte length.
+func wasmSection(id byte, payload ...byte) []byte {
+	return append([]byte{id, byte(len(payload))}, payload...)
+}
+
+func wasmName(name string) []byte {
+	return append([]byte{byte(len(name))}, name...)
+}
+
+// testWasmModule assembles a WASI command that imports fd_read, fd_write and proc_exit, has a memory of
+// memoryPages pages and runs the instructions as _start.
+func testWasmModule(memoryPages byte, instructions ...byte) []byte {
+	module := []byte{0x00, 'a', 's', 'm', 0x01, 0x00, 0x00, 0x00}
+	module = append(module, wasmSection(1,
+		0x03,
+		0x60, 0x04, 0x7f, 0x7f, 0x7f, 0x7f, 0x01, 0x7f, // (i32, i32, i32, i32) -> i32
+		0x60, 0x01, 0x7f, 0x00, // (i32) -> ()
+		0x60, 0x00, 0x00, // () -> ()
+	)...)
+
+	imports := []byte{0x03}
+	for _, function := range []struct {
+		name      string
+		typeIndex byte
+	}{{"fd_read", 0}, {"fd_write", 0}, {"proc_exit", 1}} {
+		imports = append(imports, wasmName("wasi_snapshot_preview1")...)
+		imports = append(imports, wasmName(function.name)...)
+		imports = append(imports, 0x00, function.typeIndex)
+	}
+	module = append(module, wasmSection(2, imports...)...)
+	module = append(module, wasmSection(3, 0x01, 0x02)...)
+	module = append(module, wasmSection(5, 0x01, 0x00, memoryPages)...)
+
+	exports := []byte{0x02}
+	exports = append(exports, wasmName("memory")...)
+	exports = append(exports, 0x02, 0x00)
+	exports = append(exports, wasmName("_start")...)
+	exports = append(exports, 0x00, 0x03)
+	module = append(module, wasmSection(7, exports...)...)
+
+	body := append([]byte{0x00}, instructions...)
+	body = append(body, 0x0b)
+	module = append(module, wasmSection(10, append([]byte{0x01, byte(len(body))}, body...)...)...)
+	return module
+}
+
+// echoWasmModule copies up to 1024 bytes of stdin to stdout.
+var echoWasmModule = testWasmModule(1,
+	0x41, 0x00, 0x41, 0x10, 0x36, 0x02, 0x00, // iov.buf = 16
+	0x41, 0x04, 0x41, 0x80, 0x08, 0x36, 0x02, 0x00, // iov.len = 1024
+	0x41, 0x00, 0x41, 0x00, 0x41, 0x01, 0x41, 0x08, 0x10, 0x00, 0x1a, // fd_read(stdin, iov, 1, &n)
+	0x41, 0x04, 0x41, 0x08, 0x28, 0x02, 0x00, 0x36, 0x02, 0x00, // iov.len = n
+	0x41, 0x01, 0x41, 0x00, 0x41, 0x01, 0x41, 0x08, 0x10, 0x01, 0x1a, // fd_write(stdout, iov, 1, &n)
+)
+
+// exitWasmModule exits with code 3.
+var exitWasmModule = testWasmModule(1, 0x41, 0x03, 0x10, 0x02)
+
+// loopWasmModule never returns.
+var loopWasmModule = testWasmModule(1, 0x03, 0x40, 0x0c, 0x00, 0x0b)
+
+func TestRunWasmModule(t *testing.T) {
+	ctx := context.Background()
+	options := wasmRunOptions{Name: "echo", Stdin: []byte(`{"a":1}`), MaxMemory: defaultWasmMaxMemory, Timeout: 5 * time.Second}
+
+	t.Run("passes stdin to stdout", func(t *testing.T) {
+		output, err := runWasmModule(ctx, echoWasmModule, options)
+		require.NoError(t, err)
+		assert.Equal(t, `{"a":1}`, string(output))
+	})
+
+	t.Run("reports exit codes", func(t *testing.T) {
+		_, err := runWasmModule(ctx, exitWasmModule, options)
+		assert.EqualError(t, err, "exited with code 3")
+	})
+
+	t.Run("stops modules at the timeout", func(t *testing.T) {
+		options := options
+		options.Timeout = 100 * time.Millisecond
+		_, err := runWasmModule(ctx, loopWasmModule, options)
+		assert.EqualError(t, err, "exceeded the timeout of 100ms")
+	})
+
+	t.Run("limits memory", func(t *testing.T) {
+		options := options
+		options.MaxMemory = wasmPageSize
+		_, err := runWasmModule(ctx, testWasmModule(2), options)
+		assert.ErrorContains(t, err, "invalid module")
+	})
+}
+
+func newWasmTestTool(module arkv1alpha1.WasmModuleSource) *arkv1alpha1.Tool {
+	return &arkv1alpha1.Tool{
+		ObjectMeta: metav1.ObjectMeta{Name: "echo", Namespace: "default"},
+		Spec: arkv1alpha1.ToolSpec{
+			Type: ToolTypeWasm,
+			Wasm: &arkv1alpha1.WasmSpec{Module: module, Timeout: "5s"},
+		},
+	}
+}
+
+func TestWasmExecutor(t *testing.T) {
+	ctx := context.Background()
+	configMap := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "modules", Namespace: "default"},
+		BinaryData: map[string][]byte{"echo.wasm": echoWasmModule},
+	}
+	execute := func(t *testing.T, tool *arkv1alpha1.Tool, arguments string) (ToolResult, error) {
+		t.Helper()
+		executor := &WasmExecutor{K8sClient: setupTestClient([]client.Object{tool, configMap}), ToolName: tool.Name, ToolNamespace: "default"}
+		return executor.Execute(ctx, ToolCall{ID: "call-1", Function: openai.ChatCompletionMessageToolCallFunction{Name: tool.Name, Arguments: arguments}})
+	}
+	configMapSource := arkv1alpha1.WasmModuleSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+		LocalObjectReference: corev1.LocalObjectReference{Name: "modules"},
+		Key:                  "echo.wasm",
+	}}
+
+	t.Run("runs a module from a configmap", func(t *testing.T) {
+		result, err := execute(t, newWasmTestTool(configMapSource), `{"expression":"1+1"}`)
+		require.NoError(t, err)
+		assert.Empty(t, result.Error)
+		assert.JSONEq(t, `{"expression":"1+1"}`, result.Content)
+	})
+
+	t.Run("returns output that is not JSON as an error", func(t *testing.T) {
+		result, err := execute(t, newWasmTestTool(configMapSource), `plain text`)
+		require.NoError(t, err)
+		assert.Equal(t, `WebAssembly module of tool echo returned output that is not JSON: "plain text"`, result.Error)
+	})
+
+	t.Run("verifies the digest of the module", func(t *testing.T) {
+		source := configMapSource
+		source.SHA256 = strings.Repeat("0", 64)
+		_, err := execute(t, newWasmTestTool(source), `{}`)
+		assert.ErrorContains(t, err, "does not match sha256")
+	})
+
+	t.Run("downloads modules from URLs", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			_, _ = w.Write(echoWasmModule)
+		}))
+		t.Cleanup(server.Close)
+
+		digest := sha256.Sum256(echoWasmModule)
+		result, err := execute(t, newWasmTestTool(arkv1alpha1.WasmModuleSource{URL: server.URL + "/echo.wasm", SHA256: hex.EncodeToString(digest[:])}), `[1,2]`)
+		require.NoError(t, err)
+		assert.Equal(t, `[1,2]`, result.Content)
+	})
+}
+
+func TestParseOCIReference(t *testing.T) {
+	tests := []struct {
+		image    string
+		expected ociReference
+	}{
+		{"calculator", ociReference{Registry: dockerHubRegistry, Repository: "library/calculator", Reference: "latest"}},
+		{"docker.io/acme/calculator:v1", ociReference{Registry: dockerHubRegistry, Repository: "acme/calculator", Reference: "v1"}},
+		{"ghcr.io/acme/tools/calculator:v1", ociReference{Registry: "ghcr.io", Repository: "acme/tools/calculator", Reference: "v1"}},
+		{"localhost:5000/calculator@sha256:abc", ociReference{Registry: "localhost:5000", Repository: "calculator", Reference: "sha256:abc"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.image, func(t *testing.T) {
+			ref, err := parseOCIReference(tt.image)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, ref)
+		})
+	}
+}
+
+func TestPullWasmImage(t *testing.T) {
+	layerDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(echoWasmModule))
+	manifest, err := json.Marshal(map[string]any{
+		"schemaVersion": 2,
+		"mediaType":     ociImageManifestMediaType,
+		"layers": []map[string]any{
+			{"mediaType": "application/vnd.oci.image.config.v1+json", "digest": "sha256:config"},
+			{"mediaType": "application/vnd.wasm.content.layer.v1+wasm", "digest": layerDigest},
+		},
+	})
+	require.NoError(t, err)
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			username, password, _ := r.BasicAuth()
+			assert.Equal(t, "robot:secret", username+":"+password)
+			assert.Regexp(t, `^repository:acme/\w+:pull$`, r.URL.Query().Get("scope"))
+			_, _ = w.Write([]byte(`{"token":"pull-token"}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer pull-token" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(`Bearer realm="%s/token",service="registry"`, server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v2/acme/echo/manifests/v1":
+			_, _ = w.Write(manifest)
+		case "/v2/acme/echo/blobs/" + layerDigest:
+			_, _ = w.Write(echoWasmModule)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	registry := strings.TrimPrefix(server.URL, "http://")
+	module, err := pullWasmImage(context.Background(), registry+"/acme/echo:v1", &registryCredentials{Username: "robot", Password: "secret"})
+	require.NoError(t, err)
+	assert.Equal(t, echoWasmModule, module)
+
+	_, err = pullWasmImage(context.Background(), registry+"/acme/other:v1", &registryCredentials{Username: "robot", Password: "secret"})
+	assert.Error(t, err)
+}
+
+func TestWasmModuleCache(t *testing.T) {
+	ctx := context.Background()
+	fetchOf := func(module string, fetches *int) func() ([]byte, error) {
+		return func() ([]byte, error) {
+			*fetches++
+			return []byte(module), nil
+		}
+	}
+
+	t.Run("evicts the least recently used modules when full", func(t *testing.T) {
+		cache := newWasmModuleCache(8)
+		var fetches int
+		for _, key := range []string{"a", "b", "a", "c"} {
+			module, err := cache.get(ctx, key, fetchOf(key+key+key, &fetches))
+			require.NoError(t, err)
+			assert.Equal(t, key+key+key, string(module))
+		}
+		assert.Equal(t, 3, fetches)
+		assert.Contains(t, cache.entries, "a")
+		assert.NotContains(t, cache.entries, "b")
+		assert.Contains(t, cache.entries, "c")
+		assert.Equal(t, 6, cache.size)
+	})
+
+	t.Run("drops expired modules", func(t *testing.T) {
+		cache := newWasmModuleCache(1 << 10)
+		now := time.Now()
+		cache.now = func() time.Time { return now }
+		var fetches int
+		_, err := cache.get(ctx, "a", fetchOf("a", &fetches))
+		require.NoError(t, err)
+
+		now = now.Add(wasmModuleCacheTTL)
+		_, err = cache.get(ctx, "b", fetchOf("b", &fetches))
+		require.NoError(t, err)
+		assert.NotContains(t, cache.entries, "a")
+		assert.Equal(t, 1, cache.size)
+	})
+
+	t.Run("shares a single fetch between concurrent calls", func(t *testing.T) {
+		cache := newWasmModuleCache(1 << 10)
+		release := make(chan struct{})
+		var fetches atomic.Int32
+		fetch := func() ([]byte, error) {
+			fetches.Add(1)
+			<-release
+			return []byte("module"), nil
+		}
+
+		var wg sync.WaitGroup
+		results := make([]string, 5)
+		for i := range results {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				module, err := cache.get(ctx, "a", fetch)
+				assert.NoError(t, err)
+				results[i] = string(module)
+			}()
+		}
+		require.Eventually(t, func() bool {
+			cache.mu.Lock()
+			defer cache.mu.Unlock()
+			return fetches.Load() == 1 && len(cache.fetches) == 1
+		}, time.Second, time.Millisecond)
+		// Give the other calls time to wait for the fetch
+		time.Sleep(10 * time.Millisecond)
+		close(release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), fetches.Load())
+		assert.Equal(t, []string{"module", "module", "module", "module", "module"}, results)
+	})
+}
+
+func TestWasmMaxMemory(t *testing.T) {
+	quantity := resource.MustParse("16Mi")
+	assert.Equal(t, int64(16<<20), wasmMaxMemory(&arkv1alpha1.WasmSpec{MaxMemory: &quantity}))
+	assert.Equal(t, int64(defaultWasmMaxMemory), wasmMaxMemory(&arkv1alpha1.WasmSpec{}))
+}
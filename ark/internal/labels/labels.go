@@ -3,6 +3,9 @@
 package labels
 
 const (
-	MCPServerLabel = "mcp/server"
-	A2AServerLabel = "a2a/server"
+	MCPServerLabel     = "mcp/server"
+	A2AServerLabel     = "a2a/server"
+	OpenAPIServerLabel = "openapi/server"
+	// MCPExposeLabel publishes an agent or team as a tool of the ARK MCP endpoint when set to "true"
+	MCPExposeLabel = "mcp/expose"
 )
@@ -0,0 +1,217 @@
+/* Copyright 2025. McKinsey & Company */
+
+package mcpgateway
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+const (
+	serviceAccountUsernamePrefix = "system:serviceaccount:"
+	sessionIDHeader              = "Mcp-Session-Id"
+)
+
+type userKey struct{}
+
+// sessionOwner is the user a session was created for, and the namespace of its endpoint.
+type sessionOwner struct {
+	username  string
+	namespace string
+}
+
+// sessionOwners records the owner of every session, because the streamable HTTP handler finds the session of a
+// request by its session ID alone, and the tools of a session create queries on behalf of its owner.
+type sessionOwners struct {
+	mu     sync.Mutex
+	owners map[string]sessionOwner
+}
+
+func (o *sessionOwners) get(sessionID string) (sessionOwner, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	owner, ok := o.owners[sessionID]
+	return owner, ok
+}
+
+func (o *sessionOwners) set(sessionID string, owner sessionOwner) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.owners == nil {
+		o.owners = map[string]sessionOwner{}
+	}
+	o.owners[sessionID] = owner
+}
+
+func (o *sessionOwners) delete(sessionID string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.owners, sessionID)
+}
+
+// sessionRecorder records the owner of the session the handler creates for a request.
+type sessionRecorder struct {
+	http.ResponseWriter
+	owners      *sessionOwners
+	owner       sessionOwner
+	wroteHeader bool
+}
+
+func (r *sessionRecorder) WriteHeader(status int) {
+	if !r.wroteHeader {
+		r.wroteHeader = true
+		if sessionID := r.Header().Get(sessionIDHeader); sessionID != "" && status < http.StatusBadRequest {
+			r.owners.set(sessionID, r.owner)
+		}
+	}
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *sessionRecorder) Write(data []byte) (int, error) {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	return r.ResponseWriter.Write(data)
+}
+
+func (r *sessionRecorder) Flush() {
+	if !r.wroteHeader {
+		r.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := r.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (r *sessionRecorder) Unwrap() http.ResponseWriter {
+	return r.ResponseWriter
+}
+
+// authenticate lets a request through when its bearer token is valid and its user may create queries in the
+// namespace. The user is added to the context of the request. A request of an existing session is only let through
+// for the user the session was created for.
+func (s *Server) authenticate(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		namespace := req.PathValue("namespace")
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			http.Error(w, fmt.Sprintf("invalid namespace %q", namespace), http.StatusNotFound)
+			return
+		}
+
+		token, ok := strings.CutPrefix(req.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "a bearer token is required", http.StatusUnauthorized)
+			return
+		}
+		user, err := s.reviewToken(req.Context(), token)
+		if err != nil {
+			log.Error(err, "failed to review the token of an MCP client")
+			http.Error(w, "failed to authenticate", http.StatusInternalServerError)
+			return
+		}
+		if user == nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "the bearer token is not valid", http.StatusUnauthorized)
+			return
+		}
+
+		allowed, err := s.canCreateQueries(req.Context(), user, namespace)
+		if err != nil {
+			log.Error(err, "failed to authorize an MCP client", "user", user.Username, "namespace", namespace)
+			http.Error(w, "failed to authorize", http.StatusInternalServerError)
+			return
+		}
+		if !allowed {
+			http.Error(w, fmt.Sprintf("%s may not create queries in namespace %s", user.Username, namespace), http.StatusForbidden)
+			return
+		}
+
+		current := sessionOwner{username: user.Username, namespace: namespace}
+		if sessionID := req.Header.Get(sessionIDHeader); sessionID != "" {
+			owner, ok := s.sessions.get(sessionID)
+			if !ok {
+				http.Error(w, "session not found", http.StatusNotFound)
+				return
+			}
+			if owner != current {
+				http.Error(w, fmt.Sprintf("the session does not belong to %s in namespace %s", user.Username, namespace), http.StatusForbidden)
+				return
+			}
+			if req.Method == http.MethodDelete {
+				defer s.sessions.delete(sessionID)
+			}
+		} else {
+			w = &sessionRecorder{ResponseWriter: w, owners: &s.sessions, owner: current}
+		}
+
+		next.ServeHTTP(w, req.WithContext(context.WithValue(req.Context(), userKey{}, user)))
+	})
+}
+
+// reviewToken returns the user of the token, or nil when the token is not valid.
+func (s *Server) reviewToken(ctx context.Context, token string) (*authenticationv1.UserInfo, error) {
+	review := &authenticationv1.TokenReview{Spec: authenticationv1.TokenReviewSpec{Token: token}}
+	if err := s.Client.Create(ctx, review); err != nil {
+		return nil, err
+	}
+	if !review.Status.Authenticated {
+		return nil, nil
+	}
+	return &review.Status.User, nil
+}
+
+func (s *Server) canCreateQueries(ctx context.Context, user *authenticationv1.UserInfo, namespace string) (bool, error) {
+	extra := map[string]authorizationv1.ExtraValue{}
+	for key, values := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(values)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: namespace,
+				Verb:      "create",
+				Group:     arkv1alpha1.GroupVersion.Group,
+				Resource:  "queries",
+			},
+		},
+	}
+	if err := s.Client.Create(ctx, review); err != nil {
+		return false, err
+	}
+	return review.Status.Allowed, nil
+}
+
+func userFromContext(ctx context.Context) *authenticationv1.UserInfo {
+	user, _ := ctx.Value(userKey{}).(*authenticationv1.UserInfo)
+	return user
+}
+
+// queryServiceAccount returns the service account the query of a user runs with: the user itself when it is a
+// service account of the namespace. Other users get no service account.
+func queryServiceAccount(user *authenticationv1.UserInfo, namespace string) string {
+	if user == nil {
+		return ""
+	}
+	name, ok := strings.CutPrefix(user.Username, serviceAccountUsernamePrefix+namespace+":")
+	if !ok || strings.Contains(name, ":") {
+		return ""
+	}
+	return name
+}
@@ -0,0 +1,140 @@
+/* Copyright 2025. McKinsey & Company */
+
+package mcpgateway
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+// newReviewingClient answers token reviews for the tokens of the users, and allows the users of allowed to create
+// queries.
+func newReviewingClient(t *testing.T, users map[string]string, allowed map[string]bool) (client.Client, *[]authorizationv1.SubjectAccessReview) {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	var reviews []authorizationv1.SubjectAccessReview
+	k8sClient := fake.NewClientBuilder().WithScheme(scheme).WithInterceptorFuncs(interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			switch review := obj.(type) {
+			case *authenticationv1.TokenReview:
+				if username, ok := users[review.Spec.Token]; ok {
+					review.Status.Authenticated = true
+					review.Status.User = authenticationv1.UserInfo{Username: username, Groups: []string{"system:authenticated"}}
+				}
+				return nil
+			case *authorizationv1.SubjectAccessReview:
+				review.Status.Allowed = allowed[review.Spec.User]
+				reviews = append(reviews, *review)
+				return nil
+			}
+			return c.Create(ctx, obj, opts...)
+		},
+	}).Build()
+	return k8sClient, &reviews
+}
+
+func TestServerAuthenticatesClients(t *testing.T) {
+	k8sClient, reviews := newReviewingClient(t,
+		map[string]string{"alice-token": "alice", "bob-token": "bob"},
+		map[string]bool{"alice": true},
+	)
+	s := &Server{Client: k8sClient}
+	endpoint := httptest.NewServer(s.Handler())
+	defer endpoint.Close()
+
+	post := func(path, token string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, endpoint.URL+path, strings.NewReader(
+			`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"v1"}}}`))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	resp := post("/mcp/default", "")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, "Bearer", resp.Header.Get("WWW-Authenticate"))
+
+	assert.Equal(t, http.StatusUnauthorized, post("/mcp/default", "stolen-token").StatusCode)
+	assert.Equal(t, http.StatusForbidden, post("/mcp/default", "bob-token").StatusCode)
+	assert.Equal(t, http.StatusNotFound, post("/mcp/Default", "alice-token").StatusCode)
+	assert.Equal(t, http.StatusOK, post("/mcp/default", "alice-token").StatusCode)
+
+	require.NotEmpty(t, *reviews)
+	attributes := (*reviews)[len(*reviews)-1].Spec.ResourceAttributes
+	require.NotNil(t, attributes)
+	assert.Equal(t, authorizationv1.ResourceAttributes{
+		Namespace: "default",
+		Verb:      "create",
+		Group:     "ark.mckinsey.com",
+		Resource:  "queries",
+	}, *attributes)
+}
+
+func TestServerKeepsSessionsToTheirOwner(t *testing.T) {
+	k8sClient, _ := newReviewingClient(t,
+		map[string]string{"alice-token": "alice", "carol-token": "carol"},
+		map[string]bool{"alice": true, "carol": true},
+	)
+	s := &Server{Client: k8sClient}
+	endpoint := httptest.NewServer(s.Handler())
+	defer endpoint.Close()
+
+	post := func(path, token, sessionID, body string) *http.Response {
+		req, err := http.NewRequest(http.MethodPost, endpoint.URL+path, strings.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Accept", "application/json, text/event-stream")
+		req.Header.Set("Authorization", "Bearer "+token)
+		if sessionID != "" {
+			req.Header.Set(sessionIDHeader, sessionID)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	resp := post("/mcp/default", "alice-token", "",
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"protocolVersion":"2025-06-18","capabilities":{},"clientInfo":{"name":"test","version":"v1"}}}`)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	sessionID := resp.Header.Get(sessionIDHeader)
+	require.NotEmpty(t, sessionID)
+
+	initialized := `{"jsonrpc":"2.0","method":"notifications/initialized","params":{}}`
+	assert.Equal(t, http.StatusForbidden, post("/mcp/default", "carol-token", sessionID, initialized).StatusCode)
+	assert.Equal(t, http.StatusForbidden, post("/mcp/other", "alice-token", sessionID, initialized).StatusCode)
+	assert.Equal(t, http.StatusNotFound, post("/mcp/default", "carol-token", "unknown", initialized).StatusCode)
+	assert.Equal(t, http.StatusAccepted, post("/mcp/default", "alice-token", sessionID, initialized).StatusCode)
+}
+
+func TestQueryServiceAccount(t *testing.T) {
+	user := func(username string) *authenticationv1.UserInfo {
+		return &authenticationv1.UserInfo{Username: username}
+	}
+	assert.Equal(t, "assistant", queryServiceAccount(user("system:serviceaccount:default:assistant"), "default"))
+	assert.Empty(t, queryServiceAccount(user("system:serviceaccount:other:assistant"), "default"))
+	assert.Empty(t, queryServiceAccount(user("alice"), "default"))
+	assert.Empty(t, queryServiceAccount(nil, "default"))
+}
@@ -0,0 +1,132 @@
+/* Copyright 2025. McKinsey & Company */
+
+// Package mcpgateway publishes ARK agents and teams as the tools of an MCP server, so that MCP clients such as IDE
+// assistants can query them.
+package mcpgateway
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/labels"
+)
+
+const (
+	// DefaultPollInterval is how often a tool call checks the status of its query.
+	DefaultPollInterval = time.Second
+
+	shutdownTimeout   = 10 * time.Second
+	readHeaderTimeout = 10 * time.Second
+)
+
+var log = logf.Log.WithName("mcp-gateway")
+
+// Server serves the agents and teams of every namespace as MCP tools at /mcp/{namespace}, over the streamable HTTP
+// transport. Only agents and teams labeled with labels.MCPExposeLabel are published, and the tools of a session are
+// listed when the client connects. Clients authenticate with a Kubernetes bearer token, whose user must be allowed
+// to create queries in the namespace. A session can only be used by the user who created it.
+type Server struct {
+	Client client.Client
+	// BindAddress is the address the endpoint listens on
+	BindAddress string
+	// Version is reported to clients as the version of the server
+	Version string
+	// PollInterval defaults to DefaultPollInterval
+	PollInterval time.Duration
+
+	sessions sessionOwners
+}
+
+// Handler returns the HTTP handler of the endpoint.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/mcp/{namespace}", s.authenticate(mcp.NewStreamableHTTPHandler(s.serverForRequest, nil)))
+	return mux
+}
+
+func (s *Server) serverForRequest(req *http.Request) *mcp.Server {
+	namespace := req.PathValue("namespace")
+	server, err := s.newMCPServer(req.Context(), namespace, userFromContext(req.Context()))
+	if err != nil {
+		log.Error(err, "failed to list the agents and teams published over MCP", "namespace", namespace)
+		return nil
+	}
+	return server
+}
+
+// newMCPServer returns an MCP server with a tool for every published agent and team of the namespace. The tools
+// create queries on behalf of the user.
+func (s *Server) newMCPServer(ctx context.Context, namespace string, user *authenticationv1.UserInfo) (*mcp.Server, error) {
+	published := client.MatchingLabels{labels.MCPExposeLabel: "true"}
+
+	var agents arkv1alpha1.AgentList
+	if err := s.Client.List(ctx, &agents, client.InNamespace(namespace), published); err != nil {
+		return nil, err
+	}
+	var teams arkv1alpha1.TeamList
+	if err := s.Client.List(ctx, &teams, client.InNamespace(namespace), published); err != nil {
+		return nil, err
+	}
+
+	server := mcp.NewServer(&mcp.Implementation{Name: "ark", Version: s.Version}, nil)
+	for i := range agents.Items {
+		agent := &agents.Items[i]
+		target := arkv1alpha1.QueryTarget{Type: "agent", Name: agent.Name}
+		s.addQueryTool(server, namespace, user, target, agent.Spec.Description, agent.Spec.Parameters)
+	}
+	for i := range teams.Items {
+		team := &teams.Items[i]
+		target := arkv1alpha1.QueryTarget{Type: "team", Name: team.Name}
+		s.addQueryTool(server, namespace, user, target, team.Spec.Description, team.Spec.Parameters)
+	}
+	return server, nil
+}
+
+// Start serves the endpoint until the context is done.
+func (s *Server) Start(ctx context.Context) error {
+	httpServer := &http.Server{
+		Addr:              s.BindAddress,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		log.Info("serving agents and teams over MCP", "address", s.BindAddress)
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	// Clients keep streams open for notifications, so close the connections left after the timeout
+	if err := httpServer.Shutdown(shutdownCtx); err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return err
+	}
+	return httpServer.Close()
+}
+
+// NeedLeaderElection lets every replica of the controller manager serve the endpoint.
+func (s *Server) NeedLeaderElection() bool {
+	return false
+}
+
+func (s *Server) pollInterval() time.Duration {
+	if s.PollInterval > 0 {
+		return s.PollInterval
+	}
+	return DefaultPollInterval
+}
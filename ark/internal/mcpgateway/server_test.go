@@ -0,0 +1,231 @@
+/* Copyright 2025. McKinsey & Company */
+
+package mcpgateway
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/labels"
+)
+
+func newTestClient(t *testing.T, objects ...client.Object) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, arkv1alpha1.AddToScheme(scheme))
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		WithStatusSubresource(&arkv1alpha1.Query{}).
+		Build()
+}
+
+func publishedAgent() *arkv1alpha1.Agent {
+	return &arkv1alpha1.Agent{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "weather",
+			Namespace: "default",
+			Labels:    map[string]string{labels.MCPExposeLabel: "true"},
+		},
+		Spec: arkv1alpha1.AgentSpec{
+			Description: "Forecasts the weather",
+			Parameters: []arkv1alpha1.Parameter{
+				{Name: "city", ValueFrom: &arkv1alpha1.ValueFromSource{QueryParameterRef: &arkv1alpha1.QueryParameterReference{Name: "city"}}},
+				{Name: "units", Value: "metric"},
+			},
+		},
+	}
+}
+
+// testUser is the service account MCP clients of the tests authenticate as.
+var testUser = &authenticationv1.UserInfo{Username: "system:serviceaccount:default:assistant"}
+
+// connect connects a client of the test user to the MCP server of the namespace.
+func connect(t *testing.T, s *Server, namespace string, options *mcp.ClientOptions) *mcp.ClientSession {
+	t.Helper()
+	ctx := context.Background()
+	server, err := s.newMCPServer(ctx, namespace, testUser)
+	require.NoError(t, err)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	serverSession, err := server.Connect(ctx, serverTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = serverSession.Close() })
+
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test", Version: "v1"}, options).Connect(ctx, clientTransport, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = session.Close() })
+	return session
+}
+
+// completeQueries plays the query controller: it marks the first query of the namespace as running, waits for
+// release, then completes it with the response.
+func completeQueries(t *testing.T, k8sClient client.Client, release <-chan struct{}, phase, content string) <-chan arkv1alpha1.Query {
+	t.Helper()
+	created := make(chan arkv1alpha1.Query, 1)
+	go func() {
+		ctx := context.Background()
+		var query arkv1alpha1.Query
+		for {
+			var queries arkv1alpha1.QueryList
+			if err := k8sClient.List(ctx, &queries, client.InNamespace("default")); err == nil && len(queries.Items) > 0 {
+				query = queries.Items[0]
+				break
+			}
+			time.Sleep(5 * time.Millisecond)
+		}
+		created <- query
+
+		query.Status.Phase = "running"
+		if err := k8sClient.Status().Update(ctx, &query); err != nil {
+			return
+		}
+		<-release
+		query.Status.Phase = phase
+		query.Status.Responses = []arkv1alpha1.Response{{Target: query.Spec.Targets[0], Content: content, Phase: phase}}
+		if phase == "error" {
+			query.Status.Conditions = []metav1.Condition{{
+				Type:               string(arkv1alpha1.QueryCompleted),
+				Status:             metav1.ConditionTrue,
+				Reason:             "QueryErrored",
+				Message:            content,
+				LastTransitionTime: metav1.Now(),
+			}}
+		}
+		_ = k8sClient.Status().Update(ctx, &query)
+	}()
+	return created
+}
+
+func TestServerPublishesLabeledAgentsAndTeams(t *testing.T) {
+	unlabeled := &arkv1alpha1.Agent{ObjectMeta: metav1.ObjectMeta{Name: "private", Namespace: "default"}}
+	otherNamespace := publishedAgent()
+	otherNamespace.Namespace = "other"
+	team := &arkv1alpha1.Team{ObjectMeta: metav1.ObjectMeta{
+		Name:      "research",
+		Namespace: "default",
+		Labels:    map[string]string{labels.MCPExposeLabel: "true"},
+	}}
+	s := &Server{Client: newTestClient(t, publishedAgent(), unlabeled, otherNamespace, team)}
+
+	tools, err := connect(t, s, "default", nil).ListTools(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, tools.Tools, 2)
+
+	byName := map[string]*mcp.Tool{}
+	for _, tool := range tools.Tools {
+		byName[tool.Name] = tool
+	}
+	require.Contains(t, byName, "agent-weather")
+	require.Contains(t, byName, "team-research")
+	assert.Equal(t, "Forecasts the weather", byName["agent-weather"].Description)
+	assert.Equal(t, "Query the research team", byName["team-research"].Description)
+
+	schema, ok := byName["agent-weather"].InputSchema.(map[string]any)
+	require.True(t, ok)
+	properties, ok := schema["properties"].(map[string]any)
+	require.True(t, ok)
+	assert.Contains(t, properties, "input")
+	assert.Contains(t, properties, "city")
+	assert.NotContains(t, properties, "units")
+	assert.Equal(t, []any{"input"}, schema["required"])
+}
+
+func TestServerQueriesAgentWithProgress(t *testing.T) {
+	k8sClient := newTestClient(t, publishedAgent())
+	s := &Server{Client: k8sClient, PollInterval: 10 * time.Millisecond}
+
+	release := make(chan struct{})
+	created := completeQueries(t, k8sClient, release, "done", "Sunny")
+
+	var once sync.Once
+	var progressMessage string
+	session := connect(t, s, "default", &mcp.ClientOptions{
+		ProgressNotificationHandler: func(ctx context.Context, req *mcp.ProgressNotificationClientRequest) {
+			once.Do(func() {
+				progressMessage = req.Params.Message
+				close(release)
+			})
+		},
+	})
+
+	params := &mcp.CallToolParams{
+		Meta:      mcp.Meta{},
+		Name:      "agent-weather",
+		Arguments: map[string]any{"input": "What is the weather in {{.city}}?", "city": "Paris"},
+	}
+	params.SetProgressToken("weather-1")
+	result, err := session.CallTool(context.Background(), params)
+	require.NoError(t, err)
+
+	assert.False(t, result.IsError)
+	require.Len(t, result.Content, 1)
+	assert.Equal(t, "Sunny", result.Content[0].(*mcp.TextContent).Text)
+
+	query := <-created
+	assert.Equal(t, "default", query.Namespace)
+	assert.Equal(t, "mcp", query.Annotations[annotations.TriggeredFrom])
+	assert.Equal(t, "system:serviceaccount:default:assistant", query.Annotations[annotations.RequestedBy])
+	assert.Equal(t, "assistant", query.Spec.ServiceAccount)
+	assert.Equal(t, []arkv1alpha1.QueryTarget{{Type: "agent", Name: "weather"}}, query.Spec.Targets)
+	assert.Equal(t, []arkv1alpha1.Parameter{{Name: "city", Value: "Paris"}}, query.Spec.Parameters)
+	input, err := query.Spec.GetInputString()
+	require.NoError(t, err)
+	assert.Equal(t, "What is the weather in {{.city}}?", input)
+	assert.Regexp(t, `^query mcp-agent-weather-\S+ is (pending|running)$`, progressMessage)
+}
+
+func TestServerReportsFailedQueries(t *testing.T) {
+	k8sClient := newTestClient(t, publishedAgent())
+	s := &Server{Client: k8sClient, PollInterval: 10 * time.Millisecond}
+
+	release := make(chan struct{})
+	close(release)
+	completeQueries(t, k8sClient, release, "error", "model unavailable")
+
+	result, err := connect(t, s, "default", nil).CallTool(context.Background(), &mcp.CallToolParams{
+		Name:      "agent-weather",
+		Arguments: map[string]any{"input": "What is the weather?"},
+	})
+	require.NoError(t, err)
+	assert.True(t, result.IsError)
+	assert.Equal(t, "model unavailable", result.Content[0].(*mcp.TextContent).Text)
+}
+
+func TestParseArguments(t *testing.T) {
+	tests := []struct {
+		name      string
+		arguments string
+		wantError string
+	}{
+		{name: "missing input", arguments: `{"city": "Paris"}`, wantError: "argument input is required"},
+		{name: "empty input", arguments: `{"input": " "}`, wantError: "argument input is required"},
+		{name: "unknown argument", arguments: `{"input": "hi", "country": "France"}`, wantError: "unknown argument country"},
+		{name: "non-string parameter", arguments: `{"input": "hi", "city": 42}`, wantError: "argument city must be a string"},
+		{name: "valid", arguments: `{"input": "hi", "city": "Paris"}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := parseArguments([]byte(tt.arguments), []string{"city"})
+			if tt.wantError == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantError)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+/* Copyright 2025. McKinsey & Company */
+
+package mcpgateway
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/annotations"
+)
+
+const (
+	inputArgument = "input"
+	triggeredFrom = "mcp"
+
+	// defaultQueryTimeout matches the default timeout of the Query CRD
+	defaultQueryTimeout = 5 * time.Minute
+	// completionGracePeriod is how long a tool call waits beyond the timeout of its query for the query to complete
+	completionGracePeriod = time.Minute
+
+	phasePending  = "pending"
+	phaseDone     = "done"
+	phaseError    = "error"
+	phaseCanceled = "canceled"
+)
+
+// addQueryTool adds a tool that queries the target. The tool takes the input of the query, and the query
+// parameters referenced by the parameters of the target.
+func (s *Server) addQueryTool(server *mcp.Server, namespace string, user *authenticationv1.UserInfo, target arkv1alpha1.QueryTarget, description string, parameters []arkv1alpha1.Parameter) {
+	parameterNames := queryParameterNames(parameters)
+	if description == "" {
+		description = fmt.Sprintf("Query the %s %s", target.Name, target.Type)
+	}
+	tool := &mcp.Tool{
+		Name:        toolName(target),
+		Description: description,
+		InputSchema: inputSchema(parameterNames),
+	}
+	server.AddTool(tool, func(ctx context.Context, req *mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		input, queryParameters, err := parseArguments(req.Params.Arguments, parameterNames)
+		if err != nil {
+			return errorResult(err.Error()), nil
+		}
+		query, err := s.createQuery(ctx, namespace, user, target, input, queryParameters)
+		if err != nil {
+			return errorResult(fmt.Sprintf("failed to create query: %v", err)), nil
+		}
+		return s.awaitQuery(ctx, req, query)
+	})
+}
+
+// toolName names tools after their targets, such as "agent-weather" or "team-research".
+func toolName(target arkv1alpha1.QueryTarget) string {
+	return target.Type + "-" + target.Name
+}
+
+// queryParameterNames returns the sorted names of the query parameters referenced by the parameters.
+func queryParameterNames(parameters []arkv1alpha1.Parameter) []string {
+	var names []string
+	for _, parameter := range parameters {
+		if parameter.ValueFrom == nil || parameter.ValueFrom.QueryParameterRef == nil {
+			continue
+		}
+		name := parameter.ValueFrom.QueryParameterRef.Name
+		if name != inputArgument && !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+func inputSchema(parameterNames []string) *jsonschema.Schema {
+	schema := &jsonschema.Schema{
+		Type: "object",
+		Properties: map[string]*jsonschema.Schema{
+			inputArgument: {Type: "string", Description: "The input of the query"},
+		},
+		Required: []string{inputArgument},
+	}
+	for _, name := range parameterNames {
+		schema.Properties[name] = &jsonschema.Schema{Type: "string", Description: fmt.Sprintf("The %s query parameter", name)}
+	}
+	return schema
+}
+
+// parseArguments returns the input and the query parameters of a tool call.
+func parseArguments(arguments json.RawMessage, parameterNames []string) (string, []arkv1alpha1.Parameter, error) {
+	var args map[string]any
+	if len(arguments) > 0 {
+		if err := json.Unmarshal(arguments, &args); err != nil {
+			return "", nil, fmt.Errorf("invalid arguments: %w", err)
+		}
+	}
+
+	input, ok := args[inputArgument].(string)
+	if !ok || strings.TrimSpace(input) == "" {
+		return "", nil, fmt.Errorf("argument %s is required and must be a non-empty string", inputArgument)
+	}
+
+	var parameters []arkv1alpha1.Parameter
+	for name, value := range args {
+		if name == inputArgument {
+			continue
+		}
+		if !slices.Contains(parameterNames, name) {
+			return "", nil, fmt.Errorf("unknown argument %s", name)
+		}
+		stringValue, ok := value.(string)
+		if !ok {
+			return "", nil, fmt.Errorf("argument %s must be a string", name)
+		}
+		parameters = append(parameters, arkv1alpha1.Parameter{Name: name, Value: stringValue})
+	}
+	slices.SortFunc(parameters, func(a, b arkv1alpha1.Parameter) int { return strings.Compare(a.Name, b.Name) })
+	return input, parameters, nil
+}
+
+// createQuery creates the query of a tool call on behalf of the user, who is recorded in an annotation. Queries of
+// service accounts of the namespace run with that service account.
+func (s *Server) createQuery(ctx context.Context, namespace string, user *authenticationv1.UserInfo, target arkv1alpha1.QueryTarget, input string, parameters []arkv1alpha1.Parameter) (*arkv1alpha1.Query, error) {
+	rawInput, err := json.Marshal(input)
+	if err != nil {
+		return nil, err
+	}
+	query := &arkv1alpha1.Query{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "mcp-" + toolName(target) + "-",
+			Namespace:    namespace,
+			Annotations:  map[string]string{annotations.TriggeredFrom: triggeredFrom},
+		},
+		Spec: arkv1alpha1.QuerySpec{
+			Type:           arkv1alpha1.QueryTypeUser,
+			Input:          runtime.RawExtension{Raw: rawInput},
+			Parameters:     parameters,
+			Targets:        []arkv1alpha1.QueryTarget{target},
+			ServiceAccount: queryServiceAccount(user, namespace),
+		},
+	}
+	if user != nil {
+		query.Annotations[annotations.RequestedBy] = user.Username
+	}
+	if err := s.Client.Create(ctx, query); err != nil {
+		return nil, err
+	}
+	log.Info("created query for MCP tool call", "namespace", namespace, "query", query.Name, "target", toolName(target),
+		"user", query.Annotations[annotations.RequestedBy])
+	return query, nil
+}
+
+// awaitQuery waits for the query to complete and returns its response. Clients that ask for progress are notified
+// of the phase of the query while it runs. The query is canceled when the client cancels the call.
+func (s *Server) awaitQuery(ctx context.Context, req *mcp.CallToolRequest, query *arkv1alpha1.Query) (*mcp.CallToolResult, error) {
+	timeout := defaultQueryTimeout
+	if query.Spec.Timeout != nil {
+		timeout = query.Spec.Timeout.Duration
+	}
+	waitCtx, cancel := context.WithTimeout(ctx, timeout+completionGracePeriod)
+	defer cancel()
+
+	started := time.Now()
+	key := client.ObjectKeyFromObject(query)
+	ticker := time.NewTicker(s.pollInterval())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-waitCtx.Done():
+			s.cancelQuery(ctx, query)
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			return errorResult(fmt.Sprintf("query %s did not complete within %s", query.Name, timeout)), nil
+		case <-ticker.C:
+		}
+
+		if err := s.Client.Get(waitCtx, key, query); err != nil {
+			// The cache of the client may not have observed the query yet
+			if apierrors.IsNotFound(err) || waitCtx.Err() != nil {
+				continue
+			}
+			return errorResult(fmt.Sprintf("failed to get query %s: %v", query.Name, err)), nil
+		}
+
+		switch query.Status.Phase {
+		case phaseDone:
+			return queryResult(query), nil
+		case phaseError:
+			return errorResult(queryError(query)), nil
+		case phaseCanceled:
+			return errorResult(fmt.Sprintf("query %s was canceled", query.Name)), nil
+		}
+
+		phase := query.Status.Phase
+		if phase == "" {
+			phase = phasePending
+		}
+		notifyProgress(waitCtx, req, time.Since(started).Seconds(), fmt.Sprintf("query %s is %s", query.Name, phase))
+	}
+}
+
+// cancelQuery asks the query controller to stop the query of an abandoned tool call.
+func (s *Server) cancelQuery(ctx context.Context, query *arkv1alpha1.Query) {
+	patch := client.MergeFrom(query.DeepCopy())
+	query.Spec.Cancel = true
+	if err := s.Client.Patch(context.WithoutCancel(ctx), query, patch); err != nil && !apierrors.IsNotFound(err) {
+		log.Error(err, "failed to cancel query of MCP tool call", "namespace", query.Namespace, "query", query.Name)
+	}
+}
+
+func notifyProgress(ctx context.Context, req *mcp.CallToolRequest, progress float64, message string) {
+	token := req.Params.GetProgressToken()
+	if token == nil {
+		return
+	}
+	params := &mcp.ProgressNotificationParams{ProgressToken: token, Progress: progress, Message: message}
+	if err := req.Session.NotifyProgress(ctx, params); err != nil {
+		log.V(1).Info("failed to notify progress of MCP tool call", "error", err.Error())
+	}
+}
+
+func queryResult(query *arkv1alpha1.Query) *mcp.CallToolResult {
+	result := &mcp.CallToolResult{Content: []mcp.Content{}}
+	for _, response := range query.Status.Responses {
+		result.Content = append(result.Content, &mcp.TextContent{Text: response.Content})
+	}
+	return result
+}
+
+// queryError returns the error of a failed query, which the query controller reports in its Completed condition.
+func queryError(query *arkv1alpha1.Query) string {
+	if condition := meta.FindStatusCondition(query.Status.Conditions, string(arkv1alpha1.QueryCompleted)); condition != nil && condition.Message != "" {
+		return condition.Message
+	}
+	return fmt.Sprintf("query %s failed", query.Name)
+}
+
+func errorResult(message string) *mcp.CallToolResult {
+	return &mcp.CallToolResult{
+		Content: []mcp.Content{&mcp.TextContent{Text: message}},
+		IsError: true,
+	}
+}
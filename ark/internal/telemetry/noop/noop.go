@@ -122,6 +122,7 @@ func (r *noopToolRecorder) StartToolExecution(ctx context.Context, toolName, too
 }
 
 func (r *noopToolRecorder) RecordToolResult(span telemetry.Span, result string) {} //nolint:revive
+func (r *noopToolRecorder) RecordCacheHit(span telemetry.Span)                  {} //nolint:revive
 func (r *noopToolRecorder) RecordSuccess(span telemetry.Span)                   {} //nolint:revive
 func (r *noopToolRecorder) RecordError(span telemetry.Span, err error)          {} //nolint:revive
 
@@ -37,6 +37,10 @@ func (r *toolRecorder) RecordToolResult(span telemetry.Span, result string) {
 	span.SetAttributes(telemetry.String(telemetry.AttrToolOutput, result))
 }
 
+func (r *toolRecorder) RecordCacheHit(span telemetry.Span) {
+	span.SetAttributes(telemetry.Bool(telemetry.AttrToolCacheHit, true))
+}
+
 func (r *toolRecorder) RecordSuccess(span telemetry.Span) {
 	span.SetStatus(telemetry.StatusOk, "success")
 }
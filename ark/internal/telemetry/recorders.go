@@ -108,6 +108,9 @@ type ToolRecorder interface {
 	// RecordToolResult records the tool execution result.
 	RecordToolResult(span Span, result string)
 
+	// RecordCacheHit marks a tool execution as served from the result cache.
+	RecordCacheHit(span Span)
+
 	// RecordSuccess marks a span as successfully completed.
 	RecordSuccess(span Span)
 
@@ -186,6 +189,7 @@ const (
 	AttrToolInput       = "tool.input"
 	AttrToolOutput      = "tool.output"
 	AttrToolDescription = "tool.description"
+	AttrToolCacheHit    = "tool.cache_hit"
 
 	// Message attributes
 	AttrMessagesInputCount = "messages.input_count"
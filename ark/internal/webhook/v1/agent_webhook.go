@@ -5,7 +5,9 @@ package v1
 import (
 	"context"
 	"fmt"
+	"path"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -13,6 +15,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/annotations"
+	"mckinsey.com/ark/internal/genai"
 )
 
 // SetupAgentWebhookWithManager registers the webhook for Agent in the manager.
@@ -99,6 +102,10 @@ func (v *AgentCustomValidator) validateAgent(ctx context.Context, agent *arkv1al
 		return warnings, err
 	}
 
+	if err := v.validatePromptFrom(agent); err != nil {
+		return warnings, err
+	}
+
 	for i, tool := range agent.Spec.Tools {
 		toolWarnings, err := v.validateTool(i, tool)
 		if err != nil {
@@ -107,6 +114,14 @@ func (v *AgentCustomValidator) validateAgent(ctx context.Context, agent *arkv1al
 		warnings = append(warnings, toolWarnings...)
 	}
 
+	deps, err := v.agentDependencies(ctx, agent)
+	if err != nil {
+		return warnings, err
+	}
+	if err := v.ValidateNoDependencyCycle(ctx, agent.Namespace, dependencyNode{Kind: dependencyKindAgent, Name: agent.Name}, deps); err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
@@ -117,6 +132,19 @@ func (v *AgentCustomValidator) validateAgentModel(ctx context.Context, agent *ar
 	return nil
 }
 
+func (v *AgentCustomValidator) validatePromptFrom(agent *arkv1alpha1.Agent) error {
+	if agent.Spec.PromptFrom == nil {
+		return nil
+	}
+	if agent.Spec.PromptFrom.MCPPrompt == nil {
+		return fmt.Errorf("promptFrom: mcpPrompt must be specified")
+	}
+	if agent.Spec.Prompt != "" {
+		return fmt.Errorf("prompt and promptFrom cannot both be specified")
+	}
+	return nil
+}
+
 func (v *AgentCustomValidator) validateBuiltInTool(tool arkv1alpha1.AgentTool, hasName bool, index int) error {
 	if !hasName {
 		return fmt.Errorf("tool[%d]: built-in tools must specify a name", index)
@@ -143,6 +171,12 @@ func (v *AgentCustomValidator) validateTool(index int, tool arkv1alpha1.AgentToo
 	var warnings admission.Warnings
 	hasName := tool.Name != ""
 
+	for i, fn := range tool.Functions {
+		if err := genai.ValidateToolFunction(fn); err != nil {
+			return warnings, fmt.Errorf("tool[%d].functions[%d]: %w", index, i, err)
+		}
+	}
+
 	switch tool.Type {
 	case "built-in":
 		if err := v.validateBuiltInTool(tool, hasName, index); err != nil {
@@ -150,13 +184,39 @@ func (v *AgentCustomValidator) validateTool(index int, tool arkv1alpha1.AgentToo
 		}
 	case "custom":
 		return v.validateCustomTool(tool, hasName, index)
+	case genai.AgentToolTypeSelector:
+		if err := v.validateSelectorTool(tool, index); err != nil {
+			return warnings, err
+		}
 	default:
-		return warnings, fmt.Errorf("tool[%d]: unsupported tool type '%s': supported types are: built-in, custom", index, tool.Type)
+		return warnings, fmt.Errorf("tool[%d]: unsupported tool type '%s': supported types are: built-in, custom, selector", index, tool.Type)
 	}
 
 	return warnings, nil
 }
 
+func (v *AgentCustomValidator) validateSelectorTool(tool arkv1alpha1.AgentTool, index int) error {
+	if tool.Selector == nil {
+		return fmt.Errorf("tool[%d]: selector tools must specify a selector", index)
+	}
+	if tool.Name != "" || tool.Description != "" || tool.Partial != nil {
+		return fmt.Errorf("tool[%d]: selector tools do not support name, description or partial", index)
+	}
+	// An empty label selector would select every Tool of the namespace
+	if len(tool.Selector.MatchLabels) == 0 && len(tool.Selector.MatchExpressions) == 0 {
+		return fmt.Errorf("tool[%d].selector: matchLabels or matchExpressions must have at least one term", index)
+	}
+	if _, err := metav1.LabelSelectorAsSelector(&tool.Selector.LabelSelector); err != nil {
+		return fmt.Errorf("tool[%d].selector: %v", index, err)
+	}
+	for _, pattern := range append(append([]string{}, tool.Selector.Include...), tool.Selector.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("tool[%d].selector: invalid pattern '%s': %v", index, pattern, err)
+		}
+	}
+	return nil
+}
+
 func isValidBuiltInTool(name string) bool {
 	validBuiltInTools := map[string]bool{
 		"noop":      true,
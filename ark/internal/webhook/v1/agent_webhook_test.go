@@ -129,4 +129,198 @@ var _ = Describe("Agent Webhook", func() {
 			Expect(agent.Spec.ModelRef).To(BeNil())
 		})
 	})
+
+	Context("When validating dependency cycles", func() {
+		It("Should reject an agent tool that calls the agent itself", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-self", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:  genai.ToolTypeAgent,
+					Agent: &arkv1alpha1.AgentToolRef{Name: "test-agent"},
+				},
+			}
+			Expect(validator.Client.Create(ctx, tool)).To(Succeed())
+
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{Type: "custom", Name: "ask-self"}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dependency cycle detected: Agent/test-agent -> Tool/ask-self -> Agent/test-agent"))
+		})
+
+		It("Should follow partial tools to their Tool resource", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-self", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:  genai.ToolTypeAgent,
+					Agent: &arkv1alpha1.AgentToolRef{Name: "test-agent"},
+				},
+			}
+			Expect(validator.Client.Create(ctx, tool)).To(Succeed())
+
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{Type: "custom", Name: "ask-myself", Partial: &arkv1alpha1.ToolPartial{Name: "ask-self"}}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dependency cycle detected: Agent/test-agent -> Tool/ask-self -> Agent/test-agent"))
+		})
+
+		It("Should follow selector tools to the tools they select", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-self", Namespace: "default", Labels: map[string]string{"team": "support"}},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:  genai.ToolTypeAgent,
+					Agent: &arkv1alpha1.AgentToolRef{Name: "test-agent"},
+				},
+			}
+			Expect(validator.Client.Create(ctx, tool)).To(Succeed())
+
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type: genai.AgentToolTypeSelector,
+				Selector: &arkv1alpha1.AgentToolSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"team": "support"}},
+				},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dependency cycle detected: Agent/test-agent -> Tool/ask-self -> Agent/test-agent"))
+		})
+
+		It("Should allow tools that do not exist yet", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{Type: "custom", Name: "not-created-yet"}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+	})
+
+	Context("When validating tool functions", func() {
+		It("Should accept supported functions", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type: "custom",
+				Name: "fetch-page",
+				Functions: []arkv1alpha1.ToolFunction{
+					{Name: "html-to-text"},
+					{Name: "redact", Value: `[\w.]+@[\w.]+`},
+					{Name: "truncate", Value: "2000 tokens"},
+				},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject unknown functions", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type:      "custom",
+				Name:      "fetch-page",
+				Functions: []arkv1alpha1.ToolFunction{{Name: "summarize"}},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("tool[0].functions[0]: unsupported function 'summarize'"))
+		})
+
+		It("Should reject invalid function values", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type:      "custom",
+				Name:      "fetch-page",
+				Functions: []arkv1alpha1.ToolFunction{{Name: "truncate", Value: "a lot"}},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid value for function 'truncate'"))
+		})
+	})
+
+	Context("When validating the prompt source", func() {
+		It("Should accept an MCP prompt", func() {
+			agent.Spec.Prompt = ""
+			agent.Spec.PromptFrom = &arkv1alpha1.AgentPromptSource{MCPPrompt: &arkv1alpha1.MCPPromptRef{
+				MCPServerRef: arkv1alpha1.MCPServerRef{Name: "docs"},
+				Name:         "review",
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject a prompt together with promptFrom", func() {
+			agent.Spec.PromptFrom = &arkv1alpha1.AgentPromptSource{MCPPrompt: &arkv1alpha1.MCPPromptRef{
+				MCPServerRef: arkv1alpha1.MCPServerRef{Name: "docs"},
+				Name:         "review",
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(MatchError(ContainSubstring("prompt and promptFrom cannot both be specified")))
+		})
+
+		It("Should require a source in promptFrom", func() {
+			agent.Spec.Prompt = ""
+			agent.Spec.PromptFrom = &arkv1alpha1.AgentPromptSource{}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(MatchError(ContainSubstring("promptFrom: mcpPrompt must be specified")))
+		})
+	})
+
+	Context("When validating selector tools", func() {
+		It("Should accept a label selector with name patterns", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type: genai.AgentToolTypeSelector,
+				Selector: &arkv1alpha1.AgentToolSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"mcp/server": "github"}},
+					Include:       []string{"github-*-issue*"},
+					NamePrefix:    "gh-",
+				},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should require a selector", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{Type: genai.AgentToolTypeSelector}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(MatchError(ContainSubstring("tool[0]: selector tools must specify a selector")))
+		})
+
+		It("Should reject names on selector tools", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type:     genai.AgentToolTypeSelector,
+				Name:     "github",
+				Selector: &arkv1alpha1.AgentToolSelector{},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(MatchError(ContainSubstring("selector tools do not support name")))
+		})
+
+		It("Should reject invalid name patterns", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type: genai.AgentToolTypeSelector,
+				Selector: &arkv1alpha1.AgentToolSelector{
+					LabelSelector: metav1.LabelSelector{MatchLabels: map[string]string{"mcp/server": "github"}},
+					Exclude:       []string{"delete-["},
+				},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(MatchError(ContainSubstring("invalid pattern 'delete-['")))
+		})
+
+		It("Should reject empty label selectors", func() {
+			agent.Spec.Tools = []arkv1alpha1.AgentTool{{
+				Type:     genai.AgentToolTypeSelector,
+				Selector: &arkv1alpha1.AgentToolSelector{Include: []string{"get_*"}},
+			}}
+
+			_, err := validator.ValidateCreate(ctx, agent)
+			Expect(err).To(MatchError(ContainSubstring("tool[0].selector: matchLabels or matchExpressions must have at least one term")))
+		})
+	})
 })
@@ -0,0 +1,172 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+const (
+	dependencyKindAgent = "Agent"
+	dependencyKindTeam  = "Team"
+	dependencyKindTool  = "Tool"
+)
+
+// dependencyNode is an agent, team or tool that can call other agents and teams at runtime.
+type dependencyNode struct {
+	Kind string
+	Name string
+}
+
+func (n dependencyNode) String() string {
+	return n.Kind + "/" + n.Name
+}
+
+func teamDependencies(team *arkv1alpha1.Team) []dependencyNode {
+	var deps []dependencyNode
+	for _, member := range team.Spec.Members {
+		switch member.Type {
+		case MemberTypeAgent:
+			deps = append(deps, dependencyNode{Kind: dependencyKindAgent, Name: member.Name})
+		case MemberTypeTeam:
+			deps = append(deps, dependencyNode{Kind: dependencyKindTeam, Name: member.Name})
+		}
+		if member.HistoryPolicy != nil && member.HistoryPolicy.Summarizer != "" {
+			deps = append(deps, dependencyNode{Kind: dependencyKindAgent, Name: member.HistoryPolicy.Summarizer})
+		}
+	}
+	if team.Spec.Selector != nil && team.Spec.Selector.Agent != "" {
+		deps = append(deps, dependencyNode{Kind: dependencyKindAgent, Name: team.Spec.Selector.Agent})
+	}
+	if team.Spec.Vote != nil && team.Spec.Vote.Judge != "" {
+		deps = append(deps, dependencyNode{Kind: dependencyKindAgent, Name: team.Spec.Vote.Judge})
+	}
+	return deps
+}
+
+// agentDependencies returns the tools an agent calls, by the name of their Tool resource. Selector tools are
+// resolved to the tools they currently select.
+func (v *ResourceValidator) agentDependencies(ctx context.Context, agent *arkv1alpha1.Agent) ([]dependencyNode, error) {
+	var deps []dependencyNode
+	for i := range agent.Spec.Tools {
+		tool := &agent.Spec.Tools[i]
+		switch tool.Type {
+		case genai.AgentToolTypeCustom:
+			if name := tool.GetToolCRDName(); name != "" {
+				deps = append(deps, dependencyNode{Kind: dependencyKindTool, Name: name})
+			}
+		case genai.AgentToolTypeSelector:
+			selected, err := genai.ListSelectedTools(ctx, v.Client, agent.Namespace, tool.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("tools[%d]: %v", i, err)
+			}
+			for _, selectedTool := range selected {
+				deps = append(deps, dependencyNode{Kind: dependencyKindTool, Name: selectedTool.Name})
+			}
+		}
+	}
+	return deps, nil
+}
+
+func toolDependencies(tool *arkv1alpha1.Tool) []dependencyNode {
+	switch {
+	case tool.Spec.Type == genai.ToolTypeAgent && tool.Spec.Agent != nil:
+		return []dependencyNode{{Kind: dependencyKindAgent, Name: tool.Spec.Agent.Name}}
+	case tool.Spec.Type == genai.ToolTypeTeam && tool.Spec.Team != nil:
+		return []dependencyNode{{Kind: dependencyKindTeam, Name: tool.Spec.Team.Name}}
+	default:
+		return nil
+	}
+}
+
+// loadDependencies returns what a stored resource calls. Resources that do not exist yet have no dependencies.
+func (v *ResourceValidator) loadDependencies(ctx context.Context, node dependencyNode, namespace string) ([]dependencyNode, error) {
+	key := types.NamespacedName{Name: node.Name, Namespace: namespace}
+
+	var (
+		deps []dependencyNode
+		err  error
+	)
+	switch node.Kind {
+	case dependencyKindAgent:
+		agent := &arkv1alpha1.Agent{}
+		if err = v.Client.Get(ctx, key, agent); err == nil {
+			if deps, err = v.agentDependencies(ctx, agent); err != nil {
+				return nil, fmt.Errorf("failed to resolve the tools of %s in namespace '%s': %v", node, namespace, err)
+			}
+		}
+	case dependencyKindTeam:
+		team := &arkv1alpha1.Team{}
+		if err = v.Client.Get(ctx, key, team); err == nil {
+			deps = teamDependencies(team)
+		}
+	case dependencyKindTool:
+		tool := &arkv1alpha1.Tool{}
+		if err = v.Client.Get(ctx, key, tool); err == nil {
+			deps = toolDependencies(tool)
+		}
+	}
+
+	if errors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s in namespace '%s': %v", node, namespace, err)
+	}
+	return deps, nil
+}
+
+// ValidateNoDependencyCycle rejects a resource when the agents, teams and tools it calls lead back to it.
+// The dependencies of the resource itself are passed in, since the stored version may be outdated or missing.
+func (v *ResourceValidator) ValidateNoDependencyCycle(ctx context.Context, namespace string, start dependencyNode, startDeps []dependencyNode) error {
+	visited := map[dependencyNode]bool{}
+
+	var visit func(node dependencyNode, path []dependencyNode) ([]dependencyNode, error)
+	visit = func(node dependencyNode, path []dependencyNode) ([]dependencyNode, error) {
+		path = append(path, node)
+		if node == start && len(path) > 1 {
+			return path, nil
+		}
+		if visited[node] {
+			return nil, nil
+		}
+		visited[node] = true
+
+		deps := startDeps
+		if node != start {
+			var err error
+			if deps, err = v.loadDependencies(ctx, node, namespace); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, dep := range deps {
+			cycle, err := visit(dep, path)
+			if err != nil || cycle != nil {
+				return cycle, err
+			}
+		}
+		return nil, nil
+	}
+
+	cycle, err := visit(start, nil)
+	if err != nil {
+		return err
+	}
+	if cycle != nil {
+		names := make([]string, 0, len(cycle))
+		for _, node := range cycle {
+			names = append(names, node.String())
+		}
+		return fmt.Errorf("dependency cycle detected: %s", strings.Join(names, " -> "))
+	}
+	return nil
+}
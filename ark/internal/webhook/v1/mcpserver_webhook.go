@@ -5,6 +5,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"path"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -15,6 +16,7 @@ import (
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
 )
 
 var mcpserverlog = logf.Log.WithName("mcpserver-resource")
@@ -35,6 +37,15 @@ func SetupMCPServerWebhookWithManager(mgr ctrl.Manager) error {
 type MCPServerValidator struct {
 	Client   client.Client
 	Resolver *common.ValueSourceResolver
+	// Stdio checks the commands of stdio servers, defaulting to the supervisor of the controller
+	Stdio *genai.MCPStdioSupervisor
+}
+
+func (v *MCPServerValidator) getStdio() *genai.MCPStdioSupervisor {
+	if v.Stdio == nil {
+		return genai.DefaultMCPStdioSupervisor
+	}
+	return v.Stdio
 }
 
 var _ webhook.CustomValidator = &MCPServerValidator{}
@@ -47,10 +58,23 @@ func (v *MCPServerValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 
 	mcpserverlog.Info("Validating MCPServer", "name", mcpserver.GetName(), "namespace", mcpserver.GetNamespace())
 
-	_, err := v.Resolver.ResolveValueSource(ctx, mcpserver.Spec.Address, mcpserver.GetNamespace())
-	if err != nil {
-		mcpserverlog.Error(err, "Failed to resolve Address", "mcpserver", mcpserver.GetName())
-		return nil, fmt.Errorf("failed to resolve Address: %w", err)
+	if mcpserver.Spec.Transport == genai.MCPStdioTransport {
+		if err := validateMCPServerCommand(mcpserver); err != nil {
+			mcpserverlog.Error(err, "Failed to validate command", "mcpserver", mcpserver.GetName())
+			return nil, err
+		}
+		if err := v.getStdio().CheckCommand(mcpserver.Spec.Command.Command); err != nil {
+			return nil, fmt.Errorf("command: %w", err)
+		}
+	} else {
+		if mcpserver.Spec.Command != nil {
+			return nil, fmt.Errorf("command is only used by the stdio transport")
+		}
+		_, err := v.Resolver.ResolveValueSource(ctx, mcpserver.Spec.Address, mcpserver.GetNamespace())
+		if err != nil {
+			mcpserverlog.Error(err, "Failed to resolve Address", "mcpserver", mcpserver.GetName())
+			return nil, fmt.Errorf("failed to resolve Address: %w", err)
+		}
 	}
 
 	for i, header := range mcpserver.Spec.Headers {
@@ -61,15 +85,71 @@ func (v *MCPServerValidator) ValidateCreate(ctx context.Context, obj runtime.Obj
 		}
 	}
 
+	if err := ValidateAuth(mcpserver.Spec.Auth, "auth"); err != nil {
+		mcpserverlog.Error(err, "Failed to validate auth", "mcpserver", mcpserver.GetName())
+		return nil, err
+	}
+
 	// Validate PollInterval
 	if err := ValidatePollInterval(mcpserver.Spec.PollInterval.Duration); err != nil {
 		mcpserverlog.Error(err, "Failed to validate pollInterval", "mcpserver", mcpserver.GetName())
 		return nil, fmt.Errorf("failed to validate pollInterval: %w", err)
 	}
 
+	warnings, err := validateMCPServerTools(mcpserver.Spec.Tools)
+	if err != nil {
+		mcpserverlog.Error(err, "Failed to validate tools", "mcpserver", mcpserver.GetName())
+		return nil, err
+	}
+
 	mcpserverlog.Info("MCPServer validation complete", "name", mcpserver.GetName())
 
-	return nil, nil
+	return warnings, nil
+}
+
+// validateMCPServerCommand validates servers of the stdio transport, which are launched rather than addressed.
+func validateMCPServerCommand(mcpserver *arkv1alpha1.MCPServer) error {
+	spec := mcpserver.Spec
+	if spec.Command == nil {
+		return fmt.Errorf("command is required by the stdio transport")
+	}
+	if spec.Address.Value != "" || spec.Address.ValueFrom != nil {
+		return fmt.Errorf("address is not used by the stdio transport")
+	}
+	if len(spec.Headers) > 0 || spec.Auth != nil {
+		return fmt.Errorf("headers and auth are not used by the stdio transport")
+	}
+
+	for i, env := range spec.Command.Env {
+		hasValue := env.Value != ""
+		hasValueFrom := env.ValueFrom != nil
+		if hasValue == hasValueFrom {
+			return fmt.Errorf("command.env[%d]: exactly one of value or valueFrom must be specified", i)
+		}
+		if hasValueFrom && env.ValueFrom.QueryParameterRef != nil {
+			return fmt.Errorf("command.env[%d]: queryParameterRef is not supported, as the process is shared by all queries", i)
+		}
+	}
+	return nil
+}
+
+func validateMCPServerTools(tools *arkv1alpha1.MCPServerTools) (admission.Warnings, error) {
+	if tools == nil {
+		return nil, nil
+	}
+	for _, pattern := range append(append([]string{}, tools.Include...), tools.Exclude...) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("tools: invalid pattern '%s': %v", pattern, err)
+		}
+	}
+
+	var warnings admission.Warnings
+	for i, override := range tools.Overrides {
+		if !genai.ToolNameMatches(tools.Include, tools.Exclude, override.Name) {
+			warnings = append(warnings, fmt.Sprintf("tools.overrides[%d]: tool '%s' is not published by the include and exclude patterns", i, override.Name))
+		}
+	}
+	return warnings, nil
 }
 
 func (v *MCPServerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
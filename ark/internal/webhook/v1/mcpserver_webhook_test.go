@@ -0,0 +1,132 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var _ = Describe("MCPServer Webhook", func() {
+	var (
+		ctx       context.Context
+		mcpServer *arkv1alpha1.MCPServer
+		validator *MCPServerValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &MCPServerValidator{Resolver: common.NewValueSourceResolver(nil)}
+
+		mcpServer = &arkv1alpha1.MCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "github",
+				Namespace: "default",
+			},
+			Spec: arkv1alpha1.MCPServerSpec{
+				Address:      arkv1alpha1.ValueSource{Value: "http://github-mcp:8080/mcp"},
+				PollInterval: &metav1.Duration{Duration: time.Minute},
+				Tools: &arkv1alpha1.MCPServerTools{
+					Include:   []string{"search_*", "get_*"},
+					Exclude:   []string{"*_admin"},
+					Overrides: []arkv1alpha1.MCPToolOverride{{Name: "search_issues", Description: "Search issues"}},
+				},
+			},
+		}
+	})
+
+	Context("When validating tool selection", func() {
+		It("Should admit valid patterns and overrides", func() {
+			warnings, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny invalid patterns", func() {
+			mcpServer.Spec.Tools.Exclude = []string{"[admin"}
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(ContainSubstring("tools: invalid pattern '[admin'")))
+		})
+
+		It("Should warn about overrides of tools that are not published", func() {
+			mcpServer.Spec.Tools.Overrides = append(mcpServer.Spec.Tools.Overrides, arkv1alpha1.MCPToolOverride{Name: "delete_admin"})
+			warnings, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf("tools.overrides[1]: tool 'delete_admin' is not published by the include and exclude patterns"))
+		})
+	})
+
+	Context("When validating the stdio transport", func() {
+		BeforeEach(func() {
+			validator.Stdio = genai.NewMCPStdioSupervisor(genai.MCPStdioSupervisorOptions{AllowedCommands: []string{"mcp-server-filesystem"}})
+			mcpServer.Spec.Transport = "stdio"
+			mcpServer.Spec.Address = arkv1alpha1.ValueSource{}
+			mcpServer.Spec.Command = &arkv1alpha1.MCPServerCommand{
+				Command: "mcp-server-filesystem",
+				Args:    []string{"/data"},
+				Env: []arkv1alpha1.MCPServerEnvVar{{
+					Name: "API_KEY",
+					ValueSource: arkv1alpha1.ValueSource{ValueFrom: &arkv1alpha1.ValueFromSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							LocalObjectReference: corev1.LocalObjectReference{Name: "filesystem"},
+							Key:                  "api-key",
+						},
+					}},
+				}},
+			}
+		})
+
+		It("Should admit a command without an address", func() {
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should require a command", func() {
+			mcpServer.Spec.Command = nil
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(ContainSubstring("command is required by the stdio transport")))
+		})
+
+		It("Should deny commands that are not allowed", func() {
+			mcpServer.Spec.Command.Command = "sh"
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(ContainSubstring(`command: command "sh" is not allowed for stdio MCP servers`)))
+		})
+
+		It("Should deny commands when the stdio transport is disabled", func() {
+			validator.Stdio = genai.NewMCPStdioSupervisor(genai.MCPStdioSupervisorOptions{})
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(genai.ErrMCPStdioDisabled))
+		})
+
+		It("Should deny an address", func() {
+			mcpServer.Spec.Address = arkv1alpha1.ValueSource{Value: "http://github-mcp:8080/mcp"}
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(ContainSubstring("address is not used by the stdio transport")))
+		})
+
+		It("Should deny environment variables referencing query parameters", func() {
+			mcpServer.Spec.Command.Env[0].ValueFrom = &arkv1alpha1.ValueFromSource{
+				QueryParameterRef: &arkv1alpha1.QueryParameterReference{Name: "token"},
+			}
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(ContainSubstring("command.env[0]: queryParameterRef is not supported")))
+		})
+
+		It("Should deny a command for other transports", func() {
+			mcpServer.Spec.Transport = "http"
+			mcpServer.Spec.Address = arkv1alpha1.ValueSource{Value: "http://github-mcp:8080/mcp"}
+			_, err := validator.ValidateCreate(ctx, mcpServer)
+			Expect(err).To(MatchError(ContainSubstring("command is only used by the stdio transport")))
+		})
+	})
+})
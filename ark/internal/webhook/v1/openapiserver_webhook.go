@@ -0,0 +1,103 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"path"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var openapiserverlog = logf.Log.WithName("openapiserver-resource")
+
+func SetupOpenAPIServerWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&arkv1alpha1.OpenAPIServer{}).
+		WithValidator(&OpenAPIServerValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-ark-mckinsey-com-v1alpha1-openapiserver,mutating=false,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=openapiservers,verbs=create;update,versions=v1alpha1,name=vopenapiserver-v1.kb.io,admissionReviewVersions=v1
+
+type OpenAPIServerValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &OpenAPIServerValidator{}
+
+func (v *OpenAPIServerValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	server, ok := obj.(*arkv1alpha1.OpenAPIServer)
+	if !ok {
+		return nil, fmt.Errorf("expected an OpenAPIServer object but got %T", obj)
+	}
+
+	openapiserverlog.Info("Validating OpenAPIServer", "name", server.GetName(), "namespace", server.GetNamespace())
+
+	if err := validateOpenAPIDocumentSource(server.Spec.Document); err != nil {
+		return nil, err
+	}
+
+	for i, header := range server.Spec.Headers {
+		if err := ValidateHeader(header, fmt.Sprintf("headers[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	if server.Spec.PollInterval != nil {
+		if err := ValidatePollInterval(server.Spec.PollInterval.Duration); err != nil {
+			return nil, fmt.Errorf("failed to validate pollInterval: %w", err)
+		}
+	}
+
+	if filter := server.Spec.Operations; filter != nil {
+		for _, pattern := range append(append([]string{}, filter.Include...), filter.Exclude...) {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("operations: invalid pattern '%s': %v", pattern, err)
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+// validateOpenAPIDocumentSource requires exactly one document source. Inline documents are parsed up front.
+func validateOpenAPIDocumentSource(source arkv1alpha1.OpenAPIDocumentSource) error {
+	count := 0
+	if source.URL != "" {
+		count++
+	}
+	if source.ConfigMapKeyRef != nil {
+		count++
+	}
+	if source.Inline != "" {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("document: exactly one of url, configMapKeyRef or inline must be specified")
+	}
+
+	if source.Inline != "" {
+		if _, err := genai.ParseOpenAPIDocument([]byte(source.Inline)); err != nil {
+			return fmt.Errorf("document.inline: %v", err)
+		}
+	}
+	return nil
+}
+
+func (v *OpenAPIServerValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *OpenAPIServerValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
@@ -4,8 +4,10 @@ package v1
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 
+	"github.com/itchyny/gojq"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -13,6 +15,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/common"
 	"mckinsey.com/ark/internal/genai"
 )
 
@@ -20,6 +23,7 @@ const (
 	MemberTypeAgent  = "agent"
 	MemberTypeTeam   = "team"
 	StrategySelector = "selector"
+	StrategyVote     = "vote"
 )
 
 func SetupTeamWebhookWithManager(mgr ctrl.Manager) error {
@@ -95,15 +99,131 @@ func (v *TeamCustomValidator) validateTeamMembers(ctx context.Context, team *ark
 		default:
 			return warnings, fmt.Errorf("team member %d has invalid type '%s': must be '%s' or '%s'", i, member.Type, MemberTypeAgent, MemberTypeTeam)
 		}
+
+		if err := v.validateHistoryPolicy(ctx, team, member); err != nil {
+			return warnings, fmt.Errorf("team member %d: %v", i, err)
+		}
+
+		// Model overrides are checked at runtime via the team status conditions
+		if member.ModelRef != nil && member.Type != MemberTypeAgent {
+			return warnings, fmt.Errorf("team member %d: modelRef is only supported for agent members", i)
+		}
+	}
+
+	if err := v.validateInstructions(ctx, team); err != nil {
+		return warnings, err
+	}
+
+	if err := v.validateState(team); err != nil {
+		return warnings, err
 	}
 
 	if err := v.validateNoMixedTeam(ctx, team); err != nil {
 		return warnings, err
 	}
 
+	if err := v.ValidateNoDependencyCycle(ctx, team.Namespace, dependencyNode{Kind: dependencyKindTeam, Name: team.Name}, teamDependencies(team)); err != nil {
+		return warnings, err
+	}
+
 	return warnings, nil
 }
 
+func (v *TeamCustomValidator) validateInstructions(ctx context.Context, team *arkv1alpha1.Team) error {
+	if err := v.ValidateParameters(ctx, team.Namespace, team.Spec.Parameters); err != nil {
+		return err
+	}
+
+	if team.Spec.Instructions == "" {
+		return nil
+	}
+
+	if err := common.ValidateTemplate(team.Spec.Instructions); err != nil {
+		return fmt.Errorf("invalid instructions template: %v", err)
+	}
+
+	return nil
+}
+
+func (v *TeamCustomValidator) validateState(team *arkv1alpha1.Team) error {
+	if state := team.Spec.State; state != nil && state.Initial != nil && len(state.Initial.Raw) > 0 {
+		var initial map[string]any
+		if err := json.Unmarshal(state.Initial.Raw, &initial); err != nil {
+			return fmt.Errorf("state.initial must be a JSON object: %v", err)
+		}
+	}
+
+	var conditions []string
+	if team.Spec.TerminateWhen != "" {
+		conditions = append(conditions, team.Spec.TerminateWhen)
+	}
+	if team.Spec.Graph != nil {
+		for _, edge := range team.Spec.Graph.Edges {
+			if edge.Condition != "" {
+				conditions = append(conditions, edge.Condition)
+			}
+		}
+	}
+
+	if len(conditions) > 0 && team.Spec.State == nil {
+		return fmt.Errorf("terminateWhen and graph edge conditions require state to be configured")
+	}
+
+	for _, condition := range conditions {
+		if _, err := gojq.Parse(condition); err != nil {
+			return fmt.Errorf("invalid condition '%s': %v", condition, err)
+		}
+	}
+
+	return nil
+}
+
+func (v *TeamCustomValidator) validateHistoryPolicy(ctx context.Context, team *arkv1alpha1.Team, member arkv1alpha1.TeamMember) error {
+	policy := member.HistoryPolicy
+	if policy == nil {
+		return nil
+	}
+
+	switch policy.Type {
+	case "", arkv1alpha1.HistoryPolicyFull, arkv1alpha1.HistoryPolicyUserInput:
+		return nil
+	case arkv1alpha1.HistoryPolicyLastN:
+		if policy.LastN == nil || *policy.LastN < 1 {
+			return fmt.Errorf("history policy lastN requires lastN to be at least 1")
+		}
+		return nil
+	case arkv1alpha1.HistoryPolicyMembers:
+		if len(policy.Members) == 0 {
+			return fmt.Errorf("history policy members requires at least one member")
+		}
+		memberTypes := make(map[string]string)
+		for _, m := range team.Spec.Members {
+			memberTypes[m.Name] = m.Type
+		}
+		for _, name := range policy.Members {
+			memberType, ok := memberTypes[name]
+			if !ok {
+				return fmt.Errorf("history policy member '%s' not found in team members", name)
+			}
+			// Only the answers of agents carry the name of their member
+			if memberType != MemberTypeAgent {
+				return fmt.Errorf("history policy member '%s' must be an agent, not a %s", name, memberType)
+			}
+		}
+		return nil
+	case arkv1alpha1.HistoryPolicySummary:
+		if policy.Summarizer == "" {
+			return fmt.Errorf("history policy summary requires summarizer to be specified")
+		}
+		if err := v.ValidateLoadAgent(ctx, policy.Summarizer, team.Namespace); err != nil {
+			return fmt.Errorf("summarizer agent '%s' not found in namespace %s: %v", policy.Summarizer, team.Namespace, err)
+		}
+		return nil
+	default:
+		return fmt.Errorf("unsupported history policy '%s'", policy.Type)
+	}
+}
+
 func (v *TeamCustomValidator) validateNoMixedTeam(ctx context.Context, team *arkv1alpha1.Team) error {
 	var hasInternalAgents, hasExternalAgents bool
 
@@ -145,11 +265,29 @@ func (v *TeamCustomValidator) validateStrategy(ctx context.Context, team *arkv1a
 		return nil
 	case "graph":
 		return v.validateGraphStrategy(team)
+	case StrategyVote:
+		return v.validateVoteStrategy(ctx, team)
 	default:
-		return fmt.Errorf("unsupported strategy '%s': must be 'sequential', 'round-robin', 'selector', or 'graph'", team.Spec.Strategy)
+		return fmt.Errorf("unsupported strategy '%s': must be 'sequential', 'round-robin', 'selector', 'graph', or 'vote'", team.Spec.Strategy)
 	}
 }
 
+func (v *TeamCustomValidator) validateVoteStrategy(ctx context.Context, team *arkv1alpha1.Team) error {
+	if len(team.Spec.Members) < 2 {
+		return fmt.Errorf("vote strategy requires at least two members")
+	}
+
+	if team.Spec.Vote == nil || team.Spec.Vote.Judge == "" {
+		return nil
+	}
+
+	if err := v.ValidateLoadAgent(ctx, team.Spec.Vote.Judge, team.Namespace); err != nil {
+		return fmt.Errorf("judge agent '%s' not found in namespace %s: %v", team.Spec.Vote.Judge, team.Namespace, err)
+	}
+
+	return nil
+}
+
 func (v *TeamCustomValidator) validateSelectorAgent(ctx context.Context, team *arkv1alpha1.Team) error {
 	if team.Spec.Selector == nil || team.Spec.Selector.Agent == "" {
 		return fmt.Errorf("selector strategy requires selector.agent to be specified")
@@ -187,10 +325,11 @@ func (v *TeamCustomValidator) validateGraphStrategy(team *arkv1alpha1.Team) erro
 		if !memberNames[edge.To] {
 			return fmt.Errorf("graph edge %d: 'to' member '%s' not found in team members", i, edge.To)
 		}
-		if _, exists := transitionMap[edge.From]; exists {
-			return fmt.Errorf("member '%s' has more than one outgoing edge", edge.From)
+		// The first edge whose condition holds is followed, so an unconditional edge must come last
+		if transitionMap[edge.From] {
+			return fmt.Errorf("member '%s' has more than one outgoing edge; only the last outgoing edge may omit a condition", edge.From)
 		}
-		transitionMap[edge.From] = true
+		transitionMap[edge.From] = edge.Condition == ""
 	}
 
 	if team.Spec.MaxTurns == nil {
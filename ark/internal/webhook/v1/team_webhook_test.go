@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -201,4 +202,262 @@ var _ = Describe("Team Webhook", func() {
 			Expect(err.Error()).To(ContainSubstring("more than one outgoing edge"))
 		})
 	})
+
+	Context("Vote strategy validation", func() {
+		It("Should allow a vote team with a judge agent", func() {
+			obj.Spec.Strategy = StrategyVote
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+			obj.Spec.Vote = &arkv1alpha1.TeamVoteSpec{Judge: "coordinator", DebateRounds: 1}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should allow majority vote without vote configuration", func() {
+			obj.Spec.Strategy = StrategyVote
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should reject a vote team with a single member", func() {
+			obj.Spec.Strategy = StrategyVote
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("at least two members"))
+		})
+
+		It("Should reject a missing judge agent", func() {
+			obj.Spec.Strategy = StrategyVote
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+			}
+			obj.Spec.Vote = &arkv1alpha1.TeamVoteSpec{Judge: "nonexistent"}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("judge agent 'nonexistent' not found"))
+		})
+	})
+
+	Context("Team instructions and member overrides", func() {
+		It("Should allow instructions and a model override on agent members", func() {
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Instructions = "You are {{.Member}} in team {{ toJson .Team }}."
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent", ModelRef: &arkv1alpha1.AgentModelRef{Name: "cheap-model"}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should reject invalid instruction templates", func() {
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Instructions = "You are {{.Member"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid instructions template"))
+		})
+	})
+
+	Context("Team state", func() {
+		BeforeEach(func() {
+			obj.Spec.Strategy = "graph"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "analyst", Type: "agent"},
+				{Name: "writer", Type: "agent"},
+			}
+			maxTurns := 10
+			obj.Spec.MaxTurns = &maxTurns
+		})
+
+		It("Should allow conditional edges with a fallback edge", func() {
+			obj.Spec.State = &arkv1alpha1.TeamStateSpec{Initial: &runtime.RawExtension{Raw: []byte(`{"approved": false}`)}}
+			obj.Spec.TerminateWhen = ".approved == true"
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer", Condition: ".approved"},
+					{From: "researcher", To: "analyst"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should reject an unconditional edge before other outgoing edges", func() {
+			obj.Spec.State = &arkv1alpha1.TeamStateSpec{}
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "analyst"},
+					{From: "researcher", To: "writer", Condition: ".approved"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("more than one outgoing edge"))
+		})
+
+		It("Should reject conditions without state", func() {
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer", Condition: ".approved"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("require state to be configured"))
+		})
+
+		It("Should reject invalid conditions", func() {
+			obj.Spec.State = &arkv1alpha1.TeamStateSpec{}
+			obj.Spec.TerminateWhen = ".approved =="
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid condition"))
+		})
+
+		It("Should reject an initial state that is not an object", func() {
+			obj.Spec.State = &arkv1alpha1.TeamStateSpec{Initial: &runtime.RawExtension{Raw: []byte(`[1, 2]`)}}
+			obj.Spec.Graph = &arkv1alpha1.TeamGraphSpec{
+				Edges: []arkv1alpha1.TeamGraphEdge{
+					{From: "researcher", To: "writer"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("state.initial must be a JSON object"))
+		})
+	})
+
+	Context("History policies", func() {
+		BeforeEach(func() {
+			reviewers := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "reviewers", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy: "sequential",
+					Members:  []arkv1alpha1.TeamMember{{Name: "analyst", Type: "agent"}},
+				},
+			}
+			Expect(validator.Client.Create(ctx, reviewers)).To(Succeed())
+			obj.Spec.Strategy = "sequential"
+		})
+
+		It("Should allow members policies that list agent members", func() {
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "writer", Type: "agent", HistoryPolicy: &arkv1alpha1.TeamMemberHistoryPolicy{
+					Type:    arkv1alpha1.HistoryPolicyMembers,
+					Members: []string{"researcher"},
+				}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should reject members policies that list team members", func() {
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "reviewers", Type: "team"},
+				{Name: "writer", Type: "agent", HistoryPolicy: &arkv1alpha1.TeamMemberHistoryPolicy{
+					Type:    arkv1alpha1.HistoryPolicyMembers,
+					Members: []string{"reviewers"},
+				}},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("history policy member 'reviewers' must be an agent, not a team"))
+		})
+	})
+
+	Context("Dependency cycles", func() {
+		It("Should reject a nested team that contains this team", func() {
+			inner := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "inner", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy: "sequential",
+					Members:  []arkv1alpha1.TeamMember{{Name: "test-team", Type: "team"}},
+				},
+			}
+			Expect(validator.Client.Create(ctx, inner)).To(Succeed())
+
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{{Name: "inner", Type: "team"}}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dependency cycle detected: Team/test-team -> Team/inner -> Team/test-team"))
+		})
+
+		It("Should reject a member agent that calls this team through a tool", func() {
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-team", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: "team",
+					Team: &arkv1alpha1.TeamToolRef{Name: "test-team"},
+				},
+			}
+			Expect(validator.Client.Create(ctx, tool)).To(Succeed())
+
+			researcher := &arkv1alpha1.Agent{}
+			Expect(validator.Client.Get(ctx, types.NamespacedName{Name: "researcher", Namespace: "default"}, researcher)).To(Succeed())
+			researcher.Spec.Tools = []arkv1alpha1.AgentTool{{Type: "custom", Name: "ask-team"}}
+			Expect(validator.Client.Update(ctx, researcher)).To(Succeed())
+
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{{Name: "researcher", Type: "agent"}}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("Team/test-team -> Agent/researcher -> Tool/ask-team -> Team/test-team"))
+		})
+
+		It("Should allow shared members without a cycle", func() {
+			inner := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "inner", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy: "sequential",
+					Members:  []arkv1alpha1.TeamMember{{Name: "researcher", Type: "agent"}},
+				},
+			}
+			Expect(validator.Client.Create(ctx, inner)).To(Succeed())
+
+			obj.Spec.Strategy = "sequential"
+			obj.Spec.Members = []arkv1alpha1.TeamMember{
+				{Name: "researcher", Type: "agent"},
+				{Name: "inner", Type: "team"},
+			}
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+	})
 })
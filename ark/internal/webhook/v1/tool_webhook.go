@@ -7,9 +7,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/google/jsonschema-go/jsonschema"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
@@ -25,13 +28,25 @@ var log = logf.Log
 // SetupToolWebhookWithManager registers the webhook for Tool in the manager.
 func SetupToolWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr).For(&arkv1alpha1.Tool{}).
-		WithValidator(&ToolCustomValidator{}).
+		WithValidator(&ToolCustomValidator{
+			ResourceValidator: &ResourceValidator{Client: mgr.GetClient()},
+			Impersonation:     QueryImpersonation,
+		}).
 		Complete()
 }
 
 // +kubebuilder:webhook:path=/validate-ark-mckinsey-com-v1alpha1-tool,mutating=false,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=tools,verbs=create;update,versions=v1alpha1,name=vtool-v1.kb.io,admissionReviewVersions=v1
 
-type ToolCustomValidator struct{}
+// QueryImpersonation reports whether queries run with their service account. It is set from the controller flags
+// before the webhooks are set up.
+var QueryImpersonation = true
+
+type ToolCustomValidator struct {
+	*ResourceValidator
+	// Impersonation allows Kubernetes tools to read namespaces other than their own, as reads are then subject to
+	// the RBAC of the query's service account
+	Impersonation bool
+}
 
 var _ webhook.CustomValidator = &ToolCustomValidator{}
 
@@ -62,7 +77,20 @@ func (v *ToolCustomValidator) ValidateDelete(ctx context.Context, obj runtime.Ob
 	return nil, nil
 }
 
-func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.Tool) (admission.Warnings, error) {
+func (v *ToolCustomValidator) validateTool(ctx context.Context, tool *arkv1alpha1.Tool) (admission.Warnings, error) {
+	warnings, err := v.validateToolSpec(tool)
+	if err != nil {
+		return warnings, err
+	}
+
+	if err := v.ValidateNoDependencyCycle(ctx, tool.Namespace, dependencyNode{Kind: dependencyKindTool, Name: tool.Name}, toolDependencies(tool)); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
+func (v *ToolCustomValidator) validateToolSpec(tool *arkv1alpha1.Tool) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
 	// Validate inputSchema if present
@@ -72,6 +100,10 @@ func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.
 		}
 	}
 
+	if err := v.validateCache(tool); err != nil {
+		return warnings, err
+	}
+
 	switch tool.Spec.Type {
 	case genai.ToolTypeHTTP:
 		return v.validateHTTP(tool.Spec.HTTP)
@@ -82,10 +114,29 @@ func (v *ToolCustomValidator) validateTool(_ context.Context, tool *arkv1alpha1.
 	case genai.ToolTypeTeam:
 		return v.validateTeamTool(tool.Spec.Team.Name)
 	case genai.ToolTypeBuiltin:
-		return v.validateBuiltinTool(tool.Name)
+		return v.validateBuiltinTool(tool)
+	case genai.ToolTypeGraphQL:
+		return v.validateGraphQL(tool.Spec.GraphQL)
+	case genai.ToolTypeWasm:
+		return v.validateWasm(tool)
 	default:
-		return warnings, fmt.Errorf("unsupported tool type '%s': supported types are: http, mcp, agent, team, builtin", tool.Spec.Type)
+		return warnings, fmt.Errorf("unsupported tool type '%s': supported types are: http, mcp, agent, team, builtin, graphql, wasm", tool.Spec.Type)
+	}
+}
+
+// validateCache only allows caching results of tools that do not change their environment
+func (v *ToolCustomValidator) validateCache(tool *arkv1alpha1.Tool) error {
+	if tool.Spec.Cache == nil {
+		return nil
+	}
+	if tool.Spec.Cache.TTL.Duration <= 0 {
+		return fmt.Errorf("cache.ttl must be greater than zero")
 	}
+	annotations := tool.Spec.Annotations
+	if annotations == nil || (!annotations.ReadOnlyHint && !annotations.IdempotentHint) {
+		return fmt.Errorf("cache requires annotations.readOnlyHint or annotations.idempotentHint")
+	}
+	return nil
 }
 
 // validateHTTP validates HTTP-specific configuration
@@ -114,6 +165,103 @@ func (v *ToolCustomValidator) validateHTTP(httpSpec *arkv1alpha1.HTTPSpec) (admi
 		}
 	}
 
+	if err := ValidateAuth(httpSpec.Auth, "http.auth"); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
+// validateGraphQL validates GraphQL-specific configuration
+func (v *ToolCustomValidator) validateGraphQL(graphQLSpec *arkv1alpha1.GraphQLSpec) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	if graphQLSpec == nil {
+		return warnings, fmt.Errorf("graphql spec is required for graphql type")
+	}
+
+	if _, err := url.Parse(graphQLSpec.Endpoint); err != nil {
+		return warnings, fmt.Errorf("invalid graphql endpoint: %v", err)
+	}
+
+	definitions, err := genai.ParseGraphQLVariableDefinitions(graphQLSpec.Query, graphQLSpec.OperationName)
+	if err != nil {
+		return warnings, fmt.Errorf("invalid graphql query: %v", err)
+	}
+
+	defined := map[string]genai.GraphQLVariableDefinition{}
+	for _, definition := range definitions {
+		defined[definition.Name] = definition
+	}
+	mapped := map[string]bool{}
+	for i, variable := range graphQLSpec.Variables {
+		if _, exists := defined[variable.Name]; !exists {
+			return warnings, fmt.Errorf("graphql.variables[%d]: variable '%s' is not defined by the query", i, variable.Name)
+		}
+		if mapped[variable.Name] {
+			return warnings, fmt.Errorf("graphql.variables[%d]: variable '%s' is mapped more than once", i, variable.Name)
+		}
+		mapped[variable.Name] = true
+	}
+	if len(graphQLSpec.Variables) > 0 {
+		for _, definition := range definitions {
+			if !mapped[definition.Name] && definition.Type.NonNull && !definition.HasDefault {
+				warnings = append(warnings, fmt.Sprintf("required graphql variable '%s' is not mapped to a tool argument", definition.Name))
+			}
+		}
+	}
+
+	if err := ValidateAuth(graphQLSpec.Auth, "graphql.auth"); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
+
+// validateWasm validates WebAssembly-specific configuration
+func (v *ToolCustomValidator) validateWasm(tool *arkv1alpha1.Tool) (admission.Warnings, error) {
+	var warnings admission.Warnings
+
+	wasmSpec := tool.Spec.Wasm
+	if wasmSpec == nil {
+		return warnings, fmt.Errorf("wasm spec is required for wasm type")
+	}
+
+	module := wasmSpec.Module
+	sources := 0
+	if module.ConfigMapKeyRef != nil {
+		sources++
+		if module.ConfigMapKeyRef.Name == "" || module.ConfigMapKeyRef.Key == "" {
+			return warnings, fmt.Errorf("wasm.module.configMapKeyRef requires name and key")
+		}
+	}
+	if module.URL != "" {
+		sources++
+		if _, err := url.Parse(module.URL); err != nil {
+			return warnings, fmt.Errorf("invalid wasm.module.url: %v", err)
+		}
+	}
+	if module.Image != "" {
+		sources++
+	}
+	if sources != 1 {
+		return warnings, fmt.Errorf("wasm.module requires exactly one of configMapKeyRef, url or image")
+	}
+	if module.ImagePullSecret != nil && module.Image == "" {
+		return warnings, fmt.Errorf("wasm.module.imagePullSecret can only be used with wasm.module.image")
+	}
+	if module.ConfigMapKeyRef == nil && module.SHA256 == "" {
+		warnings = append(warnings, "wasm.module.sha256 is not set, the downloaded module is not verified")
+	}
+
+	if wasmSpec.MaxMemory != nil && (wasmSpec.MaxMemory.Value() <= 0 || wasmSpec.MaxMemory.Value() > 4<<30) {
+		return warnings, fmt.Errorf("wasm.maxMemory must be greater than zero and at most 4Gi")
+	}
+
+	if tool.Spec.InputSchema == nil {
+		warnings = append(warnings, "wasm tool has no inputSchema, the model is not told which arguments to pass")
+	}
+
 	return warnings, nil
 }
 
@@ -157,10 +305,18 @@ func (v *ToolCustomValidator) validateTeamTool(team string) (admission.Warnings,
 }
 
 // validateBuiltinTool validates Builtin-specific configuration
-func (v *ToolCustomValidator) validateBuiltinTool(toolName string) (admission.Warnings, error) {
+func (v *ToolCustomValidator) validateBuiltinTool(tool *arkv1alpha1.Tool) (admission.Warnings, error) {
 	var warnings admission.Warnings
 
-	supportedBuiltinTools := []string{genai.BuiltinToolNoop, genai.BuiltinToolTerminate}
+	toolName := genai.BuiltinToolName(tool)
+	if genai.IsKubernetesBuiltinTool(toolName) {
+		return warnings, v.validateKubernetesAccess(toolName, tool.Namespace, tool.Spec.Builtin)
+	}
+
+	supportedBuiltinTools := []string{
+		genai.BuiltinToolNoop, genai.BuiltinToolTerminate,
+		genai.BuiltinToolK8sGet, genai.BuiltinToolK8sList, genai.BuiltinToolK8sDescribeEvents, genai.BuiltinToolK8sLogs,
+	}
 	for _, supportedTool := range supportedBuiltinTools {
 		if toolName == supportedTool {
 			return warnings, nil
@@ -170,6 +326,47 @@ func (v *ToolCustomValidator) validateBuiltinTool(toolName string) (admission.Wa
 	return warnings, fmt.Errorf("unsupported builtin tool '%s': supported builtin tools are: %v", toolName, supportedBuiltinTools)
 }
 
+// validateKubernetesAccess validates the allow-list of the Kubernetes built-in tools
+func (v *ToolCustomValidator) validateKubernetesAccess(toolName, namespace string, builtin *arkv1alpha1.BuiltinToolRef) error {
+	if builtin == nil || builtin.Kubernetes == nil {
+		return fmt.Errorf("builtin.kubernetes is required for builtin tool '%s'", toolName)
+	}
+	access := builtin.Kubernetes
+	if len(access.Resources) == 0 {
+		return fmt.Errorf("builtin.kubernetes.resources must list at least one resource kind")
+	}
+
+	kinds := map[string]bool{}
+	for i, resource := range access.Resources {
+		if resource.Kind == "" {
+			return fmt.Errorf("builtin.kubernetes.resources[%d]: kind is required", i)
+		}
+		if _, err := schema.ParseGroupVersion(resource.APIVersion); err != nil {
+			return fmt.Errorf("builtin.kubernetes.resources[%d]: invalid apiVersion '%s': %v", i, resource.APIVersion, err)
+		}
+		// The model selects resources by kind only
+		kind := strings.ToLower(resource.Kind)
+		if kinds[kind] {
+			return fmt.Errorf("builtin.kubernetes.resources[%d]: kind '%s' is listed more than once", i, resource.Kind)
+		}
+		kinds[kind] = true
+	}
+	if toolName == genai.BuiltinToolK8sLogs && !kinds["pod"] {
+		return fmt.Errorf("builtin.kubernetes.resources must include Pod for builtin tool '%s'", toolName)
+	}
+
+	for i, allowed := range access.Namespaces {
+		if errs := validation.IsDNS1123Label(allowed); len(errs) > 0 {
+			return fmt.Errorf("builtin.kubernetes.namespaces[%d]: invalid namespace '%s': %s", i, allowed, strings.Join(errs, ", "))
+		}
+		if allowed != namespace && !v.Impersonation {
+			return fmt.Errorf("builtin.kubernetes.namespaces[%d]: namespace '%s' is not the namespace of the tool, "+
+				"which requires the controller to impersonate the service account of queries", i, allowed)
+		}
+	}
+	return nil
+}
+
 // validateInputSchema validates the tool's inputSchema using jsonschema
 func (v *ToolCustomValidator) validateInputSchema(inputSchema json.RawMessage) error {
 	// Parse the JSON schema
@@ -4,11 +4,14 @@ package v1
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
 	"mckinsey.com/ark/internal/genai"
@@ -22,11 +25,14 @@ var _ = Describe("Tool Webhook", func() {
 
 	BeforeEach(func() {
 		ctx = context.Background()
-		validator = &ToolCustomValidator{}
 
 		// Setup scheme
 		s := runtime.NewScheme()
 		Expect(arkv1alpha1.AddToScheme(s)).To(Succeed())
+
+		validator = &ToolCustomValidator{
+			ResourceValidator: &ResourceValidator{Client: fake.NewClientBuilder().WithScheme(s).Build()},
+		}
 	})
 
 	Context("When validating team tool", func() {
@@ -69,4 +75,256 @@ var _ = Describe("Tool Webhook", func() {
 			Expect(warnings).To(BeEmpty())
 		})
 	})
+
+	Context("When validating http auth", func() {
+		newHTTPTool := func(auth *arkv1alpha1.Auth) *arkv1alpha1.Tool {
+			return &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "http-tool", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: genai.ToolTypeHTTP,
+					HTTP: &arkv1alpha1.HTTPSpec{URL: "https://api.example.com", Auth: auth},
+				},
+			}
+		}
+
+		It("Should accept oauth2 client credentials", func() {
+			tool := newHTTPTool(&arkv1alpha1.Auth{OAuth2: &arkv1alpha1.OAuth2ClientCredentials{
+				TokenURL: "https://auth.example.com/token",
+				ClientID: arkv1alpha1.ValueSource{Value: "client"},
+				ClientSecret: arkv1alpha1.ValueSource{ValueFrom: &arkv1alpha1.ValueFromSource{
+					SecretKeyRef: &corev1.SecretKeySelector{LocalObjectReference: corev1.LocalObjectReference{Name: "oauth"}, Key: "secret"},
+				}},
+			}})
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject auth without oauth2", func() {
+			_, err := validator.ValidateCreate(ctx, newHTTPTool(&arkv1alpha1.Auth{}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("http.auth: oauth2 is required"))
+		})
+
+		It("Should reject a missing client secret", func() {
+			tool := newHTTPTool(&arkv1alpha1.Auth{OAuth2: &arkv1alpha1.OAuth2ClientCredentials{
+				TokenURL: "https://auth.example.com/token",
+				ClientID: arkv1alpha1.ValueSource{Value: "client"},
+			}})
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("http.auth.oauth2.clientSecret: must specify either value or valueFrom"))
+		})
+	})
+
+	Context("When validating graphql tools", func() {
+		newGraphQLTool := func(query string, variables ...arkv1alpha1.GraphQLVariable) *arkv1alpha1.Tool {
+			return &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "graphql-tool", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: genai.ToolTypeGraphQL,
+					GraphQL: &arkv1alpha1.GraphQLSpec{
+						Endpoint:  "https://api.example.com/graphql",
+						Query:     query,
+						Variables: variables,
+					},
+				},
+			}
+		}
+
+		It("Should accept a query with mapped variables", func() {
+			tool := newGraphQLTool(`query Repo($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { stars } }`,
+				arkv1alpha1.GraphQLVariable{Name: "owner", Argument: "organization"},
+				arkv1alpha1.GraphQLVariable{Name: "name", Argument: "repository"})
+
+			warnings, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should reject an invalid query", func() {
+			_, err := validator.ValidateCreate(ctx, newGraphQLTool(`query Repo($owner String!) { repository { stars } }`))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid graphql query"))
+		})
+
+		It("Should reject mappings of undefined variables", func() {
+			tool := newGraphQLTool(`query Repo($owner: String!) { repository(owner: $owner) { stars } }`,
+				arkv1alpha1.GraphQLVariable{Name: "repo", Argument: "repository"})
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("graphql.variables[0]: variable 'repo' is not defined by the query"))
+		})
+
+		It("Should warn about required variables that are not mapped", func() {
+			tool := newGraphQLTool(`query Repo($owner: String!, $name: String!) { repository(owner: $owner, name: $name) { stars } }`,
+				arkv1alpha1.GraphQLVariable{Name: "owner", Argument: "owner"})
+
+			warnings, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("required graphql variable 'name'")))
+		})
+	})
+
+	Context("When validating kubernetes builtin tools", func() {
+		newKubernetesTool := func(name string, access *arkv1alpha1.KubernetesToolAccess) *arkv1alpha1.Tool {
+			return &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "cluster-" + name, Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:    genai.ToolTypeBuiltin,
+					Builtin: &arkv1alpha1.BuiltinToolRef{Name: name, Kubernetes: access},
+				},
+			}
+		}
+
+		It("Should accept allow-listed kinds and namespaces", func() {
+			validator.Impersonation = true
+			tool := newKubernetesTool(genai.BuiltinToolK8sGet, &arkv1alpha1.KubernetesToolAccess{
+				Resources:  []arkv1alpha1.KubernetesResourceKind{{APIVersion: "v1", Kind: "Pod"}, {APIVersion: "apps/v1", Kind: "Deployment"}},
+				Namespaces: []string{"apps"},
+			})
+
+			warnings, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should only accept the namespace of the tool without impersonation", func() {
+			tool := newKubernetesTool(genai.BuiltinToolK8sGet, &arkv1alpha1.KubernetesToolAccess{
+				Resources:  []arkv1alpha1.KubernetesResourceKind{{APIVersion: "v1", Kind: "Pod"}},
+				Namespaces: []string{"default"},
+			})
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+
+			tool.Spec.Builtin.Kubernetes.Namespaces = []string{"default", "kube-system"}
+			_, err = validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("builtin.kubernetes.namespaces[1]: namespace 'kube-system' is not the namespace of the tool"))
+		})
+
+		It("Should reject tools without an allow-list", func() {
+			_, err := validator.ValidateCreate(ctx, newKubernetesTool(genai.BuiltinToolK8sList, nil))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("builtin.kubernetes is required for builtin tool 'k8s_list'"))
+		})
+
+		It("Should reject logs tools that do not allow pods", func() {
+			tool := newKubernetesTool(genai.BuiltinToolK8sLogs, &arkv1alpha1.KubernetesToolAccess{
+				Resources: []arkv1alpha1.KubernetesResourceKind{{APIVersion: "apps/v1", Kind: "Deployment"}},
+			})
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("builtin.kubernetes.resources must include Pod"))
+		})
+
+		It("Should reject invalid namespaces", func() {
+			tool := newKubernetesTool(genai.BuiltinToolK8sGet, &arkv1alpha1.KubernetesToolAccess{
+				Resources:  []arkv1alpha1.KubernetesResourceKind{{APIVersion: "v1", Kind: "Pod"}},
+				Namespaces: []string{"Apps"},
+			})
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("builtin.kubernetes.namespaces[0]: invalid namespace 'Apps'"))
+		})
+	})
+
+	Context("When validating wasm tools", func() {
+		newWasmTool := func(module arkv1alpha1.WasmModuleSource) *arkv1alpha1.Tool {
+			return &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "calculator", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:        genai.ToolTypeWasm,
+					InputSchema: &runtime.RawExtension{Raw: []byte(`{"type":"object","properties":{"expression":{"type":"string"}}}`)},
+					Wasm:        &arkv1alpha1.WasmSpec{Module: module},
+				},
+			}
+		}
+
+		It("Should accept a module from a configmap", func() {
+			tool := newWasmTool(arkv1alpha1.WasmModuleSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: "modules"},
+				Key:                  "calculator.wasm",
+			}})
+
+			warnings, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should reject modules with several sources", func() {
+			tool := newWasmTool(arkv1alpha1.WasmModuleSource{URL: "https://example.com/calculator.wasm", Image: "ghcr.io/acme/calculator:v1"})
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("wasm.module requires exactly one of configMapKeyRef, url or image"))
+		})
+
+		It("Should warn about downloaded modules without a digest", func() {
+			warnings, err := validator.ValidateCreate(ctx, newWasmTool(arkv1alpha1.WasmModuleSource{Image: "ghcr.io/acme/calculator:v1"}))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ContainElement(ContainSubstring("wasm.module.sha256 is not set")))
+		})
+	})
+
+	Context("When validating cache", func() {
+		newCachedTool := func(annotations *arkv1alpha1.ToolAnnotations) *arkv1alpha1.Tool {
+			return &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "lookup", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type:        genai.ToolTypeHTTP,
+					HTTP:        &arkv1alpha1.HTTPSpec{URL: "https://api.example.com/lookup"},
+					Annotations: annotations,
+					Cache:       &arkv1alpha1.ToolCacheSpec{TTL: metav1.Duration{Duration: time.Hour}},
+				},
+			}
+		}
+
+		It("Should accept caching of read-only tools", func() {
+			_, err := validator.ValidateCreate(ctx, newCachedTool(&arkv1alpha1.ToolAnnotations{ReadOnlyHint: true}))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("Should reject caching of tools without read-only or idempotent hints", func() {
+			_, err := validator.ValidateCreate(ctx, newCachedTool(&arkv1alpha1.ToolAnnotations{DestructiveHint: true}))
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("cache requires annotations.readOnlyHint or annotations.idempotentHint"))
+		})
+	})
+
+	Context("When validating dependency cycles", func() {
+		It("Should reject a team tool used by a member of the same team", func() {
+			team := &arkv1alpha1.Team{
+				ObjectMeta: metav1.ObjectMeta{Name: "support", Namespace: "default"},
+				Spec: arkv1alpha1.TeamSpec{
+					Strategy: "sequential",
+					Members:  []arkv1alpha1.TeamMember{{Name: "helper", Type: "agent"}},
+				},
+			}
+			agent := &arkv1alpha1.Agent{
+				ObjectMeta: metav1.ObjectMeta{Name: "helper", Namespace: "default"},
+				Spec: arkv1alpha1.AgentSpec{
+					Tools: []arkv1alpha1.AgentTool{{Type: "custom", Name: "ask-support"}},
+				},
+			}
+			Expect(validator.Client.Create(ctx, team)).To(Succeed())
+			Expect(validator.Client.Create(ctx, agent)).To(Succeed())
+
+			tool := &arkv1alpha1.Tool{
+				ObjectMeta: metav1.ObjectMeta{Name: "ask-support", Namespace: "default"},
+				Spec: arkv1alpha1.ToolSpec{
+					Type: genai.ToolTypeTeam,
+					Team: &arkv1alpha1.TeamToolRef{Name: "support"},
+				},
+			}
+
+			_, err := validator.ValidateCreate(ctx, tool)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("dependency cycle detected: Tool/ask-support -> Team/support -> Agent/helper -> Tool/ask-support"))
+		})
+	})
 })
@@ -0,0 +1,92 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+	"mckinsey.com/ark/internal/genai"
+)
+
+var toolpolicylog = logf.Log.WithName("toolpolicy-resource")
+
+func SetupToolPolicyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&arkv1alpha1.ToolPolicy{}).
+		WithValidator(&ToolPolicyValidator{Client: mgr.GetClient()}).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/validate-ark-mckinsey-com-v1alpha1-toolpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=ark.mckinsey.com,resources=toolpolicies,verbs=create;update,versions=v1alpha1,name=vtoolpolicy-v1.kb.io,admissionReviewVersions=v1
+
+type ToolPolicyValidator struct {
+	Client client.Client
+}
+
+var _ webhook.CustomValidator = &ToolPolicyValidator{}
+
+func (v *ToolPolicyValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	policy, ok := obj.(*arkv1alpha1.ToolPolicy)
+	if !ok {
+		return nil, fmt.Errorf("expected a ToolPolicy object but got %T", obj)
+	}
+
+	toolpolicylog.Info("Validating ToolPolicy", "name", policy.GetName(), "namespace", policy.GetNamespace())
+
+	if policy.Spec.ToolSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(policy.Spec.ToolSelector); err != nil {
+			return nil, fmt.Errorf("toolSelector: %v", err)
+		}
+	}
+
+	for i, rule := range policy.Spec.Rules {
+		if err := validateToolPolicyRule(rule, fmt.Sprintf("rules[%d]", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func validateToolPolicyRule(rule arkv1alpha1.ToolPolicyRule, fieldPath string) error {
+	for i, subject := range rule.Subjects {
+		subjectPath := fmt.Sprintf("%s.subjects[%d]", fieldPath, i)
+		if (subject.Name == "") == (subject.Selector == nil) {
+			return fmt.Errorf("%s: exactly one of name or selector must be specified", subjectPath)
+		}
+		if subject.Selector == nil {
+			continue
+		}
+		if subject.Kind != arkv1alpha1.ToolPolicySubjectAgent {
+			return fmt.Errorf("%s: selector is only supported for the %s kind", subjectPath, arkv1alpha1.ToolPolicySubjectAgent)
+		}
+		if _, err := metav1.LabelSelectorAsSelector(subject.Selector); err != nil {
+			return fmt.Errorf("%s.selector: %v", subjectPath, err)
+		}
+	}
+
+	if rule.Condition != "" {
+		if _, err := genai.CompileToolPolicyCondition(rule.Condition); err != nil {
+			return fmt.Errorf("%s.condition: %v", fieldPath, err)
+		}
+	}
+	return nil
+}
+
+func (v *ToolPolicyValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return v.ValidateCreate(ctx, newObj)
+}
+
+func (v *ToolPolicyValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
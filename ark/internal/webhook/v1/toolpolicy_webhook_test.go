@@ -0,0 +1,105 @@
+/* Copyright 2025. McKinsey & Company */
+
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	arkv1alpha1 "mckinsey.com/ark/api/v1alpha1"
+)
+
+var _ = Describe("ToolPolicy Webhook", func() {
+	var (
+		ctx       context.Context
+		policy    *arkv1alpha1.ToolPolicy
+		validator *ToolPolicyValidator
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		validator = &ToolPolicyValidator{}
+
+		policy = &arkv1alpha1.ToolPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-policy",
+				Namespace: "default",
+			},
+			Spec: arkv1alpha1.ToolPolicySpec{
+				ToolSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"risk": "high"}},
+				Rules: []arkv1alpha1.ToolPolicyRule{
+					{
+						Effect: arkv1alpha1.ToolPolicyEffectAllow,
+						Subjects: []arkv1alpha1.ToolPolicySubject{
+							{Kind: arkv1alpha1.ToolPolicySubjectAgent, Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "trusted"}}},
+							{Kind: arkv1alpha1.ToolPolicySubjectTeam, Name: "finance"},
+						},
+					},
+					{
+						Effect:    arkv1alpha1.ToolPolicyEffectDeny,
+						Condition: "args.amount > 1000",
+						Message:   "amounts above 1000 need approval",
+					},
+				},
+			},
+		}
+	})
+
+	Context("When validating tool policies", func() {
+		It("Should admit a valid policy", func() {
+			warnings, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeEmpty())
+		})
+
+		It("Should deny subjects with both name and selector", func() {
+			policy.Spec.Rules[0].Subjects[0].Name = "researcher"
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(MatchError(ContainSubstring("rules[0].subjects[0]: exactly one of name or selector must be specified")))
+		})
+
+		It("Should deny subjects without name or selector", func() {
+			policy.Spec.Rules[0].Subjects[1].Name = ""
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(MatchError(ContainSubstring("rules[0].subjects[1]: exactly one of name or selector must be specified")))
+		})
+
+		It("Should deny selectors for subjects other than agents", func() {
+			policy.Spec.Rules[0].Subjects[1] = arkv1alpha1.ToolPolicySubject{
+				Kind:     arkv1alpha1.ToolPolicySubjectServiceAccount,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "finance"}},
+			}
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(MatchError(ContainSubstring("selector is only supported for the Agent kind")))
+		})
+
+		It("Should deny invalid tool selectors", func() {
+			policy.Spec.ToolSelector = &metav1.LabelSelector{
+				MatchExpressions: []metav1.LabelSelectorRequirement{{Key: "risk", Operator: "Near"}},
+			}
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(MatchError(ContainSubstring("toolSelector")))
+		})
+
+		It("Should deny conditions that do not compile", func() {
+			policy.Spec.Rules[1].Condition = "args.amount >"
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(MatchError(ContainSubstring("rules[1].condition")))
+		})
+
+		It("Should deny conditions that do not evaluate to bool", func() {
+			policy.Spec.Rules[1].Condition = "args.amount"
+			_, err := validator.ValidateCreate(ctx, policy)
+			Expect(err).To(MatchError(ContainSubstring("must evaluate to bool")))
+		})
+
+		It("Should validate updates like creates", func() {
+			policy.Spec.Rules[1].Condition = "unknown"
+			_, err := validator.ValidateUpdate(ctx, policy, policy)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -5,6 +5,7 @@ package v1
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -274,6 +275,41 @@ func ValidatePollInterval(pollInterval time.Duration) error {
 	return nil
 }
 
+// ValidateAuth validates an auth block. A nil auth is valid.
+func ValidateAuth(auth *arkv1alpha1.Auth, contextPrefix string) error {
+	if auth == nil {
+		return nil
+	}
+	if auth.OAuth2 == nil {
+		return fmt.Errorf("%s: oauth2 is required", contextPrefix)
+	}
+
+	oauth2 := auth.OAuth2
+	if oauth2.TokenURL == "" {
+		return fmt.Errorf("%s.oauth2: tokenURL is required", contextPrefix)
+	}
+	if parsed, err := url.Parse(oauth2.TokenURL); err != nil || parsed.Host == "" {
+		return fmt.Errorf("%s.oauth2: invalid tokenURL '%s'", contextPrefix, oauth2.TokenURL)
+	}
+	if err := validateCredentialSource(oauth2.ClientID, contextPrefix+".oauth2.clientID"); err != nil {
+		return err
+	}
+	return validateCredentialSource(oauth2.ClientSecret, contextPrefix+".oauth2.clientSecret")
+}
+
+func validateCredentialSource(source arkv1alpha1.ValueSource, contextPrefix string) error {
+	if source.Value == "" && source.ValueFrom == nil {
+		return fmt.Errorf("%s: must specify either value or valueFrom", contextPrefix)
+	}
+	if source.Value != "" && source.ValueFrom != nil {
+		return fmt.Errorf("%s: cannot specify both value and valueFrom", contextPrefix)
+	}
+	if source.ValueFrom != nil && source.ValueFrom.SecretKeyRef == nil && source.ValueFrom.ConfigMapKeyRef == nil {
+		return fmt.Errorf("%s: valueFrom must specify secretKeyRef or configMapKeyRef", contextPrefix)
+	}
+	return nil
+}
+
 func ValidateHeader(header arkv1alpha1.Header, contextPrefix string) error {
 	if header.Name == "" {
 		return fmt.Errorf("%s: name is required", contextPrefix)
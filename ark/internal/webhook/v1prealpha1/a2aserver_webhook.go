@@ -78,6 +78,10 @@ func (v *A2AServerValidator) validateA2AServer(a2aServer *arkv1prealpha1.A2AServ
 		allErrs = append(allErrs, err)
 	}
 
+	if err := validationv1.ValidateAuth(a2aServer.Spec.Auth, "auth"); err != nil {
+		allErrs = append(allErrs, err)
+	}
+
 	// Validate PollInterval
 	if err := validationv1.ValidatePollInterval(a2aServer.Spec.PollInterval.Duration); err != nil {
 		allErrs = append(allErrs, err)
@@ -2,10 +2,12 @@ export default {
   a2aserver: 'A2AServers',
   agent: 'Agents',
   mcpserver: 'MCPServers',
+  openapiserver: 'OpenAPIServers',
   memory: 'Memories',
   models: 'Models',
   query: 'Queries',
   team: 'Teams',
   tools: 'Tools',
+  toolpolicy: 'Tool Policies',
   a2atask: 'A2ATask'
 }
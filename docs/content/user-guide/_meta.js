@@ -11,6 +11,7 @@ export default {
   queries: 'Creating Queries',
   tools: 'Creating Tools and MCP Servers',
   'ark-cli': 'The Ark CLI',
+  'mcp-clients': 'Using Agents from MCP Clients',
 
   '---patterns': { type: 'separator', title: 'Patterns and guidance' },
   'tips-on-building-agentic-use-cases': 'Tips on Building Agentic Use Cases',